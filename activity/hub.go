@@ -0,0 +1,127 @@
+// Package activity tells account watchers when new entries are committed.
+// Notifications only say that something changed; subscribers read the
+// entries themselves, so a missed or coalesced notification loses nothing.
+package activity
+
+import (
+	"sync"
+)
+
+// Subscription receives a signal whenever the watched account may have new
+// entries. C is closed when the hub stops.
+type Subscription struct {
+	C <-chan struct{}
+
+	hub       *Hub
+	accountID int64
+	signal    chan struct{}
+}
+
+// Close stops delivering signals to the subscription
+func (sub *Subscription) Close() {
+	sub.hub.unsubscribe(sub)
+}
+
+// Hub fans notifications out to the subscribers of each account
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[*Subscription]struct{}
+	stopped     bool
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[int64]map[*Subscription]struct{}{},
+	}
+}
+
+// Subscribe starts watching accountID
+func (hub *Hub) Subscribe(accountID int64) *Subscription {
+
+	signal := make(chan struct{}, 1)
+	sub := &Subscription{
+		C:         signal,
+		hub:       hub,
+		accountID: accountID,
+		signal:    signal,
+	}
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	if hub.stopped {
+		close(signal)
+		return sub
+	}
+
+	if hub.subscribers[accountID] == nil {
+		hub.subscribers[accountID] = map[*Subscription]struct{}{}
+	}
+	hub.subscribers[accountID][sub] = struct{}{}
+
+	return sub
+}
+
+func (hub *Hub) unsubscribe(sub *Subscription) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	subscribers, ok := hub.subscribers[sub.accountID]
+	if !ok {
+		return
+	}
+
+	if _, ok = subscribers[sub]; !ok {
+		return
+	}
+
+	delete(subscribers, sub)
+	if len(subscribers) == 0 {
+		delete(hub.subscribers, sub.accountID)
+	}
+	close(sub.signal)
+}
+
+// Notify signals every subscriber of accountID. It never blocks: a pending
+// signal already covers the new entry.
+func (hub *Hub) Notify(accountID int64) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for sub := range hub.subscribers[accountID] {
+		wake(sub)
+	}
+}
+
+// NotifyAll signals every subscriber, used when notifications may have been lost
+func (hub *Hub) NotifyAll() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for _, subscribers := range hub.subscribers {
+		for sub := range subscribers {
+			wake(sub)
+		}
+	}
+}
+
+// Stop closes every subscription so watchers can end their streams
+func (hub *Hub) Stop() {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	hub.stopped = true
+	for accountID, subscribers := range hub.subscribers {
+		for sub := range subscribers {
+			close(sub.signal)
+		}
+		delete(hub.subscribers, accountID)
+	}
+}
+
+func wake(sub *Subscription) {
+	select {
+	case sub.signal <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,75 @@
+package activity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func requireSignal(t *testing.T, sub *Subscription) {
+	select {
+	case _, ok := <-sub.C:
+		require.True(t, ok)
+	default:
+		t.Fatal("expected a signal")
+	}
+}
+
+func requireNoSignal(t *testing.T, sub *Subscription) {
+	select {
+	case <-sub.C:
+		t.Fatal("unexpected signal")
+	default:
+	}
+}
+
+func TestHubNotify(t *testing.T) {
+	hub := NewHub()
+
+	sub1 := hub.Subscribe(1)
+	sub2 := hub.Subscribe(2)
+	defer sub1.Close()
+	defer sub2.Close()
+
+	hub.Notify(1)
+	hub.Notify(1)
+
+	// signals are coalesced, the subscriber reads every new entry anyway
+	requireSignal(t, sub1)
+	requireNoSignal(t, sub1)
+	requireNoSignal(t, sub2)
+
+	hub.NotifyAll()
+	requireSignal(t, sub1)
+	requireSignal(t, sub2)
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub()
+
+	sub := hub.Subscribe(1)
+	sub.Close()
+	sub.Close()
+
+	_, ok := <-sub.C
+	require.False(t, ok)
+	require.Empty(t, hub.subscribers)
+
+	hub.Notify(1)
+}
+
+func TestHubStop(t *testing.T) {
+	hub := NewHub()
+
+	sub := hub.Subscribe(1)
+	hub.Stop()
+	sub.Close()
+
+	_, ok := <-sub.C
+	require.False(t, ok)
+
+	late := hub.Subscribe(1)
+	_, ok = <-late.C
+	require.False(t, ok)
+	late.Close()
+}
@@ -0,0 +1,76 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// Channel is the Postgres notification channel the entries trigger announces on
+const Channel = "account_activity"
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+
+	// pingInterval detects a dead connection when no notifications arrive
+	pingInterval = 90 * time.Second
+)
+
+// Notification is the payload sent by the entries trigger
+type Notification struct {
+	EntryID   int64 `json:"entry_id"`
+	AccountID int64 `json:"account_id"`
+}
+
+// Listen forwards Postgres notifications to hub until ctx is cancelled, then
+// stops the hub
+func Listen(ctx context.Context, dataSourceName string, hub *Hub) error {
+
+	defer hub.Stop()
+
+	listener := pq.NewListener(dataSourceName, minReconnectInterval, maxReconnectInterval,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Error().Err(err).Msg("account activity listener error")
+			}
+		})
+	defer listener.Close()
+
+	if err := listener.Listen(Channel); err != nil {
+		return fmt.Errorf("cannot listen on %s: %w", Channel, err)
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case n := <-listener.Notify:
+			// pq sends nil after reconnecting, when notifications may have been missed
+			if n == nil {
+				hub.NotifyAll()
+				continue
+			}
+
+			var notification Notification
+			if err := json.Unmarshal([]byte(n.Extra), &notification); err != nil {
+				log.Error().Err(err).Str("payload", n.Extra).Msg("invalid account activity notification")
+				continue
+			}
+			hub.Notify(notification.AccountID)
+
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				log.Error().Err(err).Msg("account activity listener ping failed")
+			}
+		}
+	}
+}
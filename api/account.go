@@ -3,6 +3,7 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -13,13 +14,33 @@ import (
 
 type CreateAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	Product  string `json:"product"`
 }
 
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	// the recipient is given by exactly one of account id, alias or saved beneficiary
+	ToAccountID     int64  `json:"to_account_id" binding:"omitempty,min=1"`
+	ToAlias         string `json:"to_alias"`
+	ToBeneficiaryID int64  `json:"to_beneficiary_id" binding:"omitempty,min=1"`
+	Amount          int64  `json:"amount" binding:"required,gt=0"`
+	Currency        string `json:"currency" binding:"required,currency"`
+	// details kept on the transfer, checked by validateTransferDetails
+	Memo      string            `json:"memo"`
+	Reference string            `json:"reference"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+type accountResponse struct {
+	db.Account
+	FormattedBalance string `json:"formatted_balance"`
+}
+
+func (server *Server) newAccountResponse(account db.Account) accountResponse {
+	return accountResponse{
+		Account:          account,
+		FormattedBalance: server.currencies.Format(account.Currency, account.Balance),
+	}
 }
 
 type getAccountRequest struct {
@@ -39,16 +60,35 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		return
 	}
 
+	if req.Product != "" {
+		if _, err := server.store.GetProduct(ctx, req.Product); err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("unknown product %q", req.Product)))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Currency: req.Currency,
+	arg := db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    authPayload.Username,
+			Currency: req.Currency,
+			Product:  req.Product,
+		},
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	account, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
 
+		if errors.Is(err, db.ErrKycAccountLimit) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
 			case "foreign_key_violation", "unique_violation":
@@ -60,7 +100,7 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, server.newAccountResponse(account))
 }
 
 func (server *Server) getAccount(ctx *gin.Context) {
@@ -83,12 +123,29 @@ func (server *Server) getAccount(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != account.Owner {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized access")))
+	if _, ok := server.memberRole(ctx, account, authPayload.Username); !ok {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, server.newAccountResponse(account))
+}
+
+// memberRole returns the role the user has on the account. When the user is
+// no member, or the role cannot be looked up, it answers the request itself.
+func (server *Server) memberRole(ctx *gin.Context, account db.Account, username string) (string, bool) {
+
+	role, err := db.MemberRole(ctx, server.store, account, username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return "", false
+	}
+
+	if role == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized access")))
+		return "", false
+	}
+
+	return role, true
 }
 
 func (server *Server) listAccount(ctx *gin.Context) {
@@ -105,9 +162,9 @@ func (server *Server) listAccount(ctx *gin.Context) {
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
 	arg := db.ListAccountsParams{
-		Owner: authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Username: authPayload.Username,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
 	}
 
 	accounts, err := server.store.ListAccounts(ctx, arg)
@@ -116,6 +173,22 @@ func (server *Server) listAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
+	res := make([]accountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		res = append(res, server.newAccountResponse(account))
+	}
+
+	ctx.JSON(http.StatusOK, res)
 
-}
\ No newline at end of file
+}
+
+func (server *Server) listProducts(ctx *gin.Context) {
+
+	products, err := server.store.ListProducts(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, products)
+}
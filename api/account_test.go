@@ -109,6 +109,10 @@ func TestGetAccount(t *testing.T) {
 					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
 					Times(1).
 					Return(account, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: account.ID, Username: "unauthorized_user"})).
+					Times(1).
+					Return(db.AccountMember{}, sql.ErrNoRows)
 			},
 			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, rr.Code)
@@ -118,6 +122,28 @@ func TestGetAccount(t *testing.T) {
 			},
 		},
 
+		{
+			name:    "joint account viewer",
+			account: account.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: account.ID, Username: "joint_viewer"})).
+					Times(1).
+					Return(db.AccountMember{AccountID: account.ID, Username: "joint_viewer", Role: db.MemberRoleViewer, Status: db.MemberStatusActive}, nil)
+			},
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				requireBodyMatchAccount(t, rr.Body, account)
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, "joint_viewer", time.Minute)
+			},
+		},
+
 		{
 			name:    "no authorization header",
 			account: account.ID,
@@ -192,9 +218,9 @@ func TestListAccount(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListAccountsParams{
-					Owner: user.Username,
-					Limit: int32(n),
-					Offset: 0,
+					Username: user.Username,
+					Limit:    int32(n),
+					Offset:   0,
 				}
 
 				store.EXPECT(). 
@@ -230,9 +256,9 @@ func TestListAccount(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListAccountsParams{
-					Owner: user.Username,
-					Limit: int32(n),
-					Offset: 0,
+					Username: user.Username,
+					Limit:    int32(n),
+					Offset:   0,
 				}
 
 				store.EXPECT(). 
@@ -315,13 +341,13 @@ func TestCreateAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer,  user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateAccountParams{
+				arg := db.CreateAccountTxParams{CreateAccountParams: db.CreateAccountParams{
 					Owner:    user.Username,
 					Currency: account.Currency,
-				}
+				}}
 
 				store.EXPECT().
-					CreateAccount(gomock.Any(), gomock.Eq(arg)). 
+					CreateAccountTx(gomock.Any(), gomock.Eq(arg)). 
 					Times(1). 
 					Return(account, nil)
 			},
@@ -340,13 +366,13 @@ func TestCreateAccountAPI(t *testing.T) {
 				addAuthorization(t, req, tokenMaker, authorizationTypeBearer,  user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateAccountParams{
+				arg := db.CreateAccountTxParams{CreateAccountParams: db.CreateAccountParams{
 					Owner:    user.Username,
 					Currency: account.Currency,
-				}
+				}}
 
 				store.EXPECT().
-					CreateAccount(gomock.Any(), gomock.Eq(arg)). 
+					CreateAccountTx(gomock.Any(), gomock.Eq(arg)). 
 					Times(1). 
 					Return(db.Account{}, &pq.Error{Code: "23505"})
 			},
@@ -357,6 +383,80 @@ func TestCreateAccountAPI(t *testing.T) {
 		},
 
 
+		{
+			name: "KycAccountLimit",
+			body: gin.H{
+				"currency": account.Currency,
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Account{}, db.ErrKycAccountLimit)
+			},
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rr.Code)
+			},
+		},
+
+		{
+			name: "SavingsProduct",
+			body: gin.H{
+				"currency": account.Currency,
+				"product":  "savings",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq("savings")).
+					Times(1).
+					Return(db.Product{Code: "savings", Name: "Savings", AnnualRateBps: 250, DayCount: "actual/365"}, nil)
+
+				arg := db.CreateAccountTxParams{CreateAccountParams: db.CreateAccountParams{
+					Owner:    user.Username,
+					Currency: account.Currency,
+					Product:  "savings",
+				}}
+
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+			},
+		},
+
+		{
+			name: "UnknownProduct",
+			body: gin.H{
+				"currency": account.Currency,
+				"product":  "gold",
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq("gold")).
+					Times(1).
+					Return(db.Product{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+
 		{
 			name: "NoAuthorization",
 			body: gin.H{
@@ -366,7 +466,7 @@ func TestCreateAccountAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateAccount(gomock.Any(), gomock.Any()).
+					CreateAccountTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
@@ -384,7 +484,7 @@ func TestCreateAccountAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateAccount(gomock.Any(), gomock.Any()).
+					CreateAccountTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.Account{}, sql.ErrConnDone)
 			},
@@ -403,7 +503,7 @@ func TestCreateAccountAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					CreateAccount(gomock.Any(), gomock.Any()).
+					CreateAccountTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
@@ -472,4 +572,29 @@ func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Ac
 	err = json.Unmarshal(data, &gotAccounts)
 	require.NoError(t, err)
 	require.Equal(t, accounts, gotAccounts)
-}
\ No newline at end of file
+}
+func TestGetAccountFormattedBalance(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+	account := db.Account{ID: 7, Owner: user.Username, Balance: 123456, Currency: util.USD}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+	server := newTestServer(t, store)
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d", account.ID), nil)
+	require.NoError(t, err)
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Equal(t, "$1,234.56", body["formatted_balance"])
+	require.Equal(t, float64(account.Balance), body["balance"])
+}
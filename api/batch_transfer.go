@@ -0,0 +1,389 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/risk"
+	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/val"
+	"github.com/lib/pq"
+)
+
+const (
+	maxBatchTransferRows  = 1000
+	maxReferenceLength    = 140
+	idempotencyKeyHeader  = "Idempotency-Key"
+	csvContentType        = "text/csv"
+	batchTransferCSVUsage = "expected a header of to_account_id,amount,reference"
+)
+
+type batchTransferRequest struct {
+	FromAccountID int64                 `json:"from_account_id" form:"from_account_id" binding:"required,min=1"`
+	Mode          string                `json:"mode" form:"mode" binding:"required,oneof=all_or_nothing best_effort"`
+	Rows          []db.BatchTransferRow `json:"rows" form:"-"`
+}
+
+type getBatchTransferRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listBatchTransferItemsRequest struct {
+	PageID   int32 `form:"page_id" binding:"min=1"`
+	PageSize int32 `form:"page_size" binding:"min=5,max=1000"`
+}
+
+type batchRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type batchTransferErrorResponse struct {
+	Error string          `json:"error"`
+	Rows  []batchRowError `json:"rows"`
+}
+
+// createBatchTransfer accepts a list of payouts from one account as JSON or
+// as a CSV body, validates and screens every row and queues the batch for
+// processing. Rows the risk screener does not allow are failed in the batch.
+func (server *Server) createBatchTransfer(ctx *gin.Context) {
+
+	req, err := bindBatchTransferRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	fromAccount, err := server.store.GetAccount(ctx, req.FromAccountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	role, ok := server.memberRole(ctx, fromAccount, authPayload.Username)
+	if !ok {
+		return
+	}
+	if !db.CanSign(role) {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(db.ErrNotSigner))
+		return
+	}
+
+	idempotencyKey := sql.NullString{
+		String: ctx.GetHeader(idempotencyKeyHeader),
+		Valid:  ctx.GetHeader(idempotencyKeyHeader) != "",
+	}
+
+	if idempotencyKey.Valid {
+		batch, err := server.store.GetBatchTransferByIdempotencyKey(ctx, db.GetBatchTransferByIdempotencyKeyParams{
+			Owner:          authPayload.Username,
+			IdempotencyKey: idempotencyKey,
+		})
+		if err == nil {
+			ctx.JSON(http.StatusOK, batch)
+			return
+		}
+		if err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	rowErrors, err := server.validateBatchTransferRows(ctx, fromAccount, req.Rows)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		ctx.JSON(http.StatusBadRequest, batchTransferErrorResponse{
+			Error: "invalid rows",
+			Rows:  rowErrors,
+		})
+		return
+	}
+
+	amounts := make([]int64, len(req.Rows))
+	for i, row := range req.Rows {
+		amounts[i] = row.Amount
+	}
+
+	// every row pays its own fee, so the fees count towards the total
+	fees, err := server.store.PreviewTransferFees(ctx, fromAccount.ID, amounts)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var totalAmount, totalFee int64
+	for i := range amounts {
+		totalAmount += amounts[i]
+		totalFee += fees[i].Total
+	}
+
+	if totalAmount+totalFee > fromAccount.Balance {
+		err := fmt.Errorf("batch total %d plus fees of %d exceeds the balance of account [%d]", totalAmount, totalFee, fromAccount.ID)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.screener.BatchTransfer(ctx, risk.Request{
+		Username:      authPayload.Username,
+		FromAccountID: fromAccount.ID,
+		Currency:      fromAccount.Currency,
+		UserAgent:     ctx.Request.UserAgent(),
+		ClientIP:      ctx.ClientIP(),
+	}, db.CreateBatchTransferTxParams{
+		Owner:          authPayload.Username,
+		FromAccountID:  fromAccount.ID,
+		Mode:           req.Mode,
+		IdempotencyKey: idempotencyKey,
+		Rows:           req.Rows,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errors.New("a batch with this idempotency key is already being created")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, result.Batch)
+}
+
+func bindBatchTransferRequest(ctx *gin.Context) (batchTransferRequest, error) {
+
+	var req batchTransferRequest
+
+	if ctx.ContentType() != csvContentType {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			return req, err
+		}
+		return req, nil
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return req, err
+	}
+
+	rows, err := parseBatchTransferCSV(ctx.Request.Body)
+	if err != nil {
+		return req, err
+	}
+	req.Rows = rows
+
+	return req, nil
+}
+
+// parseBatchTransferCSV reads to_account_id,amount,reference rows after a header line
+func parseBatchTransferCSV(body io.Reader) ([]db.BatchTransferRow, error) {
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %s", batchTransferCSVUsage)
+	}
+
+	expected := []string{"to_account_id", "amount", "reference"}
+	for i, column := range header {
+		if strings.ToLower(strings.TrimSpace(column)) != expected[i] {
+			return nil, fmt.Errorf("invalid csv header: %s", batchTransferCSVUsage)
+		}
+	}
+
+	var rows []db.BatchTransferRow
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		toAccountID, err := strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid to_account_id %q", line, record[0])
+		}
+
+		amount, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid amount %q", line, record[1])
+		}
+
+		rows = append(rows, db.BatchTransferRow{
+			ToAccountID: toAccountID,
+			Amount:      amount,
+			Reference:   strings.TrimSpace(record[2]),
+		})
+	}
+
+	return rows, nil
+}
+
+// validateBatchTransferRows checks every row before anything is stored so a
+// batch is either accepted as a whole or rejected with all its problems
+func (server *Server) validateBatchTransferRows(ctx *gin.Context, fromAccount db.Account, rows []db.BatchTransferRow) ([]batchRowError, error) {
+
+	if len(rows) == 0 || len(rows) > maxBatchTransferRows {
+		return []batchRowError{{Error: fmt.Sprintf("a batch must contain 1-%d rows", maxBatchTransferRows)}}, nil
+	}
+
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, row := range rows {
+		if row.ToAccountID > 0 && !seen[row.ToAccountID] {
+			seen[row.ToAccountID] = true
+			ids = append(ids, row.ToAccountID)
+		}
+	}
+
+	accounts, err := server.store.ListAccountsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations := make(map[int64]db.Account, len(accounts))
+	for _, account := range accounts {
+		destinations[account.ID] = account
+	}
+
+	var rowErrors []batchRowError
+	references := map[string]int{}
+
+	for i, row := range rows {
+		rowNumber := i + 1
+		addError := func(format string, args ...interface{}) {
+			rowErrors = append(rowErrors, batchRowError{Row: rowNumber, Error: fmt.Sprintf(format, args...)})
+		}
+
+		if row.Amount <= 0 {
+			addError("amount must be positive")
+		} else if fromAccount.ApprovalThreshold > 0 && row.Amount >= fromAccount.ApprovalThreshold {
+			// batches are not approved by a second signer, so they cannot
+			// carry what a single transfer would need approval for
+			addError("amount reaches the approval threshold of %d, send it as a transfer", fromAccount.ApprovalThreshold)
+		}
+
+		if err := val.ValidateString(row.Reference, 1, maxReferenceLength); err != nil {
+			addError("reference %s", err)
+		} else if first, ok := references[row.Reference]; ok {
+			addError("reference is a duplicate of row %d", first)
+		} else {
+			references[row.Reference] = rowNumber
+		}
+
+		if row.ToAccountID == fromAccount.ID {
+			addError("destination is the source account")
+			continue
+		}
+
+		destination, ok := destinations[row.ToAccountID]
+		if !ok || destination.Kind == db.AccountKindGL {
+			addError("account [%d] not found", row.ToAccountID)
+			continue
+		}
+
+		if destination.Currency != fromAccount.Currency {
+			addError("account [%d] currency mismatch: %s vs %s", destination.ID, destination.Currency, fromAccount.Currency)
+		}
+	}
+
+	return rowErrors, nil
+}
+
+func (server *Server) getBatchTransfer(ctx *gin.Context) {
+
+	batch, ok := server.authorizedBatchTransfer(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, batch)
+}
+
+// listBatchTransferItems returns the per-row report of a batch
+func (server *Server) listBatchTransferItems(ctx *gin.Context) {
+
+	batch, ok := server.authorizedBatchTransfer(ctx)
+	if !ok {
+		return
+	}
+
+	var req = listBatchTransferItemsRequest{
+		PageID:   1,
+		PageSize: 100,
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListBatchTransferItems(ctx, db.ListBatchTransferItemsParams{
+		BatchID: batch.ID,
+		Limit:   req.PageSize,
+		Offset:  (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+// authorizedBatchTransfer gets the batch of the request for a user who can
+// sign for its source account, like the one who created it
+func (server *Server) authorizedBatchTransfer(ctx *gin.Context) (db.BatchTransfer, bool) {
+
+	var req getBatchTransferRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.BatchTransfer{}, false
+	}
+
+	batch, err := server.store.GetBatchTransfer(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return batch, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return batch, false
+	}
+
+	fromAccount, err := server.store.GetAccount(ctx, batch.FromAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return batch, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	role, ok := server.memberRole(ctx, fromAccount, authPayload.Username)
+	if !ok {
+		return batch, false
+	}
+	if !db.CanSign(role) {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(db.ErrNotSigner))
+		return batch, false
+	}
+
+	return batch, true
+}
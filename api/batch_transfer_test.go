@@ -0,0 +1,514 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBatchTransferAPI(t *testing.T) {
+
+	user1, _ := createRandomUser(t)
+	user2, _ := createRandomUser(t)
+
+	fromAccount := db.Account{ID: 1, Owner: user1.Username, Balance: 1000, Currency: util.USD}
+	account2 := db.Account{ID: 2, Owner: user2.Username, Balance: 0, Currency: util.USD}
+	account3 := db.Account{ID: 3, Owner: user2.Username, Balance: 0, Currency: util.EUR}
+
+	rows := []db.BatchTransferRow{
+		{ToAccountID: account2.ID, Amount: 100, Reference: "salary january"},
+		{ToAccountID: account2.ID, Amount: 200, Reference: "bonus january"},
+	}
+	batch := db.BatchTransfer{
+		ID:            7,
+		Owner:         user1.Username,
+		FromAccountID: fromAccount.ID,
+		Mode:          db.BatchModeBestEffort,
+		Status:        db.BatchStatusPending,
+		TotalCount:    2,
+		TotalAmount:   300,
+	}
+
+	testCases := []struct {
+		name          string
+		contentType   string
+		query         string
+		body          string
+		header        map[string]string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            db.BatchModeBestEffort,
+				"rows":            rows,
+			}),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Eq([]int64{account2.ID})).Times(1).Return([]db.Account{account2}, nil)
+				store.EXPECT().
+					PreviewTransferFees(gomock.Any(), gomock.Eq(fromAccount.ID), gomock.Eq([]int64{100, 200})).
+					Times(1).
+					Return([]db.Fee{{Total: 1}, {Total: 2}}, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(2)
+				store.EXPECT().
+					CreateBatchTransferTx(gomock.Any(), batchTransferMatcher{
+						arg: db.CreateBatchTransferTxParams{
+							Owner:         user1.Username,
+							FromAccountID: fromAccount.ID,
+							Mode:          db.BatchModeBestEffort,
+							Rows:          rows,
+						},
+						decisions: []string{db.RiskDecisionAllow, db.RiskDecisionAllow},
+					}).
+					Times(1).
+					Return(db.CreateBatchTransferTxResult{Batch: batch}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				requireBodyMatchBatchTransfer(t, recorder.Body, batch)
+			},
+		},
+		{
+			name: "HeldRow",
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            db.BatchModeBestEffort,
+				"rows":            rows,
+			}),
+			header: map[string]string{"User-Agent": "payroll/1.0"},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2}, nil)
+				store.EXPECT().PreviewTransferFees(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Fee{{}, {}}, nil)
+				// the first row is paid to a known payee, the second one is large for a new payee
+				gomock.InOrder(
+					store.EXPECT().
+						GetTransferRiskSignals(gomock.Any(), gomock.Any()).
+						Return(db.GetTransferRiskSignalsRow{PayeeTransfers: 1, LargeAmount: 200}, nil),
+					store.EXPECT().
+						GetTransferRiskSignals(gomock.Any(), gomock.Any()).
+						Return(db.GetTransferRiskSignalsRow{LargeAmount: 200}, nil),
+				)
+				store.EXPECT().
+					CreateBatchTransferTx(gomock.Any(), batchTransferMatcher{
+						arg: db.CreateBatchTransferTxParams{
+							Owner:         user1.Username,
+							FromAccountID: fromAccount.ID,
+							Mode:          db.BatchModeBestEffort,
+							Rows:          rows,
+						},
+						decisions: []string{db.RiskDecisionAllow, db.RiskDecisionReview},
+						userAgent: "payroll/1.0",
+					}).
+					Times(1).
+					Return(db.CreateBatchTransferTxResult{Batch: batch}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:        "OKFromCSV",
+			contentType: "text/csv",
+			query:       fmt.Sprintf("?from_account_id=%d&mode=%s", fromAccount.ID, db.BatchModeAllOrNothing),
+			body:        "to_account_id,amount,reference\n2,100,salary january\n2,200,bonus january\n",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2}, nil)
+				store.EXPECT().PreviewTransferFees(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Fee{{}, {}}, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(2)
+				store.EXPECT().
+					CreateBatchTransferTx(gomock.Any(), batchTransferMatcher{
+						arg: db.CreateBatchTransferTxParams{
+							Owner:         user1.Username,
+							FromAccountID: fromAccount.ID,
+							Mode:          db.BatchModeAllOrNothing,
+							Rows:          rows,
+						},
+						decisions: []string{db.RiskDecisionAllow, db.RiskDecisionAllow},
+					}).
+					Times(1).
+					Return(db.CreateBatchTransferTxResult{Batch: batch}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRows",
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            db.BatchModeBestEffort,
+				"rows": []db.BatchTransferRow{
+					{ToAccountID: account2.ID, Amount: 100, Reference: "salary"},
+					{ToAccountID: account3.ID, Amount: 100, Reference: "salary"},
+					{ToAccountID: 99, Amount: 0, Reference: "missing"},
+					{ToAccountID: fromAccount.ID, Amount: 10, Reference: "self"},
+				},
+			}),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2, account3}, nil)
+				store.EXPECT().CreateBatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var res batchTransferErrorResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, []batchRowError{
+					{Row: 2, Error: "reference is a duplicate of row 1"},
+					{Row: 2, Error: "account [3] currency mismatch: EUR vs USD"},
+					{Row: 3, Error: "amount must be positive"},
+					{Row: 3, Error: "account [99] not found"},
+					{Row: 4, Error: "destination is the source account"},
+				}, res.Rows)
+			},
+		},
+		{
+			name: "InsufficientBalance",
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            db.BatchModeBestEffort,
+				"rows": []db.BatchTransferRow{
+					{ToAccountID: account2.ID, Amount: 1001, Reference: "salary"},
+				},
+			}),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2}, nil)
+				store.EXPECT().PreviewTransferFees(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Fee{{}}, nil)
+				store.EXPECT().CreateBatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientBalanceForFees",
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            db.BatchModeBestEffort,
+				"rows": []db.BatchTransferRow{
+					{ToAccountID: account2.ID, Amount: 500, Reference: "salary"},
+					{ToAccountID: account2.ID, Amount: 490, Reference: "bonus"},
+				},
+			}),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2}, nil)
+				// the amounts fit the balance of 1000, the fees do not
+				store.EXPECT().
+					PreviewTransferFees(gomock.Any(), gomock.Eq(fromAccount.ID), gomock.Eq([]int64{500, 490})).
+					Times(1).
+					Return([]db.Fee{{Total: 5}, {Total: 6}}, nil)
+				store.EXPECT().CreateBatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "plus fees of 11")
+			},
+		},
+		{
+			name:   "IdempotentReplay",
+			header: map[string]string{idempotencyKeyHeader: "payroll-2023-01"},
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            db.BatchModeBestEffort,
+				"rows":            rows,
+			}),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().
+					GetBatchTransferByIdempotencyKey(gomock.Any(), gomock.Eq(db.GetBatchTransferByIdempotencyKeyParams{
+						Owner:          user1.Username,
+						IdempotencyKey: sql.NullString{String: "payroll-2023-01", Valid: true},
+					})).
+					Times(1).
+					Return(batch, nil)
+				store.EXPECT().CreateBatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBatchTransfer(t, recorder.Body, batch)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            db.BatchModeBestEffort,
+				"rows":            rows,
+			}),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user2.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().CreateBatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidMode",
+			body: jsonBody(t, gin.H{
+				"from_account_id": fromAccount.ID,
+				"mode":            "sometimes",
+				"rows":            rows,
+			}),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/batch_transfers"+tc.query, strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			if tc.contentType != "" {
+				request.Header.Set("Content-Type", tc.contentType)
+			}
+			for key, value := range tc.header {
+				request.Header.Set(key, value)
+			}
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetBatchTransferAPI(t *testing.T) {
+
+	user1, _ := createRandomUser(t)
+	user2, _ := createRandomUser(t)
+
+	fromAccount := db.Account{ID: 1, Owner: user1.Username, Balance: 1000, Currency: util.USD}
+	batch := db.BatchTransfer{
+		ID:            7,
+		Owner:         user1.Username,
+		FromAccountID: fromAccount.ID,
+		Mode:          db.BatchModeBestEffort,
+		Status:        db.BatchStatusPending,
+		TotalCount:    2,
+		TotalAmount:   300,
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBatchTransfer(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBatchTransfer(t, recorder.Body, batch)
+			},
+		},
+		{
+			name:     "JointAccountSigner",
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBatchTransfer(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountMember{AccountID: fromAccount.ID, Username: user2.Username, Role: db.MemberRoleSigner, Status: db.MemberStatusActive}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBatchTransfer(t, recorder.Body, batch)
+			},
+		},
+		{
+			name:     "JointAccountViewer",
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBatchTransfer(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountMember{AccountID: fromAccount.ID, Username: user2.Username, Role: db.MemberRoleViewer, Status: db.MemberStatusActive}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBatchTransfer(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBatchTransfer(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(db.BatchTransfer{}, sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/batch_transfers/%d", batch.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestParseBatchTransferCSV(t *testing.T) {
+
+	rows, err := parseBatchTransferCSV(strings.NewReader("To_Account_ID, amount, reference\n5, 10, \"rent, march\"\n"))
+	require.NoError(t, err)
+	require.Equal(t, []db.BatchTransferRow{{ToAccountID: 5, Amount: 10, Reference: "rent, march"}}, rows)
+
+	_, err = parseBatchTransferCSV(strings.NewReader("account,amount,reference\n5,10,rent\n"))
+	require.ErrorContains(t, err, "invalid csv header")
+
+	_, err = parseBatchTransferCSV(strings.NewReader("to_account_id,amount,reference\n5,ten,rent\n"))
+	require.EqualError(t, err, `row 1: invalid amount "ten"`)
+}
+
+func jsonBody(t *testing.T, body gin.H) string {
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func requireBodyMatchBatchTransfer(t *testing.T, body *bytes.Buffer, batch db.BatchTransfer) {
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotBatch db.BatchTransfer
+	err = json.Unmarshal(data, &gotBatch)
+	require.NoError(t, err)
+	require.Equal(t, batch, gotBatch)
+}
+
+// batchTransferMatcher matches a batch with the decisions of its rows, made
+// for a request with the user agent
+type batchTransferMatcher struct {
+	arg       db.CreateBatchTransferTxParams
+	decisions []string
+	userAgent string
+}
+
+func (expected batchTransferMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateBatchTransferTxParams)
+	if !ok || len(arg.Screenings) != len(expected.decisions) {
+		return false
+	}
+
+	for i, screening := range arg.Screenings {
+		if screening.Decision != expected.decisions[i] || screening.UserAgent != expected.userAgent {
+			return false
+		}
+	}
+
+	arg.Screenings = nil
+	return reflect.DeepEqual(expected.arg, arg)
+}
+
+func (expected batchTransferMatcher) String() string {
+	return fmt.Sprintf("batch %v with decisions %v", expected.arg, expected.decisions)
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/util"
+	"github.com/lib/pq"
+)
+
+type lookupRecipientRequest struct {
+	Alias    string `form:"alias" binding:"required"`
+	Currency string `form:"currency" binding:"required,currency"`
+}
+
+type createBeneficiaryRequest struct {
+	Nickname string `json:"nickname" binding:"required,max=50"`
+	// the account is given either by id or by alias and currency
+	AccountID int64  `json:"account_id" binding:"omitempty,min=1"`
+	Alias     string `json:"alias"`
+	Currency  string `json:"currency" binding:"omitempty,currency"`
+}
+
+type deleteBeneficiaryRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type beneficiaryResponse struct {
+	ID         int64     `json:"id"`
+	Nickname   string    `json:"nickname"`
+	AccountID  int64     `json:"account_id"`
+	Currency   string    `json:"currency"`
+	MaskedName string    `json:"masked_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// lookupRecipient shows the sender the masked name of who an alias belongs
+// to, so they can confirm it before sending money
+func (server *Server) lookupRecipient(ctx *gin.Context) {
+
+	var req lookupRecipientRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	recipient, err := db.ResolveRecipient(ctx, server.store, req.Alias, req.Currency)
+	if err != nil {
+		if errors.Is(err, db.ErrRecipientNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recipient)
+}
+
+func (server *Server) createBeneficiary(ctx *gin.Context) {
+
+	var req createBeneficiaryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if (req.AccountID == 0) == (req.Alias == "") {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("either account_id or alias is required")))
+		return
+	}
+
+	accountID := req.AccountID
+	if req.Alias != "" {
+		if req.Currency == "" {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("currency is required with alias")))
+			return
+		}
+
+		recipient, err := db.ResolveRecipient(ctx, server.store, req.Alias, req.Currency)
+		if err != nil {
+			if errors.Is(err, db.ErrRecipientNotFound) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		accountID = recipient.AccountID
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	beneficiary, err := server.store.CreateBeneficiary(ctx, db.CreateBeneficiaryParams{
+		Owner:     authPayload.Username,
+		Nickname:  req.Nickname,
+		AccountID: accountID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// read it back for the name and currency of the account
+	row, err := server.store.GetBeneficiary(ctx, db.GetBeneficiaryParams{
+		ID:    beneficiary.ID,
+		Owner: beneficiary.Owner,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newBeneficiaryResponse(db.ListBeneficiariesRow(row)))
+}
+
+func (server *Server) listBeneficiaries(ctx *gin.Context) {
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	beneficiaries, err := server.store.ListBeneficiaries(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := make([]beneficiaryResponse, 0, len(beneficiaries))
+	for _, beneficiary := range beneficiaries {
+		res = append(res, newBeneficiaryResponse(beneficiary))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (server *Server) deleteBeneficiary(ctx *gin.Context) {
+
+	var req deleteBeneficiaryRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	deleted, err := server.store.DeleteBeneficiary(ctx, db.DeleteBeneficiaryParams{
+		ID:    req.ID,
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func newBeneficiaryResponse(beneficiary db.ListBeneficiariesRow) beneficiaryResponse {
+	return beneficiaryResponse{
+		ID:         beneficiary.ID,
+		Nickname:   beneficiary.Nickname,
+		AccountID:  beneficiary.AccountID,
+		Currency:   beneficiary.Currency,
+		MaskedName: util.MaskName(beneficiary.FullName),
+		CreatedAt:  beneficiary.CreatedAt,
+	}
+}
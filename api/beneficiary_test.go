@@ -0,0 +1,267 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupRecipientAPI(t *testing.T) {
+
+	user1, _ := createRandomUser(t)
+	user2, _ := createRandomUser(t)
+	account2 := randomAccount(user2.Username)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Username",
+			query: fmt.Sprintf("alias=%s&currency=%s", user2.Username, util.USD),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetVerifiedAliasUsername(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					GetRecipientAccount(gomock.Any(), gomock.Eq(db.GetRecipientAccountParams{Owner: user2.Username, Currency: util.USD})).
+					Times(1).
+					Return(db.GetRecipientAccountRow{ID: account2.ID, Owner: user2.Username, Currency: util.USD, FullName: "Ada Lovelace"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var recipient db.Recipient
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &recipient))
+				require.Equal(t, db.Recipient{AccountID: account2.ID, Currency: util.USD, MaskedName: "A** L*******"}, recipient)
+				require.NotContains(t, recorder.Body.String(), user2.Username)
+			},
+		},
+		{
+			name:  "NoAccountInCurrency",
+			query: fmt.Sprintf("alias=%s&currency=%s", user2.Username, util.EUR),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecipientAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.GetRecipientAccountRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingCurrency",
+			query: fmt.Sprintf("alias=%s", user2.Username),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRecipientAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/recipients?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateBeneficiaryAPI(t *testing.T) {
+
+	user1, _ := createRandomUser(t)
+	user2, _ := createRandomUser(t)
+	account2 := randomAccount(user2.Username)
+
+	beneficiary := db.Beneficiary{ID: 3, Owner: user1.Username, Nickname: "landlord", AccountID: account2.ID, CreatedAt: time.Now()}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ByAlias",
+			body: gin.H{"nickname": "landlord", "alias": "+1 555 0100", "currency": util.USD},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetVerifiedAliasUsername(gomock.Any(), gomock.Eq(db.GetVerifiedAliasUsernameParams{Kind: db.AliasKindPhone, Value: "+15550100"})).
+					Times(1).
+					Return(user2.Username, nil)
+				store.EXPECT().
+					GetRecipientAccount(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetRecipientAccountRow{ID: account2.ID, Owner: user2.Username, Currency: util.USD, FullName: "Ada Lovelace"}, nil)
+				store.EXPECT().
+					CreateBeneficiary(gomock.Any(), gomock.Eq(db.CreateBeneficiaryParams{Owner: user1.Username, Nickname: "landlord", AccountID: account2.ID})).
+					Times(1).
+					Return(beneficiary, nil)
+				store.EXPECT().
+					GetBeneficiary(gomock.Any(), gomock.Eq(db.GetBeneficiaryParams{ID: beneficiary.ID, Owner: user1.Username})).
+					Times(1).
+					Return(db.GetBeneficiaryRow{
+						ID:           beneficiary.ID,
+						Owner:        user1.Username,
+						Nickname:     beneficiary.Nickname,
+						AccountID:    account2.ID,
+						CreatedAt:    beneficiary.CreatedAt,
+						AccountOwner: user2.Username,
+						Currency:     util.USD,
+						FullName:     "Ada Lovelace",
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res beneficiaryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, beneficiary.ID, res.ID)
+				require.Equal(t, account2.ID, res.AccountID)
+				require.Equal(t, "A** L*******", res.MaskedName)
+			},
+		},
+		{
+			name: "DuplicateNickname",
+			body: gin.H{"nickname": "landlord", "account_id": account2.ID},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Times(1).Return(db.Beneficiary{}, &pq.Error{Code: "23505"})
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AliasWithoutCurrency",
+			body: gin.H{"nickname": "landlord", "alias": user2.Username},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AccountAndAlias",
+			body: gin.H{"nickname": "landlord", "account_id": account2.ID, "alias": user2.Username, "currency": util.USD},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{"nickname": "landlord", "account_id": account2.ID},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/beneficiaries", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteBeneficiaryAPI(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		DeleteBeneficiary(gomock.Any(), gomock.Eq(db.DeleteBeneficiaryParams{ID: 3, Owner: user.Username})).
+		Times(1).
+		Return(int64(1), nil)
+	// someone else's beneficiary is not found
+	store.EXPECT().
+		DeleteBeneficiary(gomock.Any(), gomock.Eq(db.DeleteBeneficiaryParams{ID: 4, Owner: user.Username})).
+		Times(1).
+		Return(int64(0), nil)
+
+	server := newTestServer(t, store)
+
+	for id, code := range map[int64]int{3: http.StatusNoContent, 4: http.StatusNotFound} {
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/beneficiaries/%d", id), nil)
+		require.NoError(t, err)
+
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, code, recorder.Code)
+	}
+}
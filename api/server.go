@@ -2,11 +2,14 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/joefazee/simplebank/currency"
 	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/risk"
 	"github.com/joefazee/simplebank/token"
 	"github.com/joefazee/simplebank/util"
 )
@@ -17,9 +20,11 @@ type Server struct {
 	router     *gin.Engine
 	tokenMaker token.Maker
 	config     util.Config
+	screener   *risk.Screener
+	currencies *currency.Registry
 }
 
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+func NewServer(config util.Config, store db.Store, currencies *currency.Registry) (*Server, error) {
 
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
@@ -30,12 +35,14 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		store:      store,
 		tokenMaker: tokenMaker,
 		config:     config,
+		screener:   risk.NewScreener(config, store),
+		currencies: currencies,
 	}
 
 	
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("currency", validCurrency(currencies))
 	}
 
 	server.setupRouter()
@@ -56,7 +63,17 @@ func (server *Server) setupRouter() {
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts", server.listAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
+	authRoutes.GET("/accounts/:id/statement", server.getAccountStatement)
+	authRoutes.GET("/products", server.listProducts)
 	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.GET("/transfers", server.searchTransfers)
+	authRoutes.GET("/recipients", server.lookupRecipient)
+	authRoutes.POST("/beneficiaries", server.createBeneficiary)
+	authRoutes.GET("/beneficiaries", server.listBeneficiaries)
+	authRoutes.DELETE("/beneficiaries/:id", server.deleteBeneficiary)
+	authRoutes.POST("/batch_transfers", server.createBatchTransfer)
+	authRoutes.GET("/batch_transfers/:id", server.getBatchTransfer)
+	authRoutes.GET("/batch_transfers/:id/items", server.listBatchTransferItems)
 
 	
 
@@ -67,6 +84,11 @@ func (server *Server) Start(address string) error {
 	return server.router.Run(address)
 }
 
+// ServeHTTP lets the server be used as the handler of an http.Server
+func (server *Server) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	server.router.ServeHTTP(res, req)
+}
+
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/joefazee/simplebank/currency"
 	db "github.com/joefazee/simplebank/db/sqlc"
 	"github.com/joefazee/simplebank/util"
 	"github.com/stretchr/testify/require"
@@ -17,12 +18,20 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store)
+	server, err := NewServer(config, store, testCurrencies)
 	require.NoError(t, err)
 
 	return server
 }
 
+// testCurrencies are the currencies util.RandomCurrency picks from
+var testCurrencies = currency.NewRegistry(
+	currency.Currency{Code: util.USD, Name: "US Dollar", MinorUnits: 2, Symbol: "$", Enabled: true},
+	currency.Currency{Code: util.EUR, Name: "Euro", MinorUnits: 2, Symbol: "€", Enabled: true},
+	currency.Currency{Code: util.NGN, Name: "Naira", MinorUnits: 2, Symbol: "₦", Enabled: true},
+	currency.Currency{Code: util.CAD, Name: "Canadian Dollar", MinorUnits: 2, Symbol: "CA$", Enabled: true},
+)
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/util"
+)
+
+const defaultStatementPeriod = 30 * 24 * time.Hour
+
+type getAccountStatementRequest struct {
+	// the statement covers [from, to); the last 30 days when not set
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	PageID   int32     `form:"page_id" binding:"min=1"`
+	PageSize int32     `form:"page_size" binding:"min=5,max=100"`
+}
+
+type statementEntryResponse struct {
+	ID              int64     `json:"id"`
+	Amount          int64     `json:"amount"`
+	FormattedAmount string    `json:"formatted_amount"`
+	EntryType       string    `json:"entry_type"`
+	TransferID      int64     `json:"transfer_id,omitempty"`
+	CorrectsEntryID int64     `json:"corrects_entry_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	// the other account of a transfer entry and the masked name of its owner
+	CounterpartyAccountID int64  `json:"counterparty_account_id,omitempty"`
+	CounterpartyName      string `json:"counterparty_name,omitempty"`
+}
+
+type accountStatementResponse struct {
+	AccountID int64                    `json:"account_id"`
+	Currency  string                   `json:"currency"`
+	From      time.Time                `json:"from"`
+	To        time.Time                `json:"to"`
+	Entries   []statementEntryResponse `json:"entries"`
+}
+
+// getAccountStatement lists the entries of an account in a period, newest
+// first, with the type of each entry and the counterparty of transfers
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req = getAccountStatementRequest{
+		PageID:   1,
+		PageSize: 20,
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+	if req.From.IsZero() {
+		req.From = req.To.Add(-defaultStatementPeriod)
+	}
+	if !req.From.Before(req.To) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("from must be before to")))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, ok := server.memberRole(ctx, account, authPayload.Username); !ok {
+		return
+	}
+
+	rows, err := server.store.ListAccountStatement(ctx, db.ListAccountStatementParams{
+		AccountID: account.ID,
+		FromTime:  req.From,
+		ToTime:    req.To,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := accountStatementResponse{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		From:      req.From,
+		To:        req.To,
+		Entries:   make([]statementEntryResponse, 0, len(rows)),
+	}
+	for _, row := range rows {
+		res.Entries = append(res.Entries, statementEntryResponse{
+			ID:                    row.ID,
+			Amount:                row.Amount,
+			FormattedAmount:       server.currencies.Format(account.Currency, row.Amount),
+			EntryType:             row.EntryType,
+			TransferID:            row.TransferID.Int64,
+			CorrectsEntryID:       row.CorrectsEntryID.Int64,
+			CreatedAt:             row.CreatedAt,
+			CounterpartyAccountID: row.CounterpartyAccountID.Int64,
+			CounterpartyName:      util.MaskName(row.CounterpartyName.String),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountStatementAPI(t *testing.T) {
+
+	user1, _ := createRandomUser(t)
+	user2, _ := createRandomUser(t)
+	account := randomAccount(user1.Username)
+
+	to := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := []db.ListAccountStatementRow{
+		{
+			ID:                    8,
+			AccountID:             account.ID,
+			Amount:                -700,
+			EntryType:             db.EntryTypeTransfer,
+			TransferID:            sql.NullInt64{Int64: 5, Valid: true},
+			CreatedAt:             to.Add(-time.Hour),
+			CounterpartyAccountID: sql.NullInt64{Int64: 77, Valid: true},
+			CounterpartyOwner:     sql.NullString{String: user2.Username, Valid: true},
+			CounterpartyName:      sql.NullString{String: user2.FullName, Valid: true},
+		},
+		{
+			ID:        6,
+			AccountID: account.ID,
+			Amount:    12,
+			EntryType: db.EntryTypeInterest,
+			CreatedAt: to.Add(-2 * time.Hour),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			query:    fmt.Sprintf("from=%s&to=%s&page_id=2&page_size=10", url.QueryEscape(from.Format(time.RFC3339)), url.QueryEscape(to.Format(time.RFC3339))),
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListAccountStatement(gomock.Any(), gomock.Eq(db.ListAccountStatementParams{
+						AccountID: account.ID,
+						FromTime:  from,
+						ToTime:    to,
+						Limit:     10,
+						Offset:    10,
+					})).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res accountStatementResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, account.ID, res.AccountID)
+				require.Len(t, res.Entries, 2)
+				require.Equal(t, db.EntryTypeTransfer, res.Entries[0].EntryType)
+				require.Equal(t, int64(5), res.Entries[0].TransferID)
+				require.Equal(t, int64(77), res.Entries[0].CounterpartyAccountID)
+				require.Equal(t, util.MaskName(user2.FullName), res.Entries[0].CounterpartyName)
+				require.Equal(t, db.EntryTypeInterest, res.Entries[1].EntryType)
+				require.Zero(t, res.Entries[1].CounterpartyAccountID)
+			},
+		},
+		{
+			name:     "DefaultPeriod",
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListAccountStatement(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ListAccountStatementParams) ([]db.ListAccountStatementRow, error) {
+						require.Equal(t, defaultStatementPeriod, arg.ToTime.Sub(arg.FromTime))
+						require.Equal(t, int32(20), arg.Limit)
+						return []db.ListAccountStatementRow{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().ListAccountStatement(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "FromAfterTo",
+			query:    fmt.Sprintf("from=%s&to=%s", url.QueryEscape(to.Format(time.RFC3339)), url.QueryEscape(from.Format(time.RFC3339))),
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d/statement?%s", account.ID, tc.query), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
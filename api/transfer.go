@@ -8,10 +8,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/risk"
 	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/util"
+	"github.com/joefazee/simplebank/val"
 )
 
-
 func (server *Server) createTransfer(ctx *gin.Context) {
 
 	var req transferRequest
@@ -20,6 +22,17 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	if err := validateTransferDetails(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	recipientName, valid := server.resolveTransferRecipient(ctx, &req, authPayload.Username)
+	if !valid {
+		return
+	}
+
 	if req.FromAccountID == req.ToAccountID {
 		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("origin and destination account is the same")))
 		return
@@ -30,34 +43,102 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("from account doesn`t belong to the authemticated user")))
+	role, ok := server.memberRole(ctx, fromAccount, authPayload.Username)
+	if !ok {
+		return
+	}
+	if !db.CanSign(role) {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(db.ErrNotSigner))
 		return
 	}
 
 	if _, valid = server.validAccount(ctx, req.ToAccountID, req.Currency); !valid {
 		return
 	}
-	
 
-	arg := db.TransferTxParams{
+	result, err := server.screener.Transfer(ctx, risk.Request{
+		Username:      authPayload.Username,
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
 		Amount:        req.Amount,
-	}
-
-	result, err := server.store.TransferTx(ctx, arg)
+		Currency:      req.Currency,
+		UserAgent:     ctx.Request.UserAgent(),
+		ClientIP:      ctx.ClientIP(),
+		Memo:          req.Memo,
+		Reference:     req.Reference,
+		Metadata:      req.Metadata,
+	})
 	if err != nil {
+		var limitErr *db.LimitExceededError
+		if errors.As(err, &limitErr) {
+			ctx.JSON(http.StatusUnprocessableEntity, limitExceededResponse(limitErr))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	switch result.Decision.Decision {
+	case db.RiskDecisionReview:
+		ctx.JSON(http.StatusAccepted, gin.H{
+			"status":    "pending_review",
+			"review_id": result.Decision.ID,
+		})
+	case db.RiskDecisionBlock:
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"error":       "transfer was blocked by risk screening",
+			"decision_id": result.Decision.ID,
+		})
+	default:
+		if result.Approval.ID != 0 {
+			ctx.JSON(http.StatusAccepted, gin.H{
+				"status":      "pending_approval",
+				"approval_id": result.Approval.ID,
+			})
+			return
+		}
+		res := server.newTransferResponse(result.Transfer, req.Currency)
+		res.RecipientName = recipientName
+		ctx.JSON(http.StatusOK, res)
+	}
+}
+
+// validateTransferDetails checks the free-form details a client attaches to a transfer
+func validateTransferDetails(req transferRequest) error {
+	if err := val.ValidateTransferMemo(req.Memo); err != nil {
+		return fmt.Errorf("memo %w", err)
+	}
+	if err := val.ValidateTransferReference(req.Reference); err != nil {
+		return fmt.Errorf("reference %w", err)
+	}
+	if err := val.ValidateTransferMetadata(req.Metadata); err != nil {
+		return fmt.Errorf("metadata %w", err)
+	}
+	return nil
+}
+
+type transferResponse struct {
+	db.TransferTxResult
+	FormattedAmount string `json:"formatted_amount"`
+	FormattedFee    string `json:"formatted_fee"`
+	// RecipientName is the masked name of a recipient given by alias or beneficiary
+	RecipientName string `json:"recipient_name,omitempty"`
+}
+
+func (server *Server) newTransferResponse(result db.TransferTxResult, currency string) transferResponse {
+	return transferResponse{
+		TransferTxResult: result,
+		FormattedAmount:  server.currencies.Format(currency, result.Transfer.Amount),
+		FormattedFee:     server.currencies.Format(currency, result.Transfer.Fee),
+	}
 }
 
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
+	if err == nil && account.Kind == db.AccountKindGL {
+		// general ledger accounts are not there for customers
+		err = sql.ErrNoRows
+	}
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -77,3 +158,62 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 
 	return account, true
 }
+
+// resolveTransferRecipient sets the destination account of a transfer sent
+// to an alias or a saved beneficiary and returns the masked name of the
+// recipient. When the recipient is unknown it answers the request itself.
+func (server *Server) resolveTransferRecipient(ctx *gin.Context, req *transferRequest, username string) (string, bool) {
+
+	given := 0
+	for _, set := range []bool{req.ToAccountID != 0, req.ToAlias != "", req.ToBeneficiaryID != 0} {
+		if set {
+			given++
+		}
+	}
+	if given != 1 {
+		err := errors.New("exactly one of to_account_id, to_alias and to_beneficiary_id is required")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return "", false
+	}
+
+	switch {
+	case req.ToAlias != "":
+		recipient, err := db.ResolveRecipient(ctx, server.store, req.ToAlias, req.Currency)
+		if err != nil {
+			if errors.Is(err, db.ErrRecipientNotFound) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return "", false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return "", false
+		}
+		req.ToAccountID = recipient.AccountID
+		return recipient.MaskedName, true
+
+	case req.ToBeneficiaryID != 0:
+		beneficiary, err := server.store.GetBeneficiary(ctx, db.GetBeneficiaryParams{
+			ID:    req.ToBeneficiaryID,
+			Owner: username,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return "", false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return "", false
+		}
+		req.ToAccountID = beneficiary.AccountID
+		return util.MaskName(beneficiary.FullName), true
+	}
+
+	return "", true
+}
+
+// limitExceededResponse tells the client which limit was hit and how much is left
+func limitExceededResponse(err *db.LimitExceededError) gin.H {
+	return gin.H{
+		"error": err.Error(),
+		"limit": err,
+	}
+}
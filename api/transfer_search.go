@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/token"
+)
+
+// searchTransfersRequest filters the transfers of the caller's accounts.
+// Unset fields leave their filter out.
+type searchTransfersRequest struct {
+	AccountID             int64     `form:"account_id" binding:"omitempty,min=1"`
+	From                  time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To                    time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	MinAmount             int64     `form:"min_amount" binding:"omitempty,min=1"`
+	MaxAmount             int64     `form:"max_amount" binding:"omitempty,min=1"`
+	CounterpartyAccountID int64     `form:"counterparty_account_id" binding:"omitempty,min=1"`
+	Direction             string    `form:"direction" binding:"omitempty,oneof=incoming outgoing internal"`
+	Status                string    `form:"status" binding:"omitempty,oneof=completed pending_review pending_approval rejected blocked"`
+	Reference             string    `form:"reference"`
+	// Query holds words that must all appear in the memo
+	Query    string `form:"q" binding:"max=100"`
+	Sort     string `form:"sort" binding:"omitempty,oneof=created_at_desc created_at_asc amount_desc amount_asc"`
+	PageSize int32  `form:"page_size" binding:"min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type transferActivityResponse struct {
+	db.SearchTransferActivityRow
+	FormattedAmount string `json:"formatted_amount"`
+}
+
+type searchTransfersResponse struct {
+	Transfers []transferActivityResponse `json:"transfers"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor"`
+}
+
+// searchTransfers searches the transfers of the accounts the caller is a
+// member of, newest first unless sorted otherwise, one page at a time
+func (server *Server) searchTransfers(ctx *gin.Context) {
+
+	var req = searchTransfersRequest{
+		PageSize: 20,
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !req.From.IsZero() && !req.To.IsZero() && !req.From.Before(req.To) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("from must be before to")))
+		return
+	}
+	if req.MaxAmount > 0 && req.MaxAmount < req.MinAmount {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("max_amount must not be less than min_amount")))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := db.SearchTransfers(ctx, server.store, db.SearchTransfersParams{
+		Username:              authPayload.Username,
+		AccountID:             req.AccountID,
+		FromTime:              req.From,
+		ToTime:                req.To,
+		MinAmount:             req.MinAmount,
+		MaxAmount:             req.MaxAmount,
+		CounterpartyAccountID: req.CounterpartyAccountID,
+		Direction:             req.Direction,
+		Status:                req.Status,
+		Reference:             req.Reference,
+		Query:                 req.Query,
+		Sort:                  req.Sort,
+		Cursor:                req.Cursor,
+		Limit:                 req.PageSize,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidTransferCursor) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	res := searchTransfersResponse{
+		Transfers:  make([]transferActivityResponse, 0, len(result.Transfers)),
+		NextCursor: result.NextCursor,
+	}
+	for _, row := range result.Transfers {
+		res.Transfers = append(res.Transfers, transferActivityResponse{
+			SearchTransferActivityRow: row,
+			FormattedAmount:           server.currencies.Format(row.Currency, row.Amount),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTransfersAPI(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	row := db.SearchTransferActivityRow{
+		Kind:          db.TransferActivityTransfer,
+		ID:            12,
+		FromAccountID: 3,
+		ToAccountID:   4,
+		Amount:        1250,
+		Currency:      util.USD,
+		Memo:          "office rent",
+		Metadata:      json.RawMessage(`{}`),
+		Status:        db.TransferStatusCompleted,
+		Direction:     db.TransferDirectionIncoming,
+		CreatedAt:     time.Now().Truncate(time.Second),
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "status=completed&direction=incoming&q=rent&sort=amount_asc&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchTransferActivity(gomock.Any(), gomock.Eq(db.SearchTransferActivityParams{
+						Username:  user.Username,
+						Direction: sql.NullString{String: db.TransferDirectionIncoming, Valid: true},
+						Status:    sql.NullString{String: db.TransferStatusCompleted, Valid: true},
+						Query:     sql.NullString{String: "rent", Valid: true},
+						Sort:      db.TransferSortSmallest,
+						PageLimit: 6,
+					})).
+					Times(1).
+					Return([]db.SearchTransferActivityRow{row}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res searchTransfersResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Len(t, res.Transfers, 1)
+				require.Empty(t, res.NextCursor)
+				require.Equal(t, row.ID, res.Transfers[0].ID)
+				require.Equal(t, "office rent", res.Transfers[0].Memo)
+				require.Equal(t, "$12.50", res.Transfers[0].FormattedAmount)
+			},
+		},
+		{
+			name:  "UnknownStatus",
+			query: "status=lost",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransferActivity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "AmountRangeReversed",
+			query: "min_amount=100&max_amount=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransferActivity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidCursor",
+			query: "cursor=bm90LWpzb24",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransferActivity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/transfers?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
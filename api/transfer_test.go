@@ -2,8 +2,10 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,6 +17,7 @@ import (
 	db "github.com/joefazee/simplebank/db/sqlc"
 	"github.com/joefazee/simplebank/token"
 	"github.com/joefazee/simplebank/util"
+	"github.com/joefazee/simplebank/val"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,12 +58,17 @@ func TestTransferAPI(t *testing.T) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
 
-				arg := db.TransferTxParams{
-					FromAccountID: account1.ID,
-					ToAccountID:   account2.ID,
-					Amount:        amount,
-				}
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), screenedTransferMatcher{
+						username:      user1.Username,
+						fromAccountID: account1.ID,
+						toAccountID:   account2.ID,
+						amount:        amount,
+						decision:      db.RiskDecisionAllow,
+					}).
+					Times(1).
+					Return(db.ScreenedTransferTxResult{Decision: db.RiskDecision{ID: 1, Decision: db.RiskDecisionAllow}}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -79,13 +87,79 @@ func TestTransferAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: account1.ID, Username: user2.Username})).
+					Times(1).
+					Return(db.AccountMember{}, sql.ErrNoRows)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(0)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "JointAccountViewer",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user2.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountMember{Role: db.MemberRoleViewer, Status: db.MemberStatusActive}, nil)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "JointAccountSignerPendingApproval",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user2.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					GetAccountMember(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountMember{Role: db.MemberRoleSigner, Status: db.MemberStatusActive}, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), screenedTransferMatcher{
+						username:      user2.Username,
+						fromAccountID: account1.ID,
+						toAccountID:   account2.ID,
+						amount:        amount,
+						decision:      db.RiskDecisionAllow,
+					}).
+					Times(1).
+					Return(db.ScreenedTransferTxResult{
+						Decision: db.RiskDecision{ID: 4, Decision: db.RiskDecisionAllow},
+						Approval: db.TransferApproval{ID: 6, Status: db.ApprovalStatusPending},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"status":"pending_approval"`)
+				require.Contains(t, recorder.Body.String(), `"approval_id":6`)
+			},
+		},
 		{
 			name: "NoAuthorization",
 			body: gin.H{
@@ -98,7 +172,7 @@ func TestTransferAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -118,7 +192,7 @@ func TestTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(0)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -138,7 +212,31 @@ func TestTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ToGLAccount",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				glAccount := account2
+				glAccount.Owner = db.SystemOwner
+				glAccount.Kind = db.AccountKindGL
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(glAccount, nil)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -158,7 +256,7 @@ func TestTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(0)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -178,7 +276,7 @@ func TestTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(0)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(0)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -198,7 +296,7 @@ func TestTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -217,7 +315,7 @@ func TestTransferAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -236,7 +334,7 @@ func TestTransferAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -255,7 +353,7 @@ func TestTransferAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrConnDone)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -275,12 +373,311 @@ func TestTransferAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
 				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
-				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, sql.ErrTxDone)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ScreenedTransferTxResult{}, sql.ErrTxDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "LimitExceeded",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ScreenedTransferTxResult{}, &db.LimitExceededError{
+					Period:    db.LimitDaily,
+					Currency:  util.USD,
+					Limit:     100,
+					Used:      95,
+					Remaining: 5,
+				})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+				var res struct {
+					Error string                `json:"error"`
+					Limit db.LimitExceededError `json:"limit"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.NotEmpty(t, res.Error)
+				require.Equal(t, db.LimitDaily, res.Limit.Period)
+				require.Equal(t, int64(100), res.Limit.Limit)
+				require.Equal(t, int64(95), res.Limit.Used)
+				require.Equal(t, int64(5), res.Limit.Remaining)
+			},
+		},
+		{
+			name: "HeldForReview",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				// a user with earlier sessions transferring from an unknown device and address
+				store.EXPECT().
+					GetTransferRiskSignals(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTransferRiskSignalsRow{PayeeTransfers: 1, PreviousSessions: 4}, nil)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), screenedTransferMatcher{
+						username:      user1.Username,
+						fromAccountID: account1.ID,
+						toAccountID:   account2.ID,
+						amount:        amount,
+						decision:      db.RiskDecisionReview,
+					}).
+					Times(1).
+					Return(db.ScreenedTransferTxResult{Decision: db.RiskDecision{ID: 7, Decision: db.RiskDecisionReview}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				require.JSONEq(t, `{"status":"pending_review","review_id":7}`, recorder.Body.String())
+			},
+		},
+		{
+			name: "Blocked",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ScreenedTransferTxResult{Decision: db.RiskDecision{ID: 8, Decision: db.RiskDecisionBlock}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ToVerifiedEmail",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_alias":        " " + user2.Email,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetVerifiedAliasUsername(gomock.Any(), gomock.Eq(db.GetVerifiedAliasUsernameParams{Kind: db.AliasKindEmail, Value: user2.Email})).
+					Times(1).
+					Return(user2.Username, nil)
+				store.EXPECT().
+					GetRecipientAccount(gomock.Any(), gomock.Eq(db.GetRecipientAccountParams{Owner: user2.Username, Currency: util.USD})).
+					Times(1).
+					Return(db.GetRecipientAccountRow{ID: account2.ID, Owner: user2.Username, Currency: util.USD, FullName: "Ada Lovelace"}, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), screenedTransferMatcher{
+						username:      user1.Username,
+						fromAccountID: account1.ID,
+						toAccountID:   account2.ID,
+						amount:        amount,
+						decision:      db.RiskDecisionAllow,
+					}).
+					Times(1).
+					Return(db.ScreenedTransferTxResult{Decision: db.RiskDecision{ID: 1, Decision: db.RiskDecisionAllow}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res transferResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "A** L*******", res.RecipientName)
+			},
+		},
+		{
+			name: "ToUnverifiedPhone",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_alias":        "+234 (803) 555-0100",
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetVerifiedAliasUsername(gomock.Any(), gomock.Eq(db.GetVerifiedAliasUsernameParams{Kind: db.AliasKindPhone, Value: "+2348035550100"})).
+					Times(1).
+					Return("", sql.ErrNoRows)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ToBeneficiary",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_beneficiary_id": 5,
+				"amount":            amount,
+				"currency":          util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBeneficiary(gomock.Any(), gomock.Eq(db.GetBeneficiaryParams{ID: 5, Owner: user1.Username})).
+					Times(1).
+					Return(db.GetBeneficiaryRow{ID: 5, Owner: user1.Username, AccountID: account2.ID, Currency: util.USD, FullName: "Ada Lovelace"}, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), screenedTransferMatcher{
+						username:      user1.Username,
+						fromAccountID: account1.ID,
+						toAccountID:   account2.ID,
+						amount:        amount,
+						decision:      db.RiskDecisionAllow,
+					}).
+					Times(1).
+					Return(db.ScreenedTransferTxResult{Decision: db.RiskDecision{ID: 1, Decision: db.RiskDecisionAllow}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SeveralRecipients",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"to_alias":        user2.Username,
+				"amount":          amount,
+				"currency":        util.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "WithDetails",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+				"memo":            "team lunch",
+				"reference":       "PO-118",
+				"metadata":        gin.H{"cost_center": "eng"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ScreenedTransferTxParams) (db.ScreenedTransferTxResult, error) {
+						require.Equal(t, "team lunch", arg.Memo)
+						require.Equal(t, "PO-118", arg.Reference)
+						require.JSONEq(t, `{"cost_center":"eng"}`, string(arg.Metadata))
+
+						return db.ScreenedTransferTxResult{
+							Decision: db.RiskDecision{ID: 1, Decision: db.RiskDecisionAllow},
+							Transfer: db.TransferTxResult{Transfer: db.Transfer{ID: 4, Memo: arg.Memo, Reference: arg.Reference, Metadata: arg.Metadata}},
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var res struct {
+					Transfer db.Transfer `json:"transfer"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				require.Equal(t, "team lunch", res.Transfer.Memo)
+				require.Equal(t, "PO-118", res.Transfer.Reference)
+				require.JSONEq(t, `{"cost_center":"eng"}`, string(res.Transfer.Metadata))
+			},
+		},
+		{
+			name: "MemoWithControlCharacters",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+				"memo":            "pay\u0000me",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TooManyMetadataKeys",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.USD,
+				"metadata":        tooManyMetadataKeys(),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -309,4 +706,40 @@ func TestTransferAPI(t *testing.T) {
 			tc.checkResponse(recorder)
 		})
 	}
-}
\ No newline at end of file
+}
+
+// screenedTransferMatcher matches the transfer and decision of a screened
+// transfer, ignoring request details such as the client address
+func tooManyMetadataKeys() map[string]string {
+	metadata := make(map[string]string, val.MaxTransferMetadataKeys+1)
+	for i := 0; i <= val.MaxTransferMetadataKeys; i++ {
+		metadata[fmt.Sprintf("key_%d", i)] = "value"
+	}
+	return metadata
+}
+
+type screenedTransferMatcher struct {
+	username      string
+	fromAccountID int64
+	toAccountID   int64
+	amount        int64
+	decision      string
+}
+
+func (expected screenedTransferMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.ScreenedTransferTxParams)
+	if !ok {
+		return false
+	}
+
+	return arg.Username == expected.username &&
+		arg.FromAccountID == expected.fromAccountID &&
+		arg.ToAccountID == expected.toAccountID &&
+		arg.Amount == expected.amount &&
+		arg.Decision == expected.decision
+}
+
+func (expected screenedTransferMatcher) String() string {
+	return fmt.Sprintf("%s transfers %d from %d to %d, decision %s",
+		expected.username, expected.amount, expected.fromAccountID, expected.toAccountID, expected.decision)
+}
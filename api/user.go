@@ -128,14 +128,16 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	sess, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID: refreshTokenPayload.ID,
-		Username: user.Username,
-		RefreshToken: refreshToken,
-		UserAgent: ctx.Request.UserAgent(),
-		ClientIp: ctx.ClientIP(),
-		IsBlocked: false,
-		ExpiresAt: refreshTokenPayload.ExpiredAt,
+	result, err := server.store.CreateSessionTx(ctx, db.CreateSessionTxParams{
+		CreateSessionParams: db.CreateSessionParams{
+			ID: refreshTokenPayload.ID,
+			Username: user.Username,
+			RefreshToken: refreshToken,
+			UserAgent: ctx.Request.UserAgent(),
+			ClientIp: ctx.ClientIP(),
+			IsBlocked: false,
+			ExpiresAt: refreshTokenPayload.ExpiredAt,
+		},
 	})
 
 	if err != nil {
@@ -144,7 +146,7 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	res := loginUserResponse{
-		SessionID: sess.ID,
+		SessionID: result.Session.ID,
 		AccessToken: accessToken,
 		AccessTokenExpiresAt: accessTokenPayload.ExpiredAt,
 		RefreshToken: refreshToken,
@@ -238,7 +238,7 @@ func TestLoginUserAPI(t *testing.T) {
 					Times(1). 
 					Return(user, nil)
 
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1)
 			},
 			checkResponse: func(rr *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, rr.Code)
@@ -256,7 +256,7 @@ func TestLoginUserAPI(t *testing.T) {
 					Times(1). 
 					Return(user, nil)
 
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, rr.Code)
@@ -274,7 +274,7 @@ func TestLoginUserAPI(t *testing.T) {
 					Times(1). 
 					Return(db.User{}, sql.ErrNoRows)
 
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, rr.Code)
@@ -293,7 +293,7 @@ func TestLoginUserAPI(t *testing.T) {
 					Times(1). 
 					Return(db.User{}, sql.ErrConnDone)
 
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, rr.Code)
@@ -312,7 +312,7 @@ func TestLoginUserAPI(t *testing.T) {
 					Times(0)
 					
 
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, rr.Code)
@@ -2,12 +2,15 @@ package api
 
 import (
 	"github.com/go-playground/validator/v10"
-	"github.com/joefazee/simplebank/util"
+	"github.com/joefazee/simplebank/currency"
 )
 
-var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
-	if currency, ok := fieldLevel.Field().Interface().(string); ok {
-		return util.IsSupprtedCurrency(currency)
+// validCurrency accepts the enabled currencies of the registry
+func validCurrency(currencies *currency.Registry) validator.Func {
+	return func(fieldLevel validator.FieldLevel) bool {
+		if code, ok := fieldLevel.Field().Interface().(string); ok {
+			return currencies.IsSupported(code)
+		}
+		return false
 	}
-	return false
 }
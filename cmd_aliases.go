@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+	"github.com/lib/pq"
+)
+
+// verifyAliasCommand records an email address or phone number support has
+// verified for a user, so other users can send money to it
+func verifyAliasCommand(ctx context.Context, config util.Config, args []string) error {
+
+	if len(args) != 2 {
+		return errors.New("usage: verify-alias <username> <email|+phone>")
+	}
+	username := args[0]
+
+	kind, value := db.ParseAlias(args[1])
+	if kind == "" {
+		return fmt.Errorf("%q is neither an email address nor a phone number starting with +", args[1])
+	}
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	alias, err := store.UpsertUserAlias(ctx, db.UpsertUserAliasParams{
+		Kind:       kind,
+		Value:      value,
+		Username:   username,
+		VerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			return fmt.Errorf("user %q does not exist", username)
+		}
+		return fmt.Errorf("cannot save alias: %w", err)
+	}
+
+	fmt.Printf("%s %s now resolves to %s\n", alias.Kind, alias.Value, alias.Username)
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+)
+
+// setCurrencyCommand adds or changes a currency and opens the general ledger
+// accounts of the chart of accounts in it. A currency is only enabled once it
+// has tier0 transfer limits. The servers read the currencies at startup.
+func setCurrencyCommand(ctx context.Context, config util.Config, args []string) error {
+
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return errors.New("usage: set-currency <code> -name -minor-units -symbol [-risk-large-amount] [-tier0-per-transaction -tier0-daily -tier0-monthly] [-disable]")
+	}
+	code := strings.ToUpper(args[0])
+
+	flags := flag.NewFlagSet("set-currency", flag.ContinueOnError)
+	name := flags.String("name", "", "name of the currency")
+	minorUnits := flags.Int("minor-units", 2, "ISO 4217 exponent, the number of decimals")
+	symbol := flags.String("symbol", "", "display symbol, the code when empty")
+	riskLargeAmount := flags.Int64("risk-large-amount", 0, "amount from which transfers to a new payee are held for review, 0 for never")
+	disable := flags.Bool("disable", false, "stop accepting the currency for new accounts and transfers")
+	var tier0 db.UpsertTransferLimitParams
+	flags.Int64Var(&tier0.PerTransaction, "tier0-per-transaction", 0, "largest transfer of a user who did not complete KYC")
+	flags.Int64Var(&tier0.Daily, "tier0-daily", 0, "daily transfer limit of a user who did not complete KYC")
+	flags.Int64Var(&tier0.Monthly, "tier0-monthly", 0, "monthly transfer limit of a user who did not complete KYC")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+	if *riskLargeAmount < 0 {
+		return errors.New("-risk-large-amount must not be negative")
+	}
+	if *symbol == "" {
+		*symbol = code + " "
+	}
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	if !*disable {
+		tier0.Profile = db.LimitProfileTier0
+		tier0.Currency = code
+		if err = setTier0Limits(ctx, store, tier0); err != nil {
+			return err
+		}
+	}
+
+	currency, err := store.UpsertCurrency(ctx, db.UpsertCurrencyParams{
+		Code:            code,
+		Name:            *name,
+		MinorUnits:      int16(*minorUnits),
+		Symbol:          *symbol,
+		Enabled:         !*disable,
+		RiskLargeAmount: *riskLargeAmount,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot save currency: %w", err)
+	}
+
+	if currency.Enabled {
+		chart, err := store.ListChartOfAccounts(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot list the chart of accounts: %w", err)
+		}
+
+		for _, glAccount := range chart {
+			if glAccount.Code == db.CustomerDepositsAccount {
+				continue
+			}
+
+			_, err = store.GetInternalAccount(ctx, db.GetInternalAccountParams{Name: glAccount.Code, Currency: code})
+			if errors.Is(err, sql.ErrNoRows) {
+				_, err = store.CreateInternalAccount(ctx, db.CreateInternalAccountParams{Name: glAccount.Code, Currency: code})
+			}
+			if err != nil {
+				return fmt.Errorf("cannot open the %s account: %w", glAccount.Code, err)
+			}
+		}
+	}
+
+	state := "enabled"
+	if !currency.Enabled {
+		state = "disabled"
+	}
+	fmt.Printf("%s (%s, %d decimals) is %s; restart the servers to pick up the change\n",
+		currency.Code, currency.Name, currency.MinorUnits, state)
+
+	return nil
+}
+
+// setTier0Limits saves the tier0 transfer limits of a currency when they are
+// given, and otherwise checks that the currency already has them. Without
+// them users who did not complete KYC could not send the currency at all.
+func setTier0Limits(ctx context.Context, store db.Store, limit db.UpsertTransferLimitParams) error {
+
+	if limit.PerTransaction != 0 || limit.Daily != 0 || limit.Monthly != 0 {
+		if limit.PerTransaction <= 0 || limit.Daily < limit.PerTransaction || limit.Monthly < limit.Daily {
+			return errors.New("tier0 limits must be positive with -tier0-per-transaction <= -tier0-daily <= -tier0-monthly")
+		}
+
+		if _, err := store.UpsertTransferLimit(ctx, limit); err != nil {
+			return fmt.Errorf("cannot save tier0 transfer limits: %w", err)
+		}
+		return nil
+	}
+
+	limits, err := store.ListTransferLimits(ctx, db.LimitProfileTier0)
+	if err != nil {
+		return fmt.Errorf("cannot list tier0 transfer limits: %w", err)
+	}
+
+	for _, existing := range limits {
+		if existing.Currency == limit.Currency {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s has no tier0 transfer limits; set them with -tier0-per-transaction, -tier0-daily and -tier0-monthly", limit.Currency)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/joefazee/simplebank/interest"
+	"github.com/joefazee/simplebank/util"
+)
+
+// accrueInterestCommand accrues the interest of one day and, when asked or on
+// the last day of a month, posts the month. It is safe to run again for a day
+// the worker already handled.
+func accrueInterestCommand(ctx context.Context, config util.Config, args []string) error {
+
+	flags := flag.NewFlagSet("accrue-interest", flag.ContinueOnError)
+	dayFlag := flags.String("day", time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02"), "UTC day to accrue, yesterday by default")
+	post := flags.Bool("post", false, "post the month of the day even if it is not its last day")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	day, err := time.Parse("2006-01-02", *dayFlag)
+	if err != nil {
+		return fmt.Errorf("invalid day %q: %w", *dayFlag, err)
+	}
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	result, err := interest.Accrue(ctx, store, day)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("accrued %s for %d of %d accounts\n", day.Format("2006-01-02"), result.Accrued, result.Accounts)
+
+	if *post || interest.IsMonthEnd(day) {
+		month := interest.MonthOf(day)
+		posted, err := interest.PostMonth(ctx, store, month)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("posted %s interest to %d accounts\n", month.Format("2006-01"), posted)
+	}
+
+	return nil
+}
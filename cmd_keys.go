@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/util"
+)
+
+// symmetricKeyBytes encodes to the 32 characters the paseto maker expects
+const symmetricKeyBytes = 24
+
+// rotateKeysCommand generates a new token symmetric key. Servers pick it up on
+// restart; tokens signed with the old key stop verifying at that point.
+func rotateKeysCommand(ctx context.Context, config util.Config, args []string) error {
+
+	flags := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	out := flags.String("out", os.Getenv("TOKEN_SYMMETRIC_KEY_FILE"), "file to write the new key to, stdout when empty")
+	revokeSessions := flags.Bool("revoke-sessions", false, "block every session so refresh tokens cannot be renewed")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	key, err := newSymmetricKey()
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		fmt.Println(key)
+	} else {
+		if err = writeKeyFile(*out, key); err != nil {
+			return err
+		}
+		fmt.Printf("wrote new token key to %s, restart the servers to use it\n", *out)
+	}
+
+	if !*revokeSessions {
+		return nil
+	}
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	blocked, err := store.BlockAllSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot revoke sessions: %w", err)
+	}
+
+	fmt.Printf("blocked %d sessions\n", blocked)
+	return nil
+}
+
+func newSymmetricKey() (string, error) {
+
+	buf := make([]byte, symmetricKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("cannot generate key: %w", err)
+	}
+
+	key := base64.RawURLEncoding.EncodeToString(buf)
+	if _, err := token.NewPasetoMaker(key); err != nil {
+		return "", fmt.Errorf("generated key is not usable: %w", err)
+	}
+
+	return key, nil
+}
+
+// writeKeyFile replaces filename atomically so a server never reads half a key
+func writeKeyFile(filename string, key string) error {
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".token-key-*")
+	if err != nil {
+		return fmt.Errorf("cannot write key: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(key + "\n"); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write key: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write key: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("cannot write key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+)
+
+// reconcileCommand compares every account balance with the sum of its entries
+// and fails when any of them disagree
+func reconcileCommand(ctx context.Context, config util.Config, args []string) error {
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	accounts, err := store.ListUnbalancedAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot reconcile ledger: %w", err)
+	}
+
+	if len(accounts) == 0 {
+		fmt.Println("every account balance matches its entries")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ACCOUNT\tOWNER\tCURRENCY\tBALANCE\tENTRIES\tDIFFERENCE")
+	for _, account := range accounts {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%d\t%d\t%d\n", account.ID, account.Owner, account.Currency,
+			account.Balance, account.EntriesTotal, account.Balance-account.EntriesTotal)
+	}
+	writer.Flush()
+
+	return fmt.Errorf("%d accounts do not match their entries", len(accounts))
+}
+
+// correctEntryCommand fixes the amount of a ledger entry. Entries cannot be
+// changed, so the difference is posted as a correcting entry.
+func correctEntryCommand(ctx context.Context, config util.Config, args []string) error {
+
+	if len(args) != 2 {
+		return errors.New("usage: correct-entry <entry_id> <amount>")
+	}
+
+	entryID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || entryID <= 0 {
+		return fmt.Errorf("invalid entry id %q", args[0])
+	}
+
+	amount, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q", args[1])
+	}
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	result, err := store.CorrectEntryTx(ctx, db.CorrectEntryTxParams{
+		EntryID: entryID,
+		Amount:  amount,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("entry %d does not exist", entryID)
+		}
+		return fmt.Errorf("cannot correct entry: %w", err)
+	}
+
+	fmt.Printf("posted entry %d of %d on account %d, balance is now %d\n",
+		result.Correction.ID, result.Correction.Amount, result.Account.ID, result.Account.Balance)
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+)
+
+// setLimitProfileCommand assigns a transfer limit profile to a user and
+// prints the limits that now apply
+func setLimitProfileCommand(ctx context.Context, config util.Config, args []string) error {
+
+	if len(args) != 2 {
+		return errors.New("usage: set-limit-profile <username> <profile>")
+	}
+	username, profile := args[0], args[1]
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	if _, err = store.GetLimitProfile(ctx, profile); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("limit profile %q does not exist", profile)
+		}
+		return fmt.Errorf("cannot get limit profile: %w", err)
+	}
+
+	user, err := store.UpdateUserLimitProfile(ctx, db.UpdateUserLimitProfileParams{
+		Username:     username,
+		LimitProfile: profile,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user %q does not exist", username)
+		}
+		return fmt.Errorf("cannot update user: %w", err)
+	}
+
+	limits, err := store.ListTransferLimits(ctx, profile)
+	if err != nil {
+		return fmt.Errorf("cannot list transfer limits: %w", err)
+	}
+
+	fmt.Printf("%s now has the %s limit profile\n", user.Username, user.LimitProfile)
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "CURRENCY\tPER TRANSACTION\tDAILY\tMONTHLY")
+	for _, limit := range limits {
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\n", limit.Currency, limit.PerTransaction, limit.Daily, limit.Monthly)
+	}
+	writer.Flush()
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/joefazee/simplebank/util"
+)
+
+// migrateCommand applies or rolls back schema migrations, or reports where the database is
+func migrateCommand(ctx context.Context, config util.Config, args []string) error {
+
+	if len(args) == 0 {
+		return errors.New("migrate needs one of up, down or status")
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	steps := flags.Int("steps", 0, "number of migrations to apply or roll back")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		if *steps == 0 {
+			_, err := runDBMigration(config.DbMigrationURL, config.DBSource)
+			return err
+		}
+		return migrateSteps(config, *steps)
+	case "down":
+		// rolling back everything by accident is not recoverable, so down
+		// defaults to a single step
+		if *steps == 0 {
+			*steps = 1
+		}
+		return migrateSteps(config, -*steps)
+	case "status":
+		return migrationStatus(config)
+	}
+
+	return fmt.Errorf("unknown migrate action %q", args[0])
+}
+
+func migrateSteps(config util.Config, steps int) error {
+
+	migration, err := migrate.New(config.DbMigrationURL, config.DBSource)
+	if err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
+	defer migration.Close()
+
+	if err = migration.Steps(steps); err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
+
+	version, dirty, err := migration.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("migration error: %w", err)
+	}
+
+	fmt.Printf("schema is at version %d (dirty: %t)\n", version, dirty)
+	return nil
+}
+
+func migrationStatus(config util.Config) error {
+
+	migration, err := migrate.New(config.DbMigrationURL, config.DBSource)
+	if err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
+	defer migration.Close()
+
+	version, dirty, err := migration.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("migration error: %w", err)
+	}
+
+	latest, pending, err := pendingMigrations(config.DbMigrationURL, version)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("current version: %d\nlatest version:  %d\npending:         %d\ndirty:           %t\n",
+		version, latest, pending, dirty)
+	return nil
+}
+
+// pendingMigrations returns the newest migration available and how many are
+// newer than version
+func pendingMigrations(migrationURL string, version uint) (latest uint, pending int, err error) {
+
+	driver, err := source.Open(migrationURL)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot open migrations %s: %w", migrationURL, err)
+	}
+	defer driver.Close()
+
+	next, err := driver.First()
+	for err == nil {
+		latest = next
+		if next > version {
+			pending++
+		}
+		next, err = driver.Next(next)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, 0, fmt.Errorf("cannot read migrations: %w", err)
+	}
+
+	return latest, pending, nil
+}
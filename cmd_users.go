@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+	"github.com/joefazee/simplebank/val"
+)
+
+const (
+	seedOpeningBalance = 100_000
+	seedTransfers      = 20
+)
+
+var seedCurrencies = []string{util.USD, util.EUR}
+
+// createAdminCommand creates a user with the admin role
+func createAdminCommand(ctx context.Context, config util.Config, args []string) error {
+
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	username := flags.String("username", "", "username of the admin")
+	password := flags.String("password", "", "password of the admin")
+	fullName := flags.String("full-name", "", "full name of the admin")
+	email := flags.String("email", "", "email address of the admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	for _, field := range []struct {
+		name     string
+		validate func(string) error
+		value    string
+	}{
+		{"username", val.ValidateUsername, *username},
+		{"password", val.ValidatePassword, *password},
+		{"full-name", val.ValidateFullname, *fullName},
+		{"email", val.ValidateEmail, *email},
+	} {
+		if err := field.validate(field.value); err != nil {
+			return fmt.Errorf("invalid -%s: %w", field.name, err)
+		}
+	}
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	user, err := createUser(ctx, store, *username, *password, *fullName, *email, util.AdminRole)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("created admin %s <%s>\n", user.Username, user.Email)
+	return nil
+}
+
+// seedCommand creates demo users with funded accounts and a few transfers
+// between them. Users that already exist are left untouched, so it can be rerun.
+func seedCommand(ctx context.Context, config util.Config, args []string) error {
+
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	users := flags.Int("users", 5, "number of demo users")
+	password := flags.String("password", "secret", "password of every demo user")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if config.Environment == "production" {
+		return errors.New("refusing to seed demo data in production")
+	}
+
+	store, closeStore, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	accounts := map[string][]db.Account{}
+
+	for i := 1; i <= *users; i++ {
+		username := fmt.Sprintf("demo_%d", i)
+
+		_, err = store.GetUser(ctx, username)
+		if err == nil {
+			fmt.Printf("skipped %s, it already exists\n", username)
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("cannot get user %s: %w", username, err)
+		}
+
+		user, err := createUser(ctx, store, username, *password, "Demo User", username+"@example.com", "")
+		if err != nil {
+			return err
+		}
+
+		if err = verifyDemoUser(ctx, store, user); err != nil {
+			return err
+		}
+
+		for _, currency := range seedCurrencies {
+			account, err := openFundedAccount(ctx, store, username, currency)
+			if err != nil {
+				return err
+			}
+			accounts[currency] = append(accounts[currency], account)
+		}
+
+		fmt.Printf("created %s with %d accounts\n", username, len(seedCurrencies))
+	}
+
+	transfers := 0
+	for _, currency := range seedCurrencies {
+		funded := accounts[currency]
+		if len(funded) < 2 {
+			continue
+		}
+
+		for i := 0; i < seedTransfers/len(seedCurrencies); i++ {
+			from := funded[util.RandomInt(0, int64(len(funded)-1))]
+			to := funded[util.RandomInt(0, int64(len(funded)-1))]
+			if from.ID == to.ID {
+				continue
+			}
+
+			_, err = store.TransferTx(ctx, db.TransferTxParams{
+				FromAccountID: from.ID,
+				ToAccountID:   to.ID,
+				Amount:        util.RandomInt(1, 1_000),
+			})
+			if err != nil {
+				return fmt.Errorf("cannot create transfer: %w", err)
+			}
+			transfers++
+		}
+	}
+
+	fmt.Printf("created %d transfers\n", transfers)
+	return nil
+}
+
+func createUser(ctx context.Context, store db.Store, username, password, fullName, email, role string) (db.User, error) {
+
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		return db.User{}, fmt.Errorf("cannot hash password: %w", err)
+	}
+
+	result, err := store.CreateUserTx(ctx, db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       username,
+			HashedPassword: hashedPassword,
+			FullName:       fullName,
+			Email:          email,
+		},
+		Role: role,
+	})
+	if err != nil {
+		return db.User{}, fmt.Errorf("cannot create user %s: %w", username, err)
+	}
+
+	return result.User, nil
+}
+
+// verifyDemoUser verifies the email and identity of a demo user, so it can
+// hold several accounts and send above the tier 0 limits
+func verifyDemoUser(ctx context.Context, store db.Store, user db.User) error {
+
+	_, err := store.UpsertUserAlias(ctx, db.UpsertUserAliasParams{
+		Kind:       db.AliasKindEmail,
+		Value:      strings.ToLower(user.Email),
+		Username:   user.Username,
+		VerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot verify email of %s: %w", user.Username, err)
+	}
+
+	_, err = store.UpdateUserKyc(ctx, db.UpdateUserKycParams{
+		Username:  user.Username,
+		KycStatus: db.KycStatusVerified,
+		KycTier:   db.KycTierBasic,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot verify identity of %s: %w", user.Username, err)
+	}
+
+	return nil
+}
+
+// openFundedAccount opens an account with an opening balance and the entry
+// backing it, so the ledger still reconciles
+func openFundedAccount(ctx context.Context, store db.Store, owner string, currency string) (db.Account, error) {
+
+	account, err := store.CreateAccountTx(ctx, db.CreateAccountTxParams{
+		CreateAccountParams: db.CreateAccountParams{
+			Owner:    owner,
+			Currency: currency,
+		},
+		OpeningBalance: seedOpeningBalance,
+	})
+	if err != nil {
+		return db.Account{}, fmt.Errorf("cannot create %s account for %s: %w", currency, owner, err)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+)
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, config util.Config, args []string) error
+}
+
+var commands = []command{
+	{"serve", "[all|grpc|gateway|gin|worker]", "run the servers; all is grpc, gateway and worker, gin runs the Gin API with its own worker", serveCommand},
+	{"migrate", "up|down [-steps n] | status", "apply, roll back or inspect schema migrations", migrateCommand},
+	{"create-admin", "-username -password -full-name -email", "create a user with the admin role", createAdminCommand},
+	{"set-limit-profile", "<username> <profile>", "assign a transfer limit profile to a user", setLimitProfileCommand},
+	{"verify-alias", "<username> <email|+phone>", "let users send money to a verified email or phone number", verifyAliasCommand},
+	{"set-currency", "<code> -name -minor-units -symbol [-risk-large-amount] [-tier0-per-transaction -tier0-daily -tier0-monthly] [-disable]", "add, change or disable a currency", setCurrencyCommand},
+	{"seed", "[-users n] [-password p]", "create demo users, accounts and transfers", seedCommand},
+	{"accrue-interest", "[-day YYYY-MM-DD] [-post]", "accrue a day of interest and post the month at its end", accrueInterestCommand},
+	{"reconcile", "", "check every account balance against its ledger entries", reconcileCommand},
+	{"correct-entry", "<entry_id> <amount>", "post a correcting entry so an entry adds up to amount", correctEntryCommand},
+	{"rotate-keys", "[-out file] [-revoke-sessions]", "generate a new token symmetric key", rotateKeysCommand},
+}
+
+// runCommand runs the subcommand named by the first argument. Without
+// arguments every server is started, as before subcommands existed.
+func runCommand(ctx context.Context, config util.Config, args []string) error {
+
+	if len(args) == 0 {
+		return serveCommand(ctx, config, nil)
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printUsage()
+		return nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, config, args[1:])
+		}
+	}
+
+	printUsage()
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [arguments]\n\ncommands:\n", os.Args[0])
+
+	writer := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(writer, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.summary)
+	}
+	writer.Flush()
+}
+
+// openStore connects to the database for the administrative commands
+func openStore(config util.Config) (db.Store, func() error, error) {
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open database: %w", err)
+	}
+
+	return db.NewStore(conn), conn.Close, nil
+}
@@ -0,0 +1,141 @@
+// Package currency holds the currencies the bank knows about, as stored in
+// the currencies table, and formats amounts in them. Amounts are integers in
+// the minor unit of their currency, whose ISO 4217 exponent says how many
+// decimals it has.
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+)
+
+// Currency describes one currency of the registry
+type Currency struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	MinorUnits int    `json:"minor_units"`
+	Symbol     string `json:"symbol"`
+	// Enabled currencies are accepted for new accounts and transfers; disabled
+	// ones are still known so existing amounts can be formatted
+	Enabled bool `json:"enabled"`
+}
+
+// Format writes amount, given in minor units, with the currency symbol,
+// thousands separators and as many decimals as the currency has
+func (c Currency) Format(amount int64) string {
+
+	var b strings.Builder
+	magnitude := uint64(amount)
+	if amount < 0 {
+		b.WriteByte('-')
+		magnitude = uint64(-(amount + 1)) + 1
+	}
+	b.WriteString(c.Symbol)
+
+	scale := uint64(1)
+	for i := 0; i < c.MinorUnits; i++ {
+		scale *= 10
+	}
+
+	whole := strconv.FormatUint(magnitude/scale, 10)
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+
+	if c.MinorUnits > 0 {
+		fmt.Fprintf(&b, ".%0*d", c.MinorUnits, magnitude%scale)
+	}
+
+	return b.String()
+}
+
+// Registry looks currencies up by code. It does not change once created.
+type Registry struct {
+	currencies map[string]Currency
+}
+
+// NewRegistry creates a registry holding the given currencies
+func NewRegistry(currencies ...Currency) *Registry {
+
+	registry := &Registry{currencies: make(map[string]Currency, len(currencies))}
+	for _, currency := range currencies {
+		registry.currencies[currency.Code] = currency
+	}
+
+	return registry
+}
+
+// Load reads the currencies table. It fails when no currency is enabled,
+// since nothing could be done with such a registry.
+func Load(ctx context.Context, store db.Store) (*Registry, error) {
+
+	rows, err := store.ListCurrencies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list currencies: %w", err)
+	}
+
+	currencies := make([]Currency, 0, len(rows))
+	for _, row := range rows {
+		currencies = append(currencies, Currency{
+			Code:       row.Code,
+			Name:       row.Name,
+			MinorUnits: int(row.MinorUnits),
+			Symbol:     row.Symbol,
+			Enabled:    row.Enabled,
+		})
+	}
+
+	registry := NewRegistry(currencies...)
+	if len(registry.Enabled()) == 0 {
+		return nil, errors.New("no currency is enabled")
+	}
+
+	return registry, nil
+}
+
+// Get returns the currency with the given code, enabled or not
+func (registry *Registry) Get(code string) (Currency, bool) {
+	currency, ok := registry.currencies[code]
+	return currency, ok
+}
+
+// IsSupported reports whether code is an enabled currency
+func (registry *Registry) IsSupported(code string) bool {
+	currency, ok := registry.currencies[code]
+	return ok && currency.Enabled
+}
+
+// Enabled returns the codes of the enabled currencies in alphabetical order
+func (registry *Registry) Enabled() []string {
+
+	var codes []string
+	for code, currency := range registry.currencies {
+		if currency.Enabled {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// Format formats amount in the currency with the given code. An unknown
+// code gets the raw amount followed by the code.
+func (registry *Registry) Format(code string, amount int64) string {
+
+	currency, ok := registry.currencies[code]
+	if !ok {
+		return fmt.Sprintf("%d %s", amount, code)
+	}
+
+	return currency.Format(amount)
+}
@@ -0,0 +1,83 @@
+package currency
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+
+	usd := Currency{Code: "USD", MinorUnits: 2, Symbol: "$"}
+	jpy := Currency{Code: "JPY", MinorUnits: 0, Symbol: "¥"}
+	kwd := Currency{Code: "KWD", MinorUnits: 3, Symbol: "KD"}
+
+	testCases := []struct {
+		currency Currency
+		amount   int64
+		want     string
+	}{
+		{usd, 0, "$0.00"},
+		{usd, 5, "$0.05"},
+		{usd, 123456, "$1,234.56"},
+		{usd, -100000099, "-$1,000,000.99"},
+		{jpy, 1234567, "¥1,234,567"},
+		{jpy, 999, "¥999"},
+		{kwd, 1234, "KD1.234"},
+		{usd, math.MinInt64, "-$92,233,720,368,547,758.08"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, tc.currency.Format(tc.amount))
+	}
+}
+
+func TestRegistry(t *testing.T) {
+
+	registry := NewRegistry(
+		Currency{Code: "USD", MinorUnits: 2, Symbol: "$", Enabled: true},
+		Currency{Code: "JPY", MinorUnits: 0, Symbol: "¥"},
+	)
+
+	require.True(t, registry.IsSupported("USD"))
+	require.False(t, registry.IsSupported("JPY"))
+	require.False(t, registry.IsSupported("XYZ"))
+	require.Equal(t, []string{"USD"}, registry.Enabled())
+
+	// disabled currencies still format
+	require.Equal(t, "¥1,000", registry.Format("JPY", 1000))
+	require.Equal(t, "1000 XYZ", registry.Format("XYZ", 1000))
+}
+
+func TestLoad(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListCurrencies(gomock.Any()).
+		Times(1).
+		Return([]db.Currency{
+			{Code: "KWD", Name: "Kuwaiti Dinar", MinorUnits: 3, Symbol: "KD", Enabled: true},
+			{Code: "USD", Name: "US Dollar", MinorUnits: 2, Symbol: "$", Enabled: true},
+		}, nil)
+
+	registry, err := Load(context.Background(), store)
+	require.NoError(t, err)
+	require.Equal(t, []string{"KWD", "USD"}, registry.Enabled())
+	require.Equal(t, "KD12.500", registry.Format("KWD", 12500))
+
+	store.EXPECT().
+		ListCurrencies(gomock.Any()).
+		Times(1).
+		Return([]db.Currency{{Code: "JPY", Symbol: "¥"}}, nil)
+
+	_, err = Load(context.Background(), store)
+	require.Error(t, err)
+}
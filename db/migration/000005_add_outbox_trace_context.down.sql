@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS "outbox" DROP COLUMN IF EXISTS "trace_context";
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE "outbox" ADD COLUMN "trace_context" jsonb NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN "outbox"."trace_context" IS 'W3C trace context of the transaction that wrote the event';
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "entries_notify_account_activity" ON "entries";
+DROP FUNCTION IF EXISTS notify_account_activity();
+DROP INDEX IF EXISTS "entries_account_id_id_idx";
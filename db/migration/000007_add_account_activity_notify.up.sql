@@ -0,0 +1,17 @@
+CREATE INDEX ON "entries" ("account_id", "id");
+
+CREATE FUNCTION notify_account_activity() RETURNS trigger AS $$
+BEGIN
+  -- notifications are delivered when the inserting transaction commits, so
+  -- listeners never see entries of a transfer that rolled back
+  PERFORM pg_notify('account_activity', json_build_object(
+    'entry_id', NEW.id,
+    'account_id', NEW.account_id
+  )::text);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "entries_notify_account_activity"
+  AFTER INSERT ON "entries"
+  FOR EACH ROW EXECUTE FUNCTION notify_account_activity();
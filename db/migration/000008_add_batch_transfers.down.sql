@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS batch_transfer_items;
+DROP TABLE IF EXISTS batch_transfers;
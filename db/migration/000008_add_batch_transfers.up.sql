@@ -0,0 +1,47 @@
+CREATE TABLE "batch_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "mode" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "total_count" int NOT NULL,
+  "total_amount" bigint NOT NULL,
+  "succeeded_count" int NOT NULL DEFAULT 0,
+  "failed_count" int NOT NULL DEFAULT 0,
+  "idempotency_key" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz
+);
+
+CREATE TABLE "batch_transfer_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "row_number" int NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reference" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "error" varchar NOT NULL DEFAULT '',
+  "processed_at" timestamptz
+);
+
+CREATE INDEX ON "batch_transfers" ("owner");
+
+CREATE UNIQUE INDEX ON "batch_transfers" ("owner", "idempotency_key");
+
+CREATE UNIQUE INDEX ON "batch_transfer_items" ("batch_id", "row_number");
+
+COMMENT ON COLUMN "batch_transfers"."mode" IS 'all_or_nothing or best_effort';
+
+COMMENT ON COLUMN "batch_transfer_items"."transfer_id" IS 'set in the same transaction as the transfer, so a row is never paid twice';
+
+ALTER TABLE "batch_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "batch_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "batch_transfer_items" ADD FOREIGN KEY ("batch_id") REFERENCES "batch_transfers" ("id");
+
+ALTER TABLE "batch_transfer_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "batch_transfer_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,4 @@
+ALTER TABLE "users" DROP COLUMN "limit_profile";
+DROP TABLE IF EXISTS transfer_usage;
+DROP TABLE IF EXISTS transfer_limits;
+DROP TABLE IF EXISTS limit_profiles;
@@ -0,0 +1,50 @@
+CREATE TABLE "limit_profiles" (
+  "name" varchar PRIMARY KEY,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_limits" (
+  "profile" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "per_transaction" bigint NOT NULL,
+  "daily" bigint NOT NULL,
+  "monthly" bigint NOT NULL,
+  PRIMARY KEY ("profile", "currency"),
+  CHECK ("per_transaction" > 0 AND "daily" >= "per_transaction" AND "monthly" >= "daily")
+);
+
+CREATE TABLE "transfer_usage" (
+  "username" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "day" date NOT NULL,
+  "amount" bigint NOT NULL DEFAULT 0,
+  "count" int NOT NULL DEFAULT 0,
+  PRIMARY KEY ("username", "currency", "day")
+);
+
+COMMENT ON TABLE "transfer_limits" IS 'a currency without a row in the profile has no limits';
+
+COMMENT ON TABLE "transfer_usage" IS 'outgoing transfers per UTC day, updated in the transfer transaction';
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("profile") REFERENCES "limit_profiles" ("name") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_usage" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+INSERT INTO "limit_profiles" ("name", "description") VALUES
+  ('standard', 'default limits of every new user'),
+  ('premium', 'higher limits for verified customers');
+
+INSERT INTO "transfer_limits" ("profile", "currency", "per_transaction", "daily", "monthly") VALUES
+  ('standard', 'USD', 5000, 20000, 100000),
+  ('standard', 'EUR', 5000, 20000, 100000),
+  ('standard', 'CAD', 5000, 20000, 100000),
+  ('standard', 'NGN', 5000000, 20000000, 100000000),
+  ('premium', 'USD', 50000, 200000, 1000000),
+  ('premium', 'EUR', 50000, 200000, 1000000),
+  ('premium', 'CAD', 50000, 200000, 1000000),
+  ('premium', 'NGN', 50000000, 200000000, 1000000000);
+
+ALTER TABLE "users" ADD COLUMN "limit_profile" varchar NOT NULL DEFAULT 'standard';
+
+ALTER TABLE "users" ADD FOREIGN KEY ("limit_profile") REFERENCES "limit_profiles" ("name");
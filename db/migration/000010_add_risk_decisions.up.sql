@@ -0,0 +1,42 @@
+CREATE TABLE "risk_decisions" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "user_agent" varchar NOT NULL DEFAULT '',
+  "client_ip" varchar NOT NULL DEFAULT '',
+  "decision" varchar NOT NULL,
+  "hits" jsonb NOT NULL DEFAULT '[]',
+  "review_status" varchar,
+  "transfer_id" bigint,
+  "reviewed_by" varchar,
+  "review_note" varchar NOT NULL DEFAULT '',
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "risk_decisions" ("username", "created_at");
+
+CREATE INDEX ON "risk_decisions" ("review_status", "id");
+
+CREATE INDEX ON "transfers" ("created_at");
+
+CREATE INDEX ON "sessions" ("username", "created_at");
+
+COMMENT ON COLUMN "risk_decisions"."decision" IS 'allow, review or block';
+
+COMMENT ON COLUMN "risk_decisions"."hits" IS 'the rules that matched, with their decision and reason';
+
+COMMENT ON COLUMN "risk_decisions"."review_status" IS 'pending, approved or rejected; only set for the review decision';
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "risk_decisions" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS interest_postings;
+DROP TABLE IF EXISTS interest_accruals;
+
+DELETE FROM entries WHERE account_id IN (SELECT account_id FROM internal_accounts);
+DELETE FROM accounts WHERE id IN (SELECT account_id FROM internal_accounts);
+DROP TABLE IF EXISTS internal_accounts;
+DELETE FROM users WHERE username = 'simplebank';
+
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_product_key";
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+ALTER TABLE "accounts" DROP COLUMN "product";
+DROP TABLE IF EXISTS products;
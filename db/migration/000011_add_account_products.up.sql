@@ -0,0 +1,84 @@
+CREATE TABLE "products" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "annual_rate_bps" int NOT NULL DEFAULT 0,
+  "day_count" varchar NOT NULL DEFAULT 'actual/365',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("annual_rate_bps" >= 0),
+  CHECK ("day_count" IN ('actual/365', 'actual/360', 'actual/actual'))
+);
+
+CREATE TABLE "internal_accounts" (
+  "name" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "account_id" bigint UNIQUE NOT NULL,
+  PRIMARY KEY ("name", "currency")
+);
+
+CREATE TABLE "interest_accruals" (
+  "account_id" bigint NOT NULL,
+  "day" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" int NOT NULL,
+  "day_count" varchar NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "day")
+);
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "month" date NOT NULL,
+  "accrued_micros" bigint NOT NULL,
+  "carried_in_micros" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "carried_out_micros" bigint NOT NULL,
+  "from_entry_id" bigint,
+  "to_entry_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "interest_postings" ("account_id", "month");
+
+COMMENT ON COLUMN "products"."annual_rate_bps" IS 'annual interest rate in basis points, 250 is 2.5%';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'interest of the day in millionths of the minor unit';
+
+COMMENT ON COLUMN "interest_postings"."month" IS 'first day of the month the interest was accrued in';
+
+COMMENT ON COLUMN "interest_postings"."carried_out_micros" IS 'the part below one minor unit, added to the next posting';
+
+INSERT INTO "products" ("code", "name", "annual_rate_bps", "day_count") VALUES
+  ('checking', 'Checking account', 0, 'actual/365'),
+  ('savings', 'Savings account', 250, 'actual/365');
+
+ALTER TABLE "accounts" ADD COLUMN "product" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("product") REFERENCES "products" ("code");
+
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_product_key" UNIQUE ("owner", "currency", "product");
+
+ALTER TABLE "internal_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("from_entry_id") REFERENCES "entries" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("to_entry_id") REFERENCES "entries" ("id");
+
+-- the bank owns the internal accounts; its password hash matches no password
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "role") VALUES
+  ('simplebank', '!', 'Simple Bank', 'ledger@simplebank.internal', 'system');
+
+WITH created AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency")
+  SELECT 'simplebank', 0, currency FROM unnest(ARRAY['USD', 'EUR', 'NGN', 'CAD']) AS currency
+  RETURNING "id", "currency"
+)
+INSERT INTO "internal_accounts" ("name", "currency", "account_id")
+SELECT 'interest_expense', currency, id FROM created;
@@ -0,0 +1,12 @@
+DELETE FROM entries WHERE account_id IN (SELECT account_id FROM internal_accounts WHERE name = 'fee_income');
+DELETE FROM internal_accounts WHERE name = 'fee_income';
+DELETE FROM accounts a WHERE a.owner = 'simplebank'
+AND NOT EXISTS (SELECT 1 FROM internal_accounts i WHERE i.account_id = a.id);
+
+DROP INDEX IF EXISTS "owner_currency_product_key";
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_product_key" UNIQUE ("owner", "currency", "product");
+
+ALTER TABLE "transfers" DROP COLUMN "fee";
+
+DROP TABLE IF EXISTS fee_tiers;
+DROP TABLE IF EXISTS fee_schedules;
@@ -0,0 +1,65 @@
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "product" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "min_fee" bigint NOT NULL DEFAULT 0,
+  "max_fee" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("min_fee" >= 0),
+  CHECK ("max_fee" >= "min_fee")
+);
+
+CREATE TABLE "fee_tiers" (
+  "schedule_id" bigint NOT NULL,
+  "from_amount" bigint NOT NULL DEFAULT 0,
+  "flat_amount" bigint NOT NULL DEFAULT 0,
+  "percent_bps" int NOT NULL DEFAULT 0,
+  PRIMARY KEY ("schedule_id", "from_amount"),
+  CHECK ("from_amount" >= 0 AND "flat_amount" >= 0 AND "percent_bps" >= 0)
+);
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("product", "currency");
+
+COMMENT ON TABLE "fee_schedules" IS 'fees charged to the sender of a transfer, by product and currency of the sending account';
+
+COMMENT ON COLUMN "fee_schedules"."max_fee" IS 'no cap when null';
+
+COMMENT ON TABLE "fee_tiers" IS 'the tier with the highest from_amount not above the transfer amount applies';
+
+COMMENT ON COLUMN "fee_tiers"."percent_bps" IS 'percentage of the transfer amount in basis points, 50 is 0.5%';
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("product") REFERENCES "products" ("code");
+
+ALTER TABLE "fee_tiers" ADD FOREIGN KEY ("schedule_id") REFERENCES "fee_schedules" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfers" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_fee_check" CHECK ("fee" >= 0);
+
+-- the bank keeps one internal account per purpose and currency, so only
+-- customers are limited to one account per currency and product
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_product_key";
+
+CREATE UNIQUE INDEX "owner_currency_product_key" ON "accounts" ("owner", "currency", "product") WHERE "owner" <> 'simplebank';
+
+WITH created AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency")
+  SELECT 'simplebank', 0, currency FROM unnest(ARRAY['USD', 'EUR', 'NGN', 'CAD']) AS currency
+  RETURNING "id", "currency"
+)
+INSERT INTO "internal_accounts" ("name", "currency", "account_id")
+SELECT 'fee_income', currency, id FROM created;
+
+-- transfers out of savings cost 1.00, or 0.5% from 1,000.00 on, at most
+-- 25.00; NGN amounts are a thousand times larger
+WITH created AS (
+  INSERT INTO "fee_schedules" ("product", "currency", "description", "max_fee")
+  SELECT 'savings', currency, 'withdrawal from savings', 2500 * scale
+  FROM (VALUES ('USD', 1), ('EUR', 1), ('CAD', 1), ('NGN', 1000)) AS c (currency, scale)
+  RETURNING "id", "currency", "max_fee" / 2500 AS scale
+)
+INSERT INTO "fee_tiers" ("schedule_id", "from_amount", "flat_amount", "percent_bps")
+SELECT id, tier.from_amount * scale, tier.flat_amount * scale, tier.percent_bps
+FROM created
+CROSS JOIN (VALUES (0, 100, 0), (100000, 0, 50)) AS tier (from_amount, flat_amount, percent_bps);
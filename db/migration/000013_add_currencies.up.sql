@@ -0,0 +1,26 @@
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "minor_units" smallint NOT NULL,
+  "symbol" varchar NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("code" ~ '^[A-Z]{3}$'),
+  CHECK ("minor_units" BETWEEN 0 AND 4)
+);
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."minor_units" IS 'ISO 4217 exponent: amounts are stored in units of 10^-minor_units';
+
+COMMENT ON COLUMN "currencies"."enabled" IS 'only enabled currencies are accepted by the APIs, which load the list at startup';
+
+INSERT INTO "currencies" ("code", "name", "minor_units", "symbol", "enabled") VALUES
+  ('USD', 'US Dollar', 2, '$', true),
+  ('EUR', 'Euro', 2, '€', true),
+  ('NGN', 'Naira', 2, '₦', true),
+  ('CAD', 'Canadian Dollar', 2, 'CA$', true),
+  ('JPY', 'Yen', 0, '¥', false),
+  ('KWD', 'Kuwaiti Dinar', 3, 'KD', false);
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
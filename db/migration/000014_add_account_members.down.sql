@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS "accounts_add_owner" ON "accounts";
+DROP FUNCTION IF EXISTS add_account_owner();
+
+ALTER TABLE "accounts" DROP COLUMN "approval_threshold";
+
+DROP TABLE IF EXISTS transfer_approvals;
+DROP TABLE IF EXISTS account_members;
@@ -0,0 +1,76 @@
+CREATE TABLE "account_members" (
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'invited',
+  "invited_by" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "accepted_at" timestamptz,
+  PRIMARY KEY ("account_id", "username"),
+  CHECK ("role" IN ('owner', 'signer', 'viewer')),
+  CHECK ("status" IN ('invited', 'active'))
+);
+
+CREATE INDEX ON "account_members" ("username", "status");
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "requested_by" varchar NOT NULL,
+  "risk_decision_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "decided_by" varchar,
+  "decided_at" timestamptz,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("status" IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX ON "transfer_approvals" ("from_account_id", "status");
+
+COMMENT ON COLUMN "account_members"."role" IS 'owner: manages members and moves money, signer: moves money, viewer: read only';
+
+COMMENT ON COLUMN "account_members"."status" IS 'invited until the user accepts the invitation';
+
+COMMENT ON TABLE "transfer_approvals" IS 'transfers of joint accounts waiting for a second signer';
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("risk_decision_id") REFERENCES "risk_decisions" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "accounts" ADD COLUMN "approval_threshold" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_approval_threshold_check" CHECK ("approval_threshold" >= 0);
+
+COMMENT ON COLUMN "accounts"."approval_threshold" IS 'transfers of at least this amount need a second signer when the account has one; 0 turns approvals off';
+
+-- the owner column stays the primary owner; every account gets it as a member
+CREATE FUNCTION add_account_owner() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO account_members (account_id, username, role, status, accepted_at)
+  VALUES (NEW.id, NEW.owner, 'owner', 'active', now());
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "accounts_add_owner"
+  AFTER INSERT ON "accounts"
+  FOR EACH ROW EXECUTE FUNCTION add_account_owner();
+
+INSERT INTO "account_members" ("account_id", "username", "role", "status", "accepted_at")
+SELECT "id", "owner", 'owner', 'active', "created_at" FROM "accounts";
@@ -0,0 +1,35 @@
+CREATE TABLE "user_aliases" (
+  "kind" varchar NOT NULL,
+  "value" varchar NOT NULL,
+  "username" varchar NOT NULL,
+  "verified_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("kind", "value"),
+  CHECK ("kind" IN ('email', 'phone'))
+);
+
+CREATE INDEX ON "user_aliases" ("username");
+
+CREATE TABLE "beneficiaries" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("owner", "nickname");
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("owner", "account_id");
+
+COMMENT ON COLUMN "user_aliases"."value" IS 'lower case email, or phone number in E.164 format';
+
+COMMENT ON COLUMN "user_aliases"."verified_at" IS 'transfers are only resolved through verified aliases';
+
+COMMENT ON TABLE "beneficiaries" IS 'accounts a user saved under a nickname to send money to';
+
+ALTER TABLE "user_aliases" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
@@ -0,0 +1,35 @@
+CREATE TABLE "payment_requests" (
+  "id" bigserial PRIMARY KEY,
+  "requester" varchar NOT NULL,
+  "payer" varchar NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "memo" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "expires_at" timestamptz NOT NULL,
+  "transfer_id" bigint,
+  "settled_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("amount" > 0),
+  CHECK ("requester" <> "payer"),
+  CHECK ("status" IN ('pending', 'paid', 'declined'))
+);
+
+CREATE INDEX ON "payment_requests" ("payer", "status");
+
+CREATE INDEX ON "payment_requests" ("requester");
+
+COMMENT ON COLUMN "payment_requests"."to_account_id" IS 'account of the requester the money is paid into';
+
+COMMENT ON COLUMN "payment_requests"."status" IS 'a pending request past expires_at can no longer be paid';
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("requester") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("payer") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
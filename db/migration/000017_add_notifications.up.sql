@@ -0,0 +1,32 @@
+CREATE TABLE "notifications" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "event_type" varchar NOT NULL,
+  "title" varchar NOT NULL,
+  "body" varchar NOT NULL,
+  "data" jsonb NOT NULL DEFAULT '{}',
+  "in_app" boolean NOT NULL,
+  "dedup_key" varchar UNIQUE NOT NULL,
+  "read_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "notifications" ("username", "id") WHERE "in_app";
+
+CREATE TABLE "notification_preferences" (
+  "username" varchar NOT NULL,
+  "event_type" varchar NOT NULL,
+  "channel" varchar NOT NULL,
+  "enabled" boolean NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "event_type", "channel"),
+  CHECK ("channel" IN ('in_app', 'email', 'sms'))
+);
+
+COMMENT ON COLUMN "notifications"."in_app" IS 'shown in the inbox; every notification is stored so a redelivered event is not sent twice';
+
+COMMENT ON TABLE "notification_preferences" IS 'overrides of the default channels, which are in_app and email';
+
+ALTER TABLE "notifications" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "notification_preferences" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
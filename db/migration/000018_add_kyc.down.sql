@@ -0,0 +1,4 @@
+DELETE FROM limit_profiles WHERE name = 'tier0';
+DROP TABLE IF EXISTS kyc_documents;
+ALTER TABLE "users" DROP COLUMN "kyc_tier";
+ALTER TABLE "users" DROP COLUMN "kyc_status";
@@ -0,0 +1,46 @@
+ALTER TABLE "users" ADD COLUMN "kyc_status" varchar NOT NULL DEFAULT 'unverified';
+
+ALTER TABLE "users" ADD COLUMN "kyc_tier" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "users" ADD CONSTRAINT "users_kyc_status_check" CHECK ("kyc_status" IN ('unverified', 'pending', 'verified', 'rejected'));
+
+ALTER TABLE "users" ADD CONSTRAINT "users_kyc_tier_check" CHECK ("kyc_tier" BETWEEN 0 AND 2);
+
+COMMENT ON COLUMN "users"."kyc_tier" IS 'tier 0 users have one account and the tier0 transfer limits whatever their limit profile';
+
+CREATE TABLE "kyc_documents" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "file_name" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "size_bytes" bigint NOT NULL,
+  "sha256" varchar NOT NULL,
+  "storage_key" varchar UNIQUE NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "reviewed_by" varchar,
+  "review_note" varchar NOT NULL DEFAULT '',
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("kind" IN ('passport', 'national_id', 'drivers_license', 'proof_of_address')),
+  CHECK ("status" IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX ON "kyc_documents" ("username");
+
+CREATE INDEX ON "kyc_documents" ("status");
+
+COMMENT ON COLUMN "kyc_documents"."storage_key" IS 'where the file is kept in the document storage';
+
+ALTER TABLE "kyc_documents" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "kyc_documents" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+INSERT INTO "limit_profiles" ("name", "description") VALUES
+  ('tier0', 'limits of users who did not complete KYC');
+
+INSERT INTO "transfer_limits" ("profile", "currency", "per_transaction", "daily", "monthly") VALUES
+  ('tier0', 'USD', 1000, 2000, 5000),
+  ('tier0', 'EUR', 1000, 2000, 5000),
+  ('tier0', 'CAD', 1000, 2000, 5000),
+  ('tier0', 'NGN', 1000000, 2000000, 5000000);
@@ -0,0 +1,30 @@
+ALTER TABLE "entries" ADD COLUMN "corrects_entry_id" bigint;
+
+COMMENT ON COLUMN "entries"."corrects_entry_id" IS 'set on correcting entries; entries are never updated, a mistake is fixed by posting the difference';
+
+CREATE INDEX ON "entries" ("corrects_entry_id");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("corrects_entry_id") REFERENCES "entries" ("id");
+
+CREATE FUNCTION reject_ledger_change() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only, % is not allowed', TG_TABLE_NAME, TG_OP
+    USING ERRCODE = 'restrict_violation';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "entries_append_only"
+  BEFORE UPDATE OR DELETE ON "entries"
+  FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "entries_no_truncate"
+  BEFORE TRUNCATE ON "entries"
+  FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "transfers_append_only"
+  BEFORE UPDATE OR DELETE ON "transfers"
+  FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "transfers_no_truncate"
+  BEFORE TRUNCATE ON "transfers"
+  FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
@@ -0,0 +1,2 @@
+ALTER TABLE "entries" DROP COLUMN "entry_type";
+ALTER TABLE "entries" DROP COLUMN "transfer_id";
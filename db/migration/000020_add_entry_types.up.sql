@@ -0,0 +1,61 @@
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD COLUMN "entry_type" varchar;
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+COMMENT ON COLUMN "entries"."transfer_id" IS 'the transfer the entry was written for, set on transfer and fee entries';
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+-- entries are append-only; the backfill below is the only update they get
+ALTER TABLE "entries" DISABLE TRIGGER "entries_append_only";
+
+UPDATE "entries" SET "entry_type" = 'adjustment' WHERE "corrects_entry_id" IS NOT NULL;
+
+UPDATE "entries" e SET "entry_type" = 'interest'
+FROM "interest_postings" p
+WHERE e."id" IN (p."from_entry_id", p."to_entry_id");
+
+-- the entries of a transfer were written in its transaction, so they share
+-- its created_at. Entries are only linked when exactly one transfer matches.
+WITH "matches" AS (
+  SELECT e."id" AS "entry_id", min(t."id") AS "transfer_id", count(*) AS "candidates"
+  FROM "entries" e
+  JOIN "transfers" t ON t."created_at" = e."created_at" AND (
+    (e."account_id" = t."from_account_id" AND e."amount" = -t."amount") OR
+    (e."account_id" = t."to_account_id" AND e."amount" = t."amount"))
+  WHERE e."entry_type" IS NULL
+  GROUP BY e."id"
+)
+UPDATE "entries" e SET "entry_type" = 'transfer', "transfer_id" = m."transfer_id"
+FROM "matches" m
+WHERE e."id" = m."entry_id" AND m."candidates" = 1;
+
+WITH "matches" AS (
+  SELECT e."id" AS "entry_id", min(t."id") AS "transfer_id", count(*) AS "candidates"
+  FROM "entries" e
+  JOIN "transfers" t ON t."created_at" = e."created_at" AND t."fee" > 0 AND (
+    (e."account_id" = t."from_account_id" AND e."amount" = -t."fee") OR
+    (e."account_id" IN (SELECT "account_id" FROM "internal_accounts" WHERE "name" = 'fee_income') AND e."amount" = t."fee"))
+  WHERE e."entry_type" IS NULL
+  GROUP BY e."id"
+)
+UPDATE "entries" e SET "entry_type" = 'fee', "transfer_id" = m."transfer_id"
+FROM "matches" m
+WHERE e."id" = m."entry_id" AND m."candidates" = 1;
+
+-- opening balances and anything that could not be matched
+UPDATE "entries" SET "entry_type" = 'adjustment' WHERE "entry_type" IS NULL;
+
+ALTER TABLE "entries" ENABLE TRIGGER "entries_append_only";
+
+ALTER TABLE "entries" ALTER COLUMN "entry_type" SET NOT NULL;
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_entry_type_check"
+  CHECK ("entry_type" IN ('transfer', 'fee', 'interest', 'adjustment', 'reversal', 'fx'));
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_transfer_id_check"
+  CHECK ("entry_type" NOT IN ('transfer', 'fee') OR "transfer_id" IS NOT NULL);
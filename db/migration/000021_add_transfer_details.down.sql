@@ -0,0 +1,7 @@
+ALTER TABLE "risk_decisions" DROP COLUMN "metadata";
+ALTER TABLE "risk_decisions" DROP COLUMN "reference";
+ALTER TABLE "risk_decisions" DROP COLUMN "memo";
+
+ALTER TABLE "transfers" DROP COLUMN "metadata";
+ALTER TABLE "transfers" DROP COLUMN "reference";
+ALTER TABLE "transfers" DROP COLUMN "memo";
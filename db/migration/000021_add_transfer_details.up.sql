@@ -0,0 +1,23 @@
+ALTER TABLE "transfers" ADD COLUMN "memo" varchar NOT NULL DEFAULT '';
+ALTER TABLE "transfers" ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+ALTER TABLE "transfers" ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_details_check" CHECK (
+  octet_length("memo") <= 140 AND
+  octet_length("reference") <= 140 AND
+  jsonb_typeof("metadata") = 'object' AND
+  octet_length("metadata"::text) <= 4096
+);
+
+CREATE INDEX ON "transfers" ("reference") WHERE "reference" <> '';
+
+CREATE INDEX ON "transfers" USING gin ("metadata" jsonb_path_ops);
+
+COMMENT ON COLUMN "transfers"."reference" IS 'set by the client to match the transfer with its own records';
+
+COMMENT ON COLUMN "transfers"."metadata" IS 'string keys and values set by the client';
+
+-- held transfers keep their details until they are executed
+ALTER TABLE "risk_decisions" ADD COLUMN "memo" varchar NOT NULL DEFAULT '';
+ALTER TABLE "risk_decisions" ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+ALTER TABLE "risk_decisions" ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
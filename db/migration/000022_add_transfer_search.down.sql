@@ -0,0 +1,5 @@
+DROP VIEW IF EXISTS "transfer_activity";
+
+DROP INDEX IF EXISTS "risk_decisions_held_idx";
+DROP INDEX IF EXISTS "risk_decisions_memo_search_idx";
+DROP INDEX IF EXISTS "transfers_memo_search_idx";
@@ -0,0 +1,3 @@
+ALTER TABLE "entries" DROP COLUMN "journal_id";
+
+DROP TABLE IF EXISTS "journals";
@@ -0,0 +1,30 @@
+CREATE TABLE "journals" (
+  "id" bigserial PRIMARY KEY,
+  "journal_type" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("journal_type" IN ('transfer', 'fee', 'interest', 'adjustment', 'reversal', 'fx'))
+);
+
+CREATE INDEX ON "journals" ("transfer_id");
+
+COMMENT ON TABLE "journals" IS 'balanced postings; the entries of a journal sum to zero per currency';
+
+ALTER TABLE "journals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "entries" ADD COLUMN "journal_id" bigint;
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'the journal the entry was posted in; single-sided adjustments have none';
+
+CREATE INDEX ON "entries" ("journal_id");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+CREATE TRIGGER "journals_append_only"
+  BEFORE UPDATE OR DELETE ON "journals"
+  FOR EACH ROW EXECUTE FUNCTION reject_ledger_change();
+
+CREATE TRIGGER "journals_no_truncate"
+  BEFORE TRUNCATE ON "journals"
+  FOR EACH STATEMENT EXECUTE FUNCTION reject_ledger_change();
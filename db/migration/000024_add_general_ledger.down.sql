@@ -0,0 +1,19 @@
+-- entries are append-only; dropping the general ledger is the exception
+ALTER TABLE "entries" DISABLE TRIGGER "entries_append_only";
+
+DELETE FROM "entries" WHERE "account_id" IN (
+  SELECT "account_id" FROM "internal_accounts" WHERE "name" NOT IN ('fee_income', 'interest_expense')
+);
+
+ALTER TABLE "entries" ENABLE TRIGGER "entries_append_only";
+
+DELETE FROM "internal_accounts" WHERE "name" NOT IN ('fee_income', 'interest_expense');
+
+DELETE FROM "accounts" a WHERE a."kind" = 'gl'
+AND NOT EXISTS (SELECT 1 FROM "internal_accounts" i WHERE i."account_id" = a."id");
+
+ALTER TABLE "accounts" DROP COLUMN "kind";
+
+ALTER TABLE "internal_accounts" DROP CONSTRAINT IF EXISTS "internal_accounts_name_fkey";
+
+DROP TABLE IF EXISTS "chart_of_accounts";
@@ -0,0 +1,69 @@
+CREATE TABLE "chart_of_accounts" (
+  "code" varchar PRIMARY KEY,
+  "number" varchar UNIQUE NOT NULL,
+  "name" varchar NOT NULL,
+  "category" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("category" IN ('asset', 'liability', 'equity', 'income', 'expense'))
+);
+
+COMMENT ON TABLE "chart_of_accounts" IS 'the general ledger; every currency has one internal account per code, except customer_deposits which is the sum of the customer accounts';
+
+COMMENT ON COLUMN "chart_of_accounts"."number" IS 'orders the accounts in reports: 1 assets, 2 liabilities, 3 equity, 4 income, 5 expenses';
+
+INSERT INTO "chart_of_accounts" ("code", "number", "name", "category") VALUES
+  ('cash', '1000', 'Cash and settlement', 'asset'),
+  ('customer_deposits', '2000', 'Customer deposits', 'liability'),
+  ('retained_earnings', '3000', 'Retained earnings', 'equity'),
+  ('fee_income', '4000', 'Transfer fee income', 'income'),
+  ('fx_spread', '4100', 'FX spread income', 'income'),
+  ('interest_expense', '5000', 'Interest paid to customers', 'expense'),
+  ('adjustments', '5100', 'Ledger adjustments', 'expense');
+
+ALTER TABLE "internal_accounts" ADD FOREIGN KEY ("name") REFERENCES "chart_of_accounts" ("code");
+
+-- the bank owns the general ledger accounts and nothing else
+ALTER TABLE "accounts" ADD COLUMN "kind" varchar NOT NULL DEFAULT 'customer';
+
+UPDATE "accounts" SET "kind" = 'gl' WHERE "owner" = 'simplebank';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_kind_check"
+  CHECK ("kind" IN ('customer', 'gl') AND ("kind" = 'gl') = ("owner" = 'simplebank'));
+
+COMMENT ON COLUMN "accounts"."kind" IS 'customer, or gl for the internal accounts of the general ledger, which customers can never see or move money from';
+
+DO $$
+DECLARE
+  missing record;
+  created_id bigint;
+BEGIN
+  FOR missing IN
+    SELECT c."code", cur."code" AS "currency"
+    FROM "chart_of_accounts" c
+    CROSS JOIN "currencies" cur
+    WHERE c."code" <> 'customer_deposits'
+      AND NOT EXISTS (SELECT 1 FROM "internal_accounts" i WHERE i."name" = c."code" AND i."currency" = cur."code")
+  LOOP
+    INSERT INTO "accounts" ("owner", "balance", "currency", "kind")
+    VALUES ('simplebank', 0, missing."currency", 'gl')
+    RETURNING "id" INTO created_id;
+
+    INSERT INTO "internal_accounts" ("name", "currency", "account_id")
+    VALUES (missing."code", missing."currency", created_id);
+  END LOOP;
+END $$;
+
+-- opening balances and corrections were single-sided until now. Their other
+-- side is posted at the same time they were, so that the trial balance of
+-- any day balances: opening balances came in as cash, corrections are
+-- adjustments.
+INSERT INTO "entries" ("account_id", "amount", "entry_type", "created_at")
+SELECT i."account_id", -e."amount", 'adjustment', e."created_at"
+FROM "entries" e
+JOIN "accounts" a ON a."id" = e."account_id"
+JOIN "internal_accounts" i ON i."currency" = a."currency"
+  AND i."name" = CASE WHEN e."corrects_entry_id" IS NULL THEN 'cash' ELSE 'adjustments' END
+WHERE e."entry_type" = 'adjustment' AND e."journal_id" IS NULL;
+
+UPDATE "accounts" a SET "balance" = (SELECT COALESCE(SUM(e."amount"), 0) FROM "entries" e WHERE e."account_id" = a."id")
+WHERE a."kind" = 'gl';
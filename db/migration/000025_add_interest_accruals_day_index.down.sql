@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "interest_accruals_day_idx";
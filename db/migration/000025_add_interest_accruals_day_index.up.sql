@@ -0,0 +1 @@
+CREATE INDEX ON "interest_accruals" ("day");
@@ -0,0 +1 @@
+COMMENT ON TABLE "transfer_limits" IS 'a currency without a row in the profile has no limits, except in tier0 where it cannot be sent';
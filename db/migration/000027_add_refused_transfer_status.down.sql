@@ -0,0 +1,42 @@
+UPDATE "risk_decisions" SET "review_status" = NULL WHERE "review_status" = 'refused';
+
+CREATE OR REPLACE VIEW "transfer_activity" AS
+SELECT
+  'transfer'::varchar AS "kind",
+  t."id",
+  t."from_account_id",
+  t."to_account_id",
+  t."amount",
+  t."fee",
+  a."currency",
+  t."memo",
+  t."reference",
+  t."metadata",
+  'completed'::varchar AS "status",
+  t."created_at"
+FROM "transfers" t
+JOIN "accounts" a ON a."id" = t."from_account_id"
+UNION ALL
+SELECT
+  'decision'::varchar AS "kind",
+  d."id",
+  d."from_account_id",
+  d."to_account_id",
+  d."amount",
+  0::bigint AS "fee",
+  d."currency",
+  d."memo",
+  d."reference",
+  d."metadata",
+  (CASE
+    WHEN d."decision" = 'block' THEN 'blocked'
+    WHEN d."review_status" = 'pending' THEN 'pending_review'
+    WHEN ta."status" = 'pending' THEN 'pending_approval'
+    ELSE 'rejected'
+  END)::varchar AS "status",
+  d."created_at"
+FROM "risk_decisions" d
+LEFT JOIN "transfer_approvals" ta ON ta."risk_decision_id" = d."id"
+WHERE d."transfer_id" IS NULL;
+
+COMMENT ON COLUMN "risk_decisions"."review_status" IS 'pending, approved or rejected; only set for the review decision';
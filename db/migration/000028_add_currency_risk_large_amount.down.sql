@@ -0,0 +1 @@
+ALTER TABLE "currencies" DROP COLUMN "risk_large_amount";
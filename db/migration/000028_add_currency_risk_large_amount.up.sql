@@ -0,0 +1,13 @@
+ALTER TABLE "currencies" ADD COLUMN "risk_large_amount" bigint NOT NULL DEFAULT 0 CHECK ("risk_large_amount" >= 0);
+
+COMMENT ON COLUMN "currencies"."risk_large_amount" IS 'transfers of at least this amount, in minor units, to a new payee are held for review; 0 turns the rule off';
+
+UPDATE "currencies" SET "risk_large_amount" = CASE "code"
+  WHEN 'USD' THEN 100000
+  WHEN 'EUR' THEN 100000
+  WHEN 'CAD' THEN 130000
+  WHEN 'NGN' THEN 150000000
+  WHEN 'JPY' THEN 150000
+  WHEN 'KWD' THEN 300000
+  ELSE 0
+END;
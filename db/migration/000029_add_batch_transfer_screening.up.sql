@@ -0,0 +1,49 @@
+ALTER TABLE "batch_transfer_items" ADD COLUMN "risk_decision_id" bigint;
+
+ALTER TABLE "batch_transfer_items" ADD FOREIGN KEY ("risk_decision_id") REFERENCES "risk_decisions" ("id");
+
+COMMENT ON COLUMN "batch_transfer_items"."risk_decision_id" IS 'screening of the row when the batch was created; rows the screener did not allow are failed and never paid';
+
+-- allowed batch rows show up as transfers once paid, until then the batch reports them
+CREATE OR REPLACE VIEW "transfer_activity" AS
+SELECT
+  'transfer'::varchar AS "kind",
+  t."id",
+  t."from_account_id",
+  t."to_account_id",
+  t."amount",
+  t."fee",
+  a."currency",
+  t."memo",
+  t."reference",
+  t."metadata",
+  'completed'::varchar AS "status",
+  t."created_at"
+FROM "transfers" t
+JOIN "accounts" a ON a."id" = t."from_account_id"
+UNION ALL
+SELECT
+  'decision'::varchar AS "kind",
+  d."id",
+  d."from_account_id",
+  d."to_account_id",
+  d."amount",
+  0::bigint AS "fee",
+  d."currency",
+  d."memo",
+  d."reference",
+  d."metadata",
+  (CASE
+    WHEN d."decision" = 'block' THEN 'blocked'
+    WHEN d."review_status" = 'pending' THEN 'pending_review'
+    WHEN d."review_status" = 'refused' THEN 'refused'
+    WHEN ta."status" = 'pending' THEN 'pending_approval'
+    ELSE 'rejected'
+  END)::varchar AS "status",
+  d."created_at"
+FROM "risk_decisions" d
+LEFT JOIN "transfer_approvals" ta ON ta."risk_decision_id" = d."id"
+WHERE d."transfer_id" IS NULL
+AND NOT (d."decision" = 'allow' AND EXISTS (
+  SELECT 1 FROM "batch_transfer_items" bi WHERE bi."risk_decision_id" = d."id"
+));
@@ -6,7 +6,9 @@ package mockdb
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,346 +38,2027 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
-// AddAccountBalance mocks base method.
-func (m *MockStore) AddAccountBalance(ctx context.Context, arg db.AddAccountBalanceParams) (db.Account, error) {
+// AcceptAccountInvitation mocks base method.
+func (m *MockStore) AcceptAccountInvitation(ctx context.Context, arg db.AcceptAccountInvitationParams) (db.AccountMember, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddAccountBalance", ctx, arg)
+	ret := m.ctrl.Call(m, "AcceptAccountInvitation", ctx, arg)
+	ret0, _ := ret[0].(db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptAccountInvitation indicates an expected call of AcceptAccountInvitation.
+func (mr *MockStoreMockRecorder) AcceptAccountInvitation(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptAccountInvitation", reflect.TypeOf((*MockStore)(nil).AcceptAccountInvitation), ctx, arg)
+}
+
+// AddTransferUsage mocks base method.
+func (m *MockStore) AddTransferUsage(ctx context.Context, arg db.AddTransferUsageParams) (db.TransferUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransferUsage", ctx, arg)
+	ret0, _ := ret[0].(db.TransferUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTransferUsage indicates an expected call of AddTransferUsage.
+func (mr *MockStoreMockRecorder) AddTransferUsage(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransferUsage", reflect.TypeOf((*MockStore)(nil).AddTransferUsage), ctx, arg)
+}
+
+// ApproveTransferTx mocks base method.
+func (m *MockStore) ApproveTransferTx(ctx context.Context, arg db.ApproveTransferTxParams) (db.ApproveTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.ApproveTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveTransferTx indicates an expected call of ApproveTransferTx.
+func (mr *MockStoreMockRecorder) ApproveTransferTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveTransferTx", reflect.TypeOf((*MockStore)(nil).ApproveTransferTx), ctx, arg)
+}
+
+// BatchTransferAllTx mocks base method.
+func (m *MockStore) BatchTransferAllTx(ctx context.Context, arg db.BatchTransferAllTxParams) (db.BatchTransferAllTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchTransferAllTx", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransferAllTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchTransferAllTx indicates an expected call of BatchTransferAllTx.
+func (mr *MockStoreMockRecorder) BatchTransferAllTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchTransferAllTx", reflect.TypeOf((*MockStore)(nil).BatchTransferAllTx), ctx, arg)
+}
+
+// BatchTransferItemTx mocks base method.
+func (m *MockStore) BatchTransferItemTx(ctx context.Context, arg db.BatchTransferItemTxParams) (db.BatchTransferItemTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchTransferItemTx", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransferItemTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchTransferItemTx indicates an expected call of BatchTransferItemTx.
+func (mr *MockStoreMockRecorder) BatchTransferItemTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchTransferItemTx", reflect.TypeOf((*MockStore)(nil).BatchTransferItemTx), ctx, arg)
+}
+
+// BlockAllSessions mocks base method.
+func (m *MockStore) BlockAllSessions(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockAllSessions", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockAllSessions indicates an expected call of BlockAllSessions.
+func (mr *MockStoreMockRecorder) BlockAllSessions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAllSessions", reflect.TypeOf((*MockStore)(nil).BlockAllSessions), ctx)
+}
+
+// CompleteBatchTransfer mocks base method.
+func (m *MockStore) CompleteBatchTransfer(ctx context.Context, arg db.CompleteBatchTransferParams) (db.BatchTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteBatchTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteBatchTransfer indicates an expected call of CompleteBatchTransfer.
+func (mr *MockStoreMockRecorder) CompleteBatchTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteBatchTransfer", reflect.TypeOf((*MockStore)(nil).CompleteBatchTransfer), ctx, arg)
+}
+
+// CorrectEntryTx mocks base method.
+func (m *MockStore) CorrectEntryTx(ctx context.Context, arg db.CorrectEntryTxParams) (db.CorrectEntryTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CorrectEntryTx", ctx, arg)
+	ret0, _ := ret[0].(db.CorrectEntryTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CorrectEntryTx indicates an expected call of CorrectEntryTx.
+func (mr *MockStoreMockRecorder) CorrectEntryTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CorrectEntryTx", reflect.TypeOf((*MockStore)(nil).CorrectEntryTx), ctx, arg)
+}
+
+// CountAccountSigners mocks base method.
+func (m *MockStore) CountAccountSigners(ctx context.Context, accountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountSigners", ctx, accountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountSigners indicates an expected call of CountAccountSigners.
+func (mr *MockStoreMockRecorder) CountAccountSigners(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountSigners", reflect.TypeOf((*MockStore)(nil).CountAccountSigners), ctx, accountID)
+}
+
+// CountAccountsByOwner mocks base method.
+func (m *MockStore) CountAccountsByOwner(ctx context.Context, owner string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountsByOwner", ctx, owner)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountsByOwner indicates an expected call of CountAccountsByOwner.
+func (mr *MockStoreMockRecorder) CountAccountsByOwner(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountsByOwner", reflect.TypeOf((*MockStore)(nil).CountAccountsByOwner), ctx, owner)
+}
+
+// CountBatchTransferItems mocks base method.
+func (m *MockStore) CountBatchTransferItems(ctx context.Context, batchID int64) (db.CountBatchTransferItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBatchTransferItems", ctx, batchID)
+	ret0, _ := ret[0].(db.CountBatchTransferItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBatchTransferItems indicates an expected call of CountBatchTransferItems.
+func (mr *MockStoreMockRecorder) CountBatchTransferItems(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBatchTransferItems", reflect.TypeOf((*MockStore)(nil).CountBatchTransferItems), ctx, batchID)
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockStore) CountUnreadNotifications(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockStoreMockRecorder) CountUnreadNotifications(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockStore)(nil).CountUnreadNotifications), ctx, username)
+}
+
+// CountUserDeviceSessions mocks base method.
+func (m *MockStore) CountUserDeviceSessions(ctx context.Context, arg db.CountUserDeviceSessionsParams) (db.CountUserDeviceSessionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserDeviceSessions", ctx, arg)
+	ret0, _ := ret[0].(db.CountUserDeviceSessionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserDeviceSessions indicates an expected call of CountUserDeviceSessions.
+func (mr *MockStoreMockRecorder) CountUserDeviceSessions(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserDeviceSessions", reflect.TypeOf((*MockStore)(nil).CountUserDeviceSessions), ctx, arg)
+}
+
+// CreateAccount mocks base method.
+func (m *MockStore) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
+}
+
+// CreateAccountTx mocks base method.
+func (m *MockStore) CreateAccountTx(ctx context.Context, arg db.CreateAccountTxParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountTx", ctx, arg)
 	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddAccountBalance indicates an expected call of AddAccountBalance.
-func (mr *MockStoreMockRecorder) AddAccountBalance(ctx, arg interface{}) *gomock.Call {
+// CreateAccountTx indicates an expected call of CreateAccountTx.
+func (mr *MockStoreMockRecorder) CreateAccountTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountTx", reflect.TypeOf((*MockStore)(nil).CreateAccountTx), ctx, arg)
+}
+
+// CreateBatchTransfer mocks base method.
+func (m *MockStore) CreateBatchTransfer(ctx context.Context, arg db.CreateBatchTransferParams) (db.BatchTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatchTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatchTransfer indicates an expected call of CreateBatchTransfer.
+func (mr *MockStoreMockRecorder) CreateBatchTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchTransfer", reflect.TypeOf((*MockStore)(nil).CreateBatchTransfer), ctx, arg)
+}
+
+// CreateBatchTransferItem mocks base method.
+func (m *MockStore) CreateBatchTransferItem(ctx context.Context, arg db.CreateBatchTransferItemParams) (db.BatchTransferItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatchTransferItem", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransferItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatchTransferItem indicates an expected call of CreateBatchTransferItem.
+func (mr *MockStoreMockRecorder) CreateBatchTransferItem(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchTransferItem", reflect.TypeOf((*MockStore)(nil).CreateBatchTransferItem), ctx, arg)
+}
+
+// CreateBatchTransferTx mocks base method.
+func (m *MockStore) CreateBatchTransferTx(ctx context.Context, arg db.CreateBatchTransferTxParams) (db.CreateBatchTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatchTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateBatchTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatchTransferTx indicates an expected call of CreateBatchTransferTx.
+func (mr *MockStoreMockRecorder) CreateBatchTransferTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchTransferTx", reflect.TypeOf((*MockStore)(nil).CreateBatchTransferTx), ctx, arg)
+}
+
+// CreateBeneficiary mocks base method.
+func (m *MockStore) CreateBeneficiary(ctx context.Context, arg db.CreateBeneficiaryParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBeneficiary", ctx, arg)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBeneficiary indicates an expected call of CreateBeneficiary.
+func (mr *MockStoreMockRecorder) CreateBeneficiary(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBeneficiary", reflect.TypeOf((*MockStore)(nil).CreateBeneficiary), ctx, arg)
+}
+
+// CreateInterestAccrual mocks base method.
+func (m *MockStore) CreateInterestAccrual(ctx context.Context, arg db.CreateInterestAccrualParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestAccrual", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestAccrual indicates an expected call of CreateInterestAccrual.
+func (mr *MockStoreMockRecorder) CreateInterestAccrual(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestAccrual", reflect.TypeOf((*MockStore)(nil).CreateInterestAccrual), ctx, arg)
+}
+
+// CreateInterestPosting mocks base method.
+func (m *MockStore) CreateInterestPosting(ctx context.Context, arg db.CreateInterestPostingParams) (db.InterestPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestPosting", ctx, arg)
+	ret0, _ := ret[0].(db.InterestPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestPosting indicates an expected call of CreateInterestPosting.
+func (mr *MockStoreMockRecorder) CreateInterestPosting(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestPosting", reflect.TypeOf((*MockStore)(nil).CreateInterestPosting), ctx, arg)
+}
+
+// CreateInternalAccount mocks base method.
+func (m *MockStore) CreateInternalAccount(ctx context.Context, arg db.CreateInternalAccountParams) (db.InternalAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInternalAccount", ctx, arg)
+	ret0, _ := ret[0].(db.InternalAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInternalAccount indicates an expected call of CreateInternalAccount.
+func (mr *MockStoreMockRecorder) CreateInternalAccount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInternalAccount", reflect.TypeOf((*MockStore)(nil).CreateInternalAccount), ctx, arg)
+}
+
+// CreateKycDocument mocks base method.
+func (m *MockStore) CreateKycDocument(ctx context.Context, arg db.CreateKycDocumentParams) (db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKycDocument", ctx, arg)
+	ret0, _ := ret[0].(db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKycDocument indicates an expected call of CreateKycDocument.
+func (mr *MockStoreMockRecorder) CreateKycDocument(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKycDocument", reflect.TypeOf((*MockStore)(nil).CreateKycDocument), ctx, arg)
+}
+
+// CreateNotification mocks base method.
+func (m *MockStore) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, arg)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockStoreMockRecorder) CreateNotification(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockStore)(nil).CreateNotification), ctx, arg)
+}
+
+// CreateOutboxEvent mocks base method.
+func (m *MockStore) CreateOutboxEvent(ctx context.Context, arg db.CreateOutboxEventParams) (db.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockStoreMockRecorder) CreateOutboxEvent(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockStore)(nil).CreateOutboxEvent), ctx, arg)
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockStore) CreatePaymentRequest(ctx context.Context, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockStoreMockRecorder) CreatePaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockStore)(nil).CreatePaymentRequest), ctx, arg)
+}
+
+// CreateRiskDecision mocks base method.
+func (m *MockStore) CreateRiskDecision(ctx context.Context, arg db.CreateRiskDecisionParams) (db.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRiskDecision", ctx, arg)
+	ret0, _ := ret[0].(db.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRiskDecision indicates an expected call of CreateRiskDecision.
+func (mr *MockStoreMockRecorder) CreateRiskDecision(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiskDecision", reflect.TypeOf((*MockStore)(nil).CreateRiskDecision), ctx, arg)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+}
+
+// CreateSessionTx mocks base method.
+func (m *MockStore) CreateSessionTx(ctx context.Context, arg db.CreateSessionTxParams) (db.CreateSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSessionTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSessionTx indicates an expected call of CreateSessionTx.
+func (mr *MockStoreMockRecorder) CreateSessionTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSessionTx", reflect.TypeOf((*MockStore)(nil).CreateSessionTx), ctx, arg)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+}
+
+// CreateTransferApproval mocks base method.
+func (m *MockStore) CreateTransferApproval(ctx context.Context, arg db.CreateTransferApprovalParams) (db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferApproval", ctx, arg)
+	ret0, _ := ret[0].(db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferApproval indicates an expected call of CreateTransferApproval.
+func (mr *MockStoreMockRecorder) CreateTransferApproval(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferApproval", reflect.TypeOf((*MockStore)(nil).CreateTransferApproval), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateUserTx mocks base method.
+func (m *MockStore) CreateUserTx(ctx context.Context, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockStoreMockRecorder) CreateUserTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), ctx, arg)
+}
+
+// DecideTransferApproval mocks base method.
+func (m *MockStore) DecideTransferApproval(ctx context.Context, arg db.DecideTransferApprovalParams) (db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideTransferApproval", ctx, arg)
+	ret0, _ := ret[0].(db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideTransferApproval indicates an expected call of DecideTransferApproval.
+func (mr *MockStoreMockRecorder) DecideTransferApproval(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideTransferApproval", reflect.TypeOf((*MockStore)(nil).DecideTransferApproval), ctx, arg)
+}
+
+// DeclinePaymentRequestTx mocks base method.
+func (m *MockStore) DeclinePaymentRequestTx(ctx context.Context, arg db.DeclinePaymentRequestTxParams) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclinePaymentRequestTx", ctx, arg)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclinePaymentRequestTx indicates an expected call of DeclinePaymentRequestTx.
+func (mr *MockStoreMockRecorder) DeclinePaymentRequestTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclinePaymentRequestTx", reflect.TypeOf((*MockStore)(nil).DeclinePaymentRequestTx), ctx, arg)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+}
+
+// DeleteAccountMember mocks base method.
+func (m *MockStore) DeleteAccountMember(ctx context.Context, arg db.DeleteAccountMemberParams) (db.AccountMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountMember", ctx, arg)
+	ret0, _ := ret[0].(db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccountMember indicates an expected call of DeleteAccountMember.
+func (mr *MockStoreMockRecorder) DeleteAccountMember(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountMember", reflect.TypeOf((*MockStore)(nil).DeleteAccountMember), ctx, arg)
+}
+
+// DeleteBeneficiary mocks base method.
+func (m *MockStore) DeleteBeneficiary(ctx context.Context, arg db.DeleteBeneficiaryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBeneficiary", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBeneficiary indicates an expected call of DeleteBeneficiary.
+func (mr *MockStoreMockRecorder) DeleteBeneficiary(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBeneficiary", reflect.TypeOf((*MockStore)(nil).DeleteBeneficiary), ctx, arg)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+}
+
+// GetAccountFeeSchedule mocks base method.
+func (m *MockStore) GetAccountFeeSchedule(ctx context.Context, id int64) (db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountFeeSchedule", ctx, id)
+	ret0, _ := ret[0].(db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountFeeSchedule indicates an expected call of GetAccountFeeSchedule.
+func (mr *MockStoreMockRecorder) GetAccountFeeSchedule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFeeSchedule", reflect.TypeOf((*MockStore)(nil).GetAccountFeeSchedule), ctx, id)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+}
+
+// GetAccountMember mocks base method.
+func (m *MockStore) GetAccountMember(ctx context.Context, arg db.GetAccountMemberParams) (db.AccountMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountMember", ctx, arg)
+	ret0, _ := ret[0].(db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountMember indicates an expected call of GetAccountMember.
+func (mr *MockStoreMockRecorder) GetAccountMember(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMember", reflect.TypeOf((*MockStore)(nil).GetAccountMember), ctx, arg)
+}
+
+// GetAccountTransferLimit mocks base method.
+func (m *MockStore) GetAccountTransferLimit(ctx context.Context, id int64) (db.GetAccountTransferLimitRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTransferLimit", ctx, id)
+	ret0, _ := ret[0].(db.GetAccountTransferLimitRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTransferLimit indicates an expected call of GetAccountTransferLimit.
+func (mr *MockStoreMockRecorder) GetAccountTransferLimit(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransferLimit", reflect.TypeOf((*MockStore)(nil).GetAccountTransferLimit), ctx, id)
+}
+
+// GetBatchTransfer mocks base method.
+func (m *MockStore) GetBatchTransfer(ctx context.Context, id int64) (db.BatchTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchTransfer", ctx, id)
+	ret0, _ := ret[0].(db.BatchTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchTransfer indicates an expected call of GetBatchTransfer.
+func (mr *MockStoreMockRecorder) GetBatchTransfer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchTransfer", reflect.TypeOf((*MockStore)(nil).GetBatchTransfer), ctx, id)
+}
+
+// GetBatchTransferByIdempotencyKey mocks base method.
+func (m *MockStore) GetBatchTransferByIdempotencyKey(ctx context.Context, arg db.GetBatchTransferByIdempotencyKeyParams) (db.BatchTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchTransferByIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchTransferByIdempotencyKey indicates an expected call of GetBatchTransferByIdempotencyKey.
+func (mr *MockStoreMockRecorder) GetBatchTransferByIdempotencyKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchTransferByIdempotencyKey", reflect.TypeOf((*MockStore)(nil).GetBatchTransferByIdempotencyKey), ctx, arg)
+}
+
+// GetBatchTransferItemForUpdate mocks base method.
+func (m *MockStore) GetBatchTransferItemForUpdate(ctx context.Context, id int64) (db.BatchTransferItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchTransferItemForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.BatchTransferItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchTransferItemForUpdate indicates an expected call of GetBatchTransferItemForUpdate.
+func (mr *MockStoreMockRecorder) GetBatchTransferItemForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchTransferItemForUpdate", reflect.TypeOf((*MockStore)(nil).GetBatchTransferItemForUpdate), ctx, id)
+}
+
+// GetBeneficiary mocks base method.
+func (m *MockStore) GetBeneficiary(ctx context.Context, arg db.GetBeneficiaryParams) (db.GetBeneficiaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiary", ctx, arg)
+	ret0, _ := ret[0].(db.GetBeneficiaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary.
+func (mr *MockStoreMockRecorder) GetBeneficiary(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockStore)(nil).GetBeneficiary), ctx, arg)
+}
+
+// GetCorrectedEntryAmount mocks base method.
+func (m *MockStore) GetCorrectedEntryAmount(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCorrectedEntryAmount", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCorrectedEntryAmount indicates an expected call of GetCorrectedEntryAmount.
+func (mr *MockStoreMockRecorder) GetCorrectedEntryAmount(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCorrectedEntryAmount", reflect.TypeOf((*MockStore)(nil).GetCorrectedEntryAmount), ctx, id)
+}
+
+// GetCurrency mocks base method.
+func (m *MockStore) GetCurrency(ctx context.Context, code string) (db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", ctx, code)
+	ret0, _ := ret[0].(db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockStoreMockRecorder) GetCurrency(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockStore)(nil).GetCurrency), ctx, code)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+}
+
+// GetInterestPosting mocks base method.
+func (m *MockStore) GetInterestPosting(ctx context.Context, arg db.GetInterestPostingParams) (db.InterestPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestPosting", ctx, arg)
+	ret0, _ := ret[0].(db.InterestPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestPosting indicates an expected call of GetInterestPosting.
+func (mr *MockStoreMockRecorder) GetInterestPosting(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestPosting", reflect.TypeOf((*MockStore)(nil).GetInterestPosting), ctx, arg)
+}
+
+// GetInternalAccount mocks base method.
+func (m *MockStore) GetInternalAccount(ctx context.Context, arg db.GetInternalAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInternalAccount", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInternalAccount indicates an expected call of GetInternalAccount.
+func (mr *MockStoreMockRecorder) GetInternalAccount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInternalAccount", reflect.TypeOf((*MockStore)(nil).GetInternalAccount), ctx, arg)
+}
+
+// GetJournal mocks base method.
+func (m *MockStore) GetJournal(ctx context.Context, id int64) (db.Journal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournal", ctx, id)
+	ret0, _ := ret[0].(db.Journal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournal indicates an expected call of GetJournal.
+func (mr *MockStoreMockRecorder) GetJournal(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournal", reflect.TypeOf((*MockStore)(nil).GetJournal), ctx, id)
+}
+
+// GetKycDocumentForUpdate mocks base method.
+func (m *MockStore) GetKycDocumentForUpdate(ctx context.Context, id int64) (db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKycDocumentForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKycDocumentForUpdate indicates an expected call of GetKycDocumentForUpdate.
+func (mr *MockStoreMockRecorder) GetKycDocumentForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycDocumentForUpdate", reflect.TypeOf((*MockStore)(nil).GetKycDocumentForUpdate), ctx, id)
+}
+
+// GetLastAccountEntryID mocks base method.
+func (m *MockStore) GetLastAccountEntryID(ctx context.Context, accountID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastAccountEntryID", ctx, accountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastAccountEntryID indicates an expected call of GetLastAccountEntryID.
+func (mr *MockStoreMockRecorder) GetLastAccountEntryID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAccountEntryID", reflect.TypeOf((*MockStore)(nil).GetLastAccountEntryID), ctx, accountID)
+}
+
+// GetLastInterestAccrualDay mocks base method.
+func (m *MockStore) GetLastInterestAccrualDay(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastInterestAccrualDay", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastInterestAccrualDay indicates an expected call of GetLastInterestAccrualDay.
+func (mr *MockStoreMockRecorder) GetLastInterestAccrualDay(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastInterestAccrualDay", reflect.TypeOf((*MockStore)(nil).GetLastInterestAccrualDay), ctx)
+}
+
+// GetLastInterestCarry mocks base method.
+func (m *MockStore) GetLastInterestCarry(ctx context.Context, arg db.GetLastInterestCarryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastInterestCarry", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastInterestCarry indicates an expected call of GetLastInterestCarry.
+func (mr *MockStoreMockRecorder) GetLastInterestCarry(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastInterestCarry", reflect.TypeOf((*MockStore)(nil).GetLastInterestCarry), ctx, arg)
+}
+
+// GetLimitProfile mocks base method.
+func (m *MockStore) GetLimitProfile(ctx context.Context, name string) (db.LimitProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitProfile", ctx, name)
+	ret0, _ := ret[0].(db.LimitProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimitProfile indicates an expected call of GetLimitProfile.
+func (mr *MockStoreMockRecorder) GetLimitProfile(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitProfile", reflect.TypeOf((*MockStore)(nil).GetLimitProfile), ctx, name)
+}
+
+// GetOutboxEvent mocks base method.
+func (m *MockStore) GetOutboxEvent(ctx context.Context, id int64) (db.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxEvent", ctx, id)
+	ret0, _ := ret[0].(db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEvent indicates an expected call of GetOutboxEvent.
+func (mr *MockStoreMockRecorder) GetOutboxEvent(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEvent", reflect.TypeOf((*MockStore)(nil).GetOutboxEvent), ctx, id)
+}
+
+// GetOutboxEventByDedupKey mocks base method.
+func (m *MockStore) GetOutboxEventByDedupKey(ctx context.Context, dedupKey string) (db.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxEventByDedupKey", ctx, dedupKey)
+	ret0, _ := ret[0].(db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEventByDedupKey indicates an expected call of GetOutboxEventByDedupKey.
+func (mr *MockStoreMockRecorder) GetOutboxEventByDedupKey(ctx, dedupKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEventByDedupKey", reflect.TypeOf((*MockStore)(nil).GetOutboxEventByDedupKey), ctx, dedupKey)
+}
+
+// GetPaymentRequest mocks base method.
+func (m *MockStore) GetPaymentRequest(ctx context.Context, id int64) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequest", ctx, id)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequest indicates an expected call of GetPaymentRequest.
+func (mr *MockStoreMockRecorder) GetPaymentRequest(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequest", reflect.TypeOf((*MockStore)(nil).GetPaymentRequest), ctx, id)
+}
+
+// GetPaymentRequestForUpdate mocks base method.
+func (m *MockStore) GetPaymentRequestForUpdate(ctx context.Context, id int64) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequestForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequestForUpdate indicates an expected call of GetPaymentRequestForUpdate.
+func (mr *MockStoreMockRecorder) GetPaymentRequestForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequestForUpdate", reflect.TypeOf((*MockStore)(nil).GetPaymentRequestForUpdate), ctx, id)
+}
+
+// GetProduct mocks base method.
+func (m *MockStore) GetProduct(ctx context.Context, code string) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, code)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockStoreMockRecorder) GetProduct(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), ctx, code)
+}
+
+// GetRecipientAccount mocks base method.
+func (m *MockStore) GetRecipientAccount(ctx context.Context, arg db.GetRecipientAccountParams) (db.GetRecipientAccountRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipientAccount", ctx, arg)
+	ret0, _ := ret[0].(db.GetRecipientAccountRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipientAccount indicates an expected call of GetRecipientAccount.
+func (mr *MockStoreMockRecorder) GetRecipientAccount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipientAccount", reflect.TypeOf((*MockStore)(nil).GetRecipientAccount), ctx, arg)
+}
+
+// GetRiskDecision mocks base method.
+func (m *MockStore) GetRiskDecision(ctx context.Context, id int64) (db.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskDecision", ctx, id)
+	ret0, _ := ret[0].(db.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskDecision indicates an expected call of GetRiskDecision.
+func (mr *MockStoreMockRecorder) GetRiskDecision(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskDecision", reflect.TypeOf((*MockStore)(nil).GetRiskDecision), ctx, id)
+}
+
+// GetRiskDecisionForUpdate mocks base method.
+func (m *MockStore) GetRiskDecisionForUpdate(ctx context.Context, id int64) (db.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskDecisionForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskDecisionForUpdate indicates an expected call of GetRiskDecisionForUpdate.
+func (mr *MockStoreMockRecorder) GetRiskDecisionForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskDecisionForUpdate", reflect.TypeOf((*MockStore)(nil).GetRiskDecisionForUpdate), ctx, id)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+}
+
+// GetTransferApprovalForUpdate mocks base method.
+func (m *MockStore) GetTransferApprovalForUpdate(ctx context.Context, id int64) (db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferApprovalForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferApprovalForUpdate indicates an expected call of GetTransferApprovalForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferApprovalForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferApprovalForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferApprovalForUpdate), ctx, id)
+}
+
+// GetTransferRiskSignals mocks base method.
+func (m *MockStore) GetTransferRiskSignals(ctx context.Context, arg db.GetTransferRiskSignalsParams) (db.GetTransferRiskSignalsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferRiskSignals", ctx, arg)
+	ret0, _ := ret[0].(db.GetTransferRiskSignalsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferRiskSignals indicates an expected call of GetTransferRiskSignals.
+func (mr *MockStoreMockRecorder) GetTransferRiskSignals(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferRiskSignals", reflect.TypeOf((*MockStore)(nil).GetTransferRiskSignals), ctx, arg)
+}
+
+// GetTransferUsageBetween mocks base method.
+func (m *MockStore) GetTransferUsageBetween(ctx context.Context, arg db.GetTransferUsageBetweenParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferUsageBetween", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferUsageBetween indicates an expected call of GetTransferUsageBetween.
+func (mr *MockStoreMockRecorder) GetTransferUsageBetween(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferUsageBetween", reflect.TypeOf((*MockStore)(nil).GetTransferUsageBetween), ctx, arg)
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockStore) GetTrialBalance(ctx context.Context, arg db.GetTrialBalanceParams) ([]db.GetTrialBalanceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, arg)
+	ret0, _ := ret[0].([]db.GetTrialBalanceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockStoreMockRecorder) GetTrialBalance(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockStore)(nil).GetTrialBalance), ctx, arg)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context, username string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, username)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, username)
+}
+
+// GetUserKyc mocks base method.
+func (m *MockStore) GetUserKyc(ctx context.Context, username string) (db.GetUserKycRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserKyc", ctx, username)
+	ret0, _ := ret[0].(db.GetUserKycRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserKyc indicates an expected call of GetUserKyc.
+func (mr *MockStoreMockRecorder) GetUserKyc(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKyc", reflect.TypeOf((*MockStore)(nil).GetUserKyc), ctx, username)
+}
+
+// GetUserKycForUpdate mocks base method.
+func (m *MockStore) GetUserKycForUpdate(ctx context.Context, username string) (db.GetUserKycForUpdateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserKycForUpdate", ctx, username)
+	ret0, _ := ret[0].(db.GetUserKycForUpdateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserKycForUpdate indicates an expected call of GetUserKycForUpdate.
+func (mr *MockStoreMockRecorder) GetUserKycForUpdate(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKycForUpdate", reflect.TypeOf((*MockStore)(nil).GetUserKycForUpdate), ctx, username)
+}
+
+// GetVerifiedAliasUsername mocks base method.
+func (m *MockStore) GetVerifiedAliasUsername(ctx context.Context, arg db.GetVerifiedAliasUsernameParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerifiedAliasUsername", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerifiedAliasUsername indicates an expected call of GetVerifiedAliasUsername.
+func (mr *MockStoreMockRecorder) GetVerifiedAliasUsername(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerifiedAliasUsername", reflect.TypeOf((*MockStore)(nil).GetVerifiedAliasUsername), ctx, arg)
+}
+
+// InviteAccountMember mocks base method.
+func (m *MockStore) InviteAccountMember(ctx context.Context, arg db.InviteAccountMemberParams) (db.AccountMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteAccountMember", ctx, arg)
+	ret0, _ := ret[0].(db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteAccountMember indicates an expected call of InviteAccountMember.
+func (mr *MockStoreMockRecorder) InviteAccountMember(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteAccountMember", reflect.TypeOf((*MockStore)(nil).InviteAccountMember), ctx, arg)
+}
+
+// ListAccountActivityAfter mocks base method.
+func (m *MockStore) ListAccountActivityAfter(ctx context.Context, arg db.ListAccountActivityAfterParams) ([]db.ListAccountActivityAfterRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountActivityAfter", ctx, arg)
+	ret0, _ := ret[0].([]db.ListAccountActivityAfterRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountActivityAfter indicates an expected call of ListAccountActivityAfter.
+func (mr *MockStoreMockRecorder) ListAccountActivityAfter(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountActivityAfter", reflect.TypeOf((*MockStore)(nil).ListAccountActivityAfter), ctx, arg)
+}
+
+// ListAccountEntriesAfter mocks base method.
+func (m *MockStore) ListAccountEntriesAfter(ctx context.Context, arg db.ListAccountEntriesAfterParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountEntriesAfter", ctx, arg)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountEntriesAfter indicates an expected call of ListAccountEntriesAfter.
+func (mr *MockStoreMockRecorder) ListAccountEntriesAfter(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountEntriesAfter", reflect.TypeOf((*MockStore)(nil).ListAccountEntriesAfter), ctx, arg)
+}
+
+// ListAccountMembers mocks base method.
+func (m *MockStore) ListAccountMembers(ctx context.Context, accountID int64) ([]db.AccountMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountMembers", ctx, accountID)
+	ret0, _ := ret[0].([]db.AccountMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountMembers indicates an expected call of ListAccountMembers.
+func (mr *MockStoreMockRecorder) ListAccountMembers(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountMembers", reflect.TypeOf((*MockStore)(nil).ListAccountMembers), ctx, accountID)
+}
+
+// ListAccountStatement mocks base method.
+func (m *MockStore) ListAccountStatement(ctx context.Context, arg db.ListAccountStatementParams) ([]db.ListAccountStatementRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountStatement", ctx, arg)
+	ret0, _ := ret[0].([]db.ListAccountStatementRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountStatement indicates an expected call of ListAccountStatement.
+func (mr *MockStoreMockRecorder) ListAccountStatement(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountStatement", reflect.TypeOf((*MockStore)(nil).ListAccountStatement), ctx, arg)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+}
+
+// ListAccountsByIDs mocks base method.
+func (m *MockStore) ListAccountsByIDs(ctx context.Context, ids []int64) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsByIDs indicates an expected call of ListAccountsByIDs.
+func (mr *MockStoreMockRecorder) ListAccountsByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsByIDs", reflect.TypeOf((*MockStore)(nil).ListAccountsByIDs), ctx, ids)
+}
+
+// ListAccountsToPostInterest mocks base method.
+func (m *MockStore) ListAccountsToPostInterest(ctx context.Context, arg db.ListAccountsToPostInterestParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsToPostInterest", ctx, arg)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsToPostInterest indicates an expected call of ListAccountsToPostInterest.
+func (mr *MockStoreMockRecorder) ListAccountsToPostInterest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsToPostInterest", reflect.TypeOf((*MockStore)(nil).ListAccountsToPostInterest), ctx, arg)
+}
+
+// ListBatchTransferItems mocks base method.
+func (m *MockStore) ListBatchTransferItems(ctx context.Context, arg db.ListBatchTransferItemsParams) ([]db.BatchTransferItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBatchTransferItems", ctx, arg)
+	ret0, _ := ret[0].([]db.BatchTransferItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBatchTransferItems indicates an expected call of ListBatchTransferItems.
+func (mr *MockStoreMockRecorder) ListBatchTransferItems(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBatchTransferItems", reflect.TypeOf((*MockStore)(nil).ListBatchTransferItems), ctx, arg)
+}
+
+// ListBeneficiaries mocks base method.
+func (m *MockStore) ListBeneficiaries(ctx context.Context, owner string) ([]db.ListBeneficiariesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBeneficiaries", ctx, owner)
+	ret0, _ := ret[0].([]db.ListBeneficiariesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBeneficiaries indicates an expected call of ListBeneficiaries.
+func (mr *MockStoreMockRecorder) ListBeneficiaries(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBeneficiaries", reflect.TypeOf((*MockStore)(nil).ListBeneficiaries), ctx, owner)
+}
+
+// ListChartOfAccounts mocks base method.
+func (m *MockStore) ListChartOfAccounts(ctx context.Context) ([]db.ChartOfAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChartOfAccounts", ctx)
+	ret0, _ := ret[0].([]db.ChartOfAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChartOfAccounts indicates an expected call of ListChartOfAccounts.
+func (mr *MockStoreMockRecorder) ListChartOfAccounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChartOfAccounts", reflect.TypeOf((*MockStore)(nil).ListChartOfAccounts), ctx)
+}
+
+// ListCurrencies mocks base method.
+func (m *MockStore) ListCurrencies(ctx context.Context) ([]db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencies", ctx)
+	ret0, _ := ret[0].([]db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencies indicates an expected call of ListCurrencies.
+func (mr *MockStoreMockRecorder) ListCurrencies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockStore)(nil).ListCurrencies), ctx)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+}
+
+// ListFeeTiers mocks base method.
+func (m *MockStore) ListFeeTiers(ctx context.Context, scheduleID int64) ([]db.FeeTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeeTiers", ctx, scheduleID)
+	ret0, _ := ret[0].([]db.FeeTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeeTiers indicates an expected call of ListFeeTiers.
+func (mr *MockStoreMockRecorder) ListFeeTiers(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeeTiers", reflect.TypeOf((*MockStore)(nil).ListFeeTiers), ctx, scheduleID)
+}
+
+// ListIncomingPaymentRequests mocks base method.
+func (m *MockStore) ListIncomingPaymentRequests(ctx context.Context, arg db.ListIncomingPaymentRequestsParams) ([]db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIncomingPaymentRequests", ctx, arg)
+	ret0, _ := ret[0].([]db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIncomingPaymentRequests indicates an expected call of ListIncomingPaymentRequests.
+func (mr *MockStoreMockRecorder) ListIncomingPaymentRequests(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncomingPaymentRequests", reflect.TypeOf((*MockStore)(nil).ListIncomingPaymentRequests), ctx, arg)
+}
+
+// ListInterestAccruals mocks base method.
+func (m *MockStore) ListInterestAccruals(ctx context.Context, arg db.ListInterestAccrualsParams) ([]db.InterestAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestAccruals", ctx, arg)
+	ret0, _ := ret[0].([]db.InterestAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestAccruals indicates an expected call of ListInterestAccruals.
+func (mr *MockStoreMockRecorder) ListInterestAccruals(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestAccruals", reflect.TypeOf((*MockStore)(nil).ListInterestAccruals), ctx, arg)
+}
+
+// ListInterestBearingBalances mocks base method.
+func (m *MockStore) ListInterestBearingBalances(ctx context.Context, arg db.ListInterestBearingBalancesParams) ([]db.ListInterestBearingBalancesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestBearingBalances", ctx, arg)
+	ret0, _ := ret[0].([]db.ListInterestBearingBalancesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestBearingBalances indicates an expected call of ListInterestBearingBalances.
+func (mr *MockStoreMockRecorder) ListInterestBearingBalances(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestBearingBalances", reflect.TypeOf((*MockStore)(nil).ListInterestBearingBalances), ctx, arg)
+}
+
+// ListJournalEntries mocks base method.
+func (m *MockStore) ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJournalEntries", ctx, journalID)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJournalEntries indicates an expected call of ListJournalEntries.
+func (mr *MockStoreMockRecorder) ListJournalEntries(ctx, journalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJournalEntries", reflect.TypeOf((*MockStore)(nil).ListJournalEntries), ctx, journalID)
+}
+
+// ListKycDocuments mocks base method.
+func (m *MockStore) ListKycDocuments(ctx context.Context, username string) ([]db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKycDocuments", ctx, username)
+	ret0, _ := ret[0].([]db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKycDocuments indicates an expected call of ListKycDocuments.
+func (mr *MockStoreMockRecorder) ListKycDocuments(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKycDocuments", reflect.TypeOf((*MockStore)(nil).ListKycDocuments), ctx, username)
+}
+
+// ListNotificationPreferences mocks base method.
+func (m *MockStore) ListNotificationPreferences(ctx context.Context, username string) ([]db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationPreferences", ctx, username)
+	ret0, _ := ret[0].([]db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationPreferences indicates an expected call of ListNotificationPreferences.
+func (mr *MockStoreMockRecorder) ListNotificationPreferences(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationPreferences", reflect.TypeOf((*MockStore)(nil).ListNotificationPreferences), ctx, username)
+}
+
+// ListNotifications mocks base method.
+func (m *MockStore) ListNotifications(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, arg)
+	ret0, _ := ret[0].([]db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockStoreMockRecorder) ListNotifications(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockStore)(nil).ListNotifications), ctx, arg)
+}
+
+// ListOutgoingPaymentRequests mocks base method.
+func (m *MockStore) ListOutgoingPaymentRequests(ctx context.Context, arg db.ListOutgoingPaymentRequestsParams) ([]db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutgoingPaymentRequests", ctx, arg)
+	ret0, _ := ret[0].([]db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutgoingPaymentRequests indicates an expected call of ListOutgoingPaymentRequests.
+func (mr *MockStoreMockRecorder) ListOutgoingPaymentRequests(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutgoingPaymentRequests", reflect.TypeOf((*MockStore)(nil).ListOutgoingPaymentRequests), ctx, arg)
+}
+
+// ListPendingBatchTransferItems mocks base method.
+func (m *MockStore) ListPendingBatchTransferItems(ctx context.Context, batchID int64) ([]db.BatchTransferItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingBatchTransferItems", ctx, batchID)
+	ret0, _ := ret[0].([]db.BatchTransferItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingBatchTransferItems indicates an expected call of ListPendingBatchTransferItems.
+func (mr *MockStoreMockRecorder) ListPendingBatchTransferItems(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingBatchTransferItems", reflect.TypeOf((*MockStore)(nil).ListPendingBatchTransferItems), ctx, batchID)
+}
+
+// ListPendingKycDocuments mocks base method.
+func (m *MockStore) ListPendingKycDocuments(ctx context.Context, arg db.ListPendingKycDocumentsParams) ([]db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingKycDocuments", ctx, arg)
+	ret0, _ := ret[0].([]db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingKycDocuments indicates an expected call of ListPendingKycDocuments.
+func (mr *MockStoreMockRecorder) ListPendingKycDocuments(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingKycDocuments", reflect.TypeOf((*MockStore)(nil).ListPendingKycDocuments), ctx, arg)
+}
+
+// ListPendingOutboxEvents mocks base method.
+func (m *MockStore) ListPendingOutboxEvents(ctx context.Context, limit int32) ([]db.Outbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingOutboxEvents", ctx, limit)
+	ret0, _ := ret[0].([]db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingOutboxEvents indicates an expected call of ListPendingOutboxEvents.
+func (mr *MockStoreMockRecorder) ListPendingOutboxEvents(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingOutboxEvents", reflect.TypeOf((*MockStore)(nil).ListPendingOutboxEvents), ctx, limit)
+}
+
+// ListPendingRiskDecisions mocks base method.
+func (m *MockStore) ListPendingRiskDecisions(ctx context.Context, arg db.ListPendingRiskDecisionsParams) ([]db.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingRiskDecisions", ctx, arg)
+	ret0, _ := ret[0].([]db.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingRiskDecisions indicates an expected call of ListPendingRiskDecisions.
+func (mr *MockStoreMockRecorder) ListPendingRiskDecisions(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingRiskDecisions", reflect.TypeOf((*MockStore)(nil).ListPendingRiskDecisions), ctx, arg)
+}
+
+// ListPendingTransferApprovals mocks base method.
+func (m *MockStore) ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingTransferApprovals", ctx, fromAccountID)
+	ret0, _ := ret[0].([]db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingTransferApprovals indicates an expected call of ListPendingTransferApprovals.
+func (mr *MockStoreMockRecorder) ListPendingTransferApprovals(ctx, fromAccountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingTransferApprovals", reflect.TypeOf((*MockStore)(nil).ListPendingTransferApprovals), ctx, fromAccountID)
+}
+
+// ListProducts mocks base method.
+func (m *MockStore) ListProducts(ctx context.Context) ([]db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", ctx)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockStoreMockRecorder) ListProducts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), ctx)
+}
+
+// ListTransferJournals mocks base method.
+func (m *MockStore) ListTransferJournals(ctx context.Context, transferID sql.NullInt64) ([]db.Journal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferJournals", ctx, transferID)
+	ret0, _ := ret[0].([]db.Journal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferJournals indicates an expected call of ListTransferJournals.
+func (mr *MockStoreMockRecorder) ListTransferJournals(ctx, transferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferJournals", reflect.TypeOf((*MockStore)(nil).ListTransferJournals), ctx, transferID)
+}
+
+// ListTransferLimits mocks base method.
+func (m *MockStore) ListTransferLimits(ctx context.Context, profile string) ([]db.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferLimits", ctx, profile)
+	ret0, _ := ret[0].([]db.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferLimits indicates an expected call of ListTransferLimits.
+func (mr *MockStoreMockRecorder) ListTransferLimits(ctx, profile interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferLimits", reflect.TypeOf((*MockStore)(nil).ListTransferLimits), ctx, profile)
 }
 
-// CreateAccount mocks base method.
-func (m *MockStore) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg interface{}) *gomock.Call {
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
 }
 
-// CreateEntry mocks base method.
-func (m *MockStore) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
+// ListUnbalancedAccounts mocks base method.
+func (m *MockStore) ListUnbalancedAccounts(ctx context.Context) ([]db.ListUnbalancedAccountsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEntry", ctx, arg)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "ListUnbalancedAccounts", ctx)
+	ret0, _ := ret[0].([]db.ListUnbalancedAccountsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEntry indicates an expected call of CreateEntry.
-func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg interface{}) *gomock.Call {
+// ListUnbalancedAccounts indicates an expected call of ListUnbalancedAccounts.
+func (mr *MockStoreMockRecorder) ListUnbalancedAccounts(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnbalancedAccounts", reflect.TypeOf((*MockStore)(nil).ListUnbalancedAccounts), ctx)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+// MarkAllNotificationsRead mocks base method.
+func (m *MockStore) MarkAllNotificationsRead(ctx context.Context, username string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", ctx, username)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(ctx, arg interface{}) *gomock.Call {
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockStoreMockRecorder) MarkAllNotificationsRead(ctx, username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MockStore)(nil).MarkAllNotificationsRead), ctx, username)
 }
 
-// CreateTransfer mocks base method.
-func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+// MarkBatchTransferItemFailed mocks base method.
+func (m *MockStore) MarkBatchTransferItemFailed(ctx context.Context, arg db.MarkBatchTransferItemFailedParams) (db.BatchTransferItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "MarkBatchTransferItemFailed", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransferItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTransfer indicates an expected call of CreateTransfer.
-func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg interface{}) *gomock.Call {
+// MarkBatchTransferItemFailed indicates an expected call of MarkBatchTransferItemFailed.
+func (mr *MockStoreMockRecorder) MarkBatchTransferItemFailed(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBatchTransferItemFailed", reflect.TypeOf((*MockStore)(nil).MarkBatchTransferItemFailed), ctx, arg)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+// MarkBatchTransferItemSucceeded mocks base method.
+func (m *MockStore) MarkBatchTransferItemSucceeded(ctx context.Context, arg db.MarkBatchTransferItemSucceededParams) (db.BatchTransferItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "MarkBatchTransferItemSucceeded", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransferItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(ctx, arg interface{}) *gomock.Call {
+// MarkBatchTransferItemSucceeded indicates an expected call of MarkBatchTransferItemSucceeded.
+func (mr *MockStoreMockRecorder) MarkBatchTransferItemSucceeded(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBatchTransferItemSucceeded", reflect.TypeOf((*MockStore)(nil).MarkBatchTransferItemSucceeded), ctx, arg)
 }
 
-// CreateUserTx mocks base method.
-func (m *MockStore) CreateUserTx(ctx context.Context, arg db.CreateUserTxParams) (db.CreateUserTxResult, error) {
+// MarkNotificationsRead mocks base method.
+func (m *MockStore) MarkNotificationsRead(ctx context.Context, arg db.MarkNotificationsReadParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserTx", ctx, arg)
-	ret0, _ := ret[0].(db.CreateUserTxResult)
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserTx indicates an expected call of CreateUserTx.
-func (mr *MockStoreMockRecorder) CreateUserTx(ctx, arg interface{}) *gomock.Call {
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockStoreMockRecorder) MarkNotificationsRead(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationsRead), ctx, arg)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+// MarkOutboxEventFailed mocks base method.
+func (m *MockStore) MarkOutboxEventFailed(ctx context.Context, arg db.MarkOutboxEventFailedParams) (db.Outbox, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MarkOutboxEventFailed", ctx, arg)
+	ret0, _ := ret[0].(db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id interface{}) *gomock.Call {
+// MarkOutboxEventFailed indicates an expected call of MarkOutboxEventFailed.
+func (mr *MockStoreMockRecorder) MarkOutboxEventFailed(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventFailed", reflect.TypeOf((*MockStore)(nil).MarkOutboxEventFailed), ctx, arg)
 }
 
-// DeleteEntry mocks base method.
-func (m *MockStore) DeleteEntry(ctx context.Context, id int64) error {
+// MarkOutboxEventPublished mocks base method.
+func (m *MockStore) MarkOutboxEventPublished(ctx context.Context, id int64) (db.Outbox, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEntry", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MarkOutboxEventPublished", ctx, id)
+	ret0, _ := ret[0].(db.Outbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteEntry indicates an expected call of DeleteEntry.
-func (mr *MockStoreMockRecorder) DeleteEntry(ctx, id interface{}) *gomock.Call {
+// MarkOutboxEventPublished indicates an expected call of MarkOutboxEventPublished.
+func (mr *MockStoreMockRecorder) MarkOutboxEventPublished(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventPublished", reflect.TypeOf((*MockStore)(nil).MarkOutboxEventPublished), ctx, id)
 }
 
-// DeleteTransfer mocks base method.
-func (m *MockStore) DeleteTransfer(ctx context.Context, id int64) error {
+// PayPaymentRequestTx mocks base method.
+func (m *MockStore) PayPaymentRequestTx(ctx context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTransfer", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PayPaymentRequestTx", ctx, arg)
+	ret0, _ := ret[0].(db.PayPaymentRequestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteTransfer indicates an expected call of DeleteTransfer.
-func (mr *MockStoreMockRecorder) DeleteTransfer(ctx, id interface{}) *gomock.Call {
+// PayPaymentRequestTx indicates an expected call of PayPaymentRequestTx.
+func (mr *MockStoreMockRecorder) PayPaymentRequestTx(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockStore)(nil).DeleteTransfer), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayPaymentRequestTx", reflect.TypeOf((*MockStore)(nil).PayPaymentRequestTx), ctx, arg)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+// PostInterestTx mocks base method.
+func (m *MockStore) PostInterestTx(ctx context.Context, arg db.PostInterestTxParams) (db.PostInterestTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "PostInterestTx", ctx, arg)
+	ret0, _ := ret[0].(db.PostInterestTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(ctx, id interface{}) *gomock.Call {
+// PostInterestTx indicates an expected call of PostInterestTx.
+func (mr *MockStoreMockRecorder) PostInterestTx(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInterestTx", reflect.TypeOf((*MockStore)(nil).PostInterestTx), ctx, arg)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+// PostJournal mocks base method.
+func (m *MockStore) PostJournal(ctx context.Context, arg db.PostJournalParams) (db.PostJournalResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "PostJournal", ctx, arg)
+	ret0, _ := ret[0].(db.PostJournalResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id interface{}) *gomock.Call {
+// PostJournal indicates an expected call of PostJournal.
+func (mr *MockStoreMockRecorder) PostJournal(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostJournal", reflect.TypeOf((*MockStore)(nil).PostJournal), ctx, arg)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+// PreviewTransferFee mocks base method.
+func (m *MockStore) PreviewTransferFee(ctx context.Context, fromAccountID, amount int64) (db.Fee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "PreviewTransferFee", ctx, fromAccountID, amount)
+	ret0, _ := ret[0].(db.Fee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(ctx, id interface{}) *gomock.Call {
+// PreviewTransferFee indicates an expected call of PreviewTransferFee.
+func (mr *MockStoreMockRecorder) PreviewTransferFee(ctx, fromAccountID, amount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTransferFee", reflect.TypeOf((*MockStore)(nil).PreviewTransferFee), ctx, fromAccountID, amount)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+// PreviewTransferFees mocks base method.
+func (m *MockStore) PreviewTransferFees(ctx context.Context, fromAccountID int64, amounts []int64) ([]db.Fee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", ctx, id)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "PreviewTransferFees", ctx, fromAccountID, amounts)
+	ret0, _ := ret[0].([]db.Fee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(ctx, id interface{}) *gomock.Call {
+// PreviewTransferFees indicates an expected call of PreviewTransferFees.
+func (mr *MockStoreMockRecorder) PreviewTransferFees(ctx, fromAccountID, amounts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTransferFees", reflect.TypeOf((*MockStore)(nil).PreviewTransferFees), ctx, fromAccountID, amounts)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+// PublishOutboxTx mocks base method.
+func (m *MockStore) PublishOutboxTx(ctx context.Context, arg db.PublishOutboxTxParams) (db.PublishOutboxTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "PublishOutboxTx", ctx, arg)
+	ret0, _ := ret[0].(db.PublishOutboxTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(ctx, id interface{}) *gomock.Call {
+// PublishOutboxTx indicates an expected call of PublishOutboxTx.
+func (mr *MockStoreMockRecorder) PublishOutboxTx(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishOutboxTx", reflect.TypeOf((*MockStore)(nil).PublishOutboxTx), ctx, arg)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(ctx context.Context, username string) (db.User, error) {
+// ReviewKycDocument mocks base method.
+func (m *MockStore) ReviewKycDocument(ctx context.Context, arg db.ReviewKycDocumentParams) (db.KycDocument, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, username)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ReviewKycDocument", ctx, arg)
+	ret0, _ := ret[0].(db.KycDocument)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(ctx, username interface{}) *gomock.Call {
+// ReviewKycDocument indicates an expected call of ReviewKycDocument.
+func (mr *MockStoreMockRecorder) ReviewKycDocument(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewKycDocument", reflect.TypeOf((*MockStore)(nil).ReviewKycDocument), ctx, arg)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+// ReviewKycDocumentTx mocks base method.
+func (m *MockStore) ReviewKycDocumentTx(ctx context.Context, arg db.ReviewKycDocumentTxParams) (db.ReviewKycDocumentTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "ReviewKycDocumentTx", ctx, arg)
+	ret0, _ := ret[0].(db.ReviewKycDocumentTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg interface{}) *gomock.Call {
+// ReviewKycDocumentTx indicates an expected call of ReviewKycDocumentTx.
+func (mr *MockStoreMockRecorder) ReviewKycDocumentTx(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewKycDocumentTx", reflect.TypeOf((*MockStore)(nil).ReviewKycDocumentTx), ctx, arg)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+// ReviewRiskDecision mocks base method.
+func (m *MockStore) ReviewRiskDecision(ctx context.Context, arg db.ReviewRiskDecisionParams) (db.RiskDecision, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
-	ret0, _ := ret[0].([]db.Entry)
+	ret := m.ctrl.Call(m, "ReviewRiskDecision", ctx, arg)
+	ret0, _ := ret[0].(db.RiskDecision)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(ctx, arg interface{}) *gomock.Call {
+// ReviewRiskDecision indicates an expected call of ReviewRiskDecision.
+func (mr *MockStoreMockRecorder) ReviewRiskDecision(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewRiskDecision", reflect.TypeOf((*MockStore)(nil).ReviewRiskDecision), ctx, arg)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.Transfer, error) {
+// ReviewTransferTx mocks base method.
+func (m *MockStore) ReviewTransferTx(ctx context.Context, arg db.ReviewTransferTxParams) (db.ReviewTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "ReviewTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.ReviewTransferTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg interface{}) *gomock.Call {
+// ReviewTransferTx indicates an expected call of ReviewTransferTx.
+func (mr *MockStoreMockRecorder) ReviewTransferTx(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewTransferTx", reflect.TypeOf((*MockStore)(nil).ReviewTransferTx), ctx, arg)
 }
 
-// TransferTx mocks base method.
-func (m *MockStore) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+// ScreenedTransferTx mocks base method.
+func (m *MockStore) ScreenedTransferTx(ctx context.Context, arg db.ScreenedTransferTxParams) (db.ScreenedTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TransferTx", ctx, arg)
-	ret0, _ := ret[0].(db.TransferTxResult)
+	ret := m.ctrl.Call(m, "ScreenedTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.ScreenedTransferTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// TransferTx indicates an expected call of TransferTx.
-func (mr *MockStoreMockRecorder) TransferTx(ctx, arg interface{}) *gomock.Call {
+// ScreenedTransferTx indicates an expected call of ScreenedTransferTx.
+func (mr *MockStoreMockRecorder) ScreenedTransferTx(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScreenedTransferTx", reflect.TypeOf((*MockStore)(nil).ScreenedTransferTx), ctx, arg)
+}
+
+// SearchTransferActivity mocks base method.
+func (m *MockStore) SearchTransferActivity(ctx context.Context, arg db.SearchTransferActivityParams) ([]db.SearchTransferActivityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransferActivity", ctx, arg)
+	ret0, _ := ret[0].([]db.SearchTransferActivityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransferActivity indicates an expected call of SearchTransferActivity.
+func (mr *MockStoreMockRecorder) SearchTransferActivity(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransferActivity", reflect.TypeOf((*MockStore)(nil).SearchTransferActivity), ctx, arg)
 }
 
-// UpdateAccount mocks base method.
-func (m *MockStore) UpdateAccount(ctx context.Context, arg db.UpdateAccountParams) (db.Account, error) {
+// SetAccountApprovalThreshold mocks base method.
+func (m *MockStore) SetAccountApprovalThreshold(ctx context.Context, arg db.SetAccountApprovalThresholdParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAccount", ctx, arg)
+	ret := m.ctrl.Call(m, "SetAccountApprovalThreshold", ctx, arg)
 	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockStoreMockRecorder) UpdateAccount(ctx, arg interface{}) *gomock.Call {
+// SetAccountApprovalThreshold indicates an expected call of SetAccountApprovalThreshold.
+func (mr *MockStoreMockRecorder) SetAccountApprovalThreshold(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountApprovalThreshold", reflect.TypeOf((*MockStore)(nil).SetAccountApprovalThreshold), ctx, arg)
 }
 
-// UpdateEntry mocks base method.
-func (m *MockStore) UpdateEntry(ctx context.Context, arg db.UpdateEntryParams) (db.Entry, error) {
+// SetInterestPostingEntries mocks base method.
+func (m *MockStore) SetInterestPostingEntries(ctx context.Context, arg db.SetInterestPostingEntriesParams) (db.InterestPosting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEntry", ctx, arg)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "SetInterestPostingEntries", ctx, arg)
+	ret0, _ := ret[0].(db.InterestPosting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateEntry indicates an expected call of UpdateEntry.
-func (mr *MockStoreMockRecorder) UpdateEntry(ctx, arg interface{}) *gomock.Call {
+// SetInterestPostingEntries indicates an expected call of SetInterestPostingEntries.
+func (mr *MockStoreMockRecorder) SetInterestPostingEntries(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntry", reflect.TypeOf((*MockStore)(nil).UpdateEntry), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInterestPostingEntries", reflect.TypeOf((*MockStore)(nil).SetInterestPostingEntries), ctx, arg)
 }
 
-// UpdateTransfer mocks base method.
-func (m *MockStore) UpdateTransfer(ctx context.Context, arg db.UpdateTransferParams) (db.Transfer, error) {
+// SetRiskDecisionTransfer mocks base method.
+func (m *MockStore) SetRiskDecisionTransfer(ctx context.Context, arg db.SetRiskDecisionTransferParams) (db.RiskDecision, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTransfer", ctx, arg)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "SetRiskDecisionTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRiskDecisionTransfer indicates an expected call of SetRiskDecisionTransfer.
+func (mr *MockStoreMockRecorder) SetRiskDecisionTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRiskDecisionTransfer", reflect.TypeOf((*MockStore)(nil).SetRiskDecisionTransfer), ctx, arg)
+}
+
+// SettlePaymentRequest mocks base method.
+func (m *MockStore) SettlePaymentRequest(ctx context.Context, arg db.SettlePaymentRequestParams) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettlePaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettlePaymentRequest indicates an expected call of SettlePaymentRequest.
+func (mr *MockStoreMockRecorder) SettlePaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettlePaymentRequest", reflect.TypeOf((*MockStore)(nil).SettlePaymentRequest), ctx, arg)
+}
+
+// SkipPendingBatchTransferItems mocks base method.
+func (m *MockStore) SkipPendingBatchTransferItems(ctx context.Context, arg db.SkipPendingBatchTransferItemsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipPendingBatchTransferItems", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SkipPendingBatchTransferItems indicates an expected call of SkipPendingBatchTransferItems.
+func (mr *MockStoreMockRecorder) SkipPendingBatchTransferItems(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipPendingBatchTransferItems", reflect.TypeOf((*MockStore)(nil).SkipPendingBatchTransferItems), ctx, arg)
+}
+
+// SubmitKycDocumentTx mocks base method.
+func (m *MockStore) SubmitKycDocumentTx(ctx context.Context, arg db.CreateKycDocumentParams) (db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitKycDocumentTx", ctx, arg)
+	ret0, _ := ret[0].(db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitKycDocumentTx indicates an expected call of SubmitKycDocumentTx.
+func (mr *MockStoreMockRecorder) SubmitKycDocumentTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitKycDocumentTx", reflect.TypeOf((*MockStore)(nil).SubmitKycDocumentTx), ctx, arg)
+}
+
+// SumInterestAccruals mocks base method.
+func (m *MockStore) SumInterestAccruals(ctx context.Context, arg db.SumInterestAccrualsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumInterestAccruals", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumInterestAccruals indicates an expected call of SumInterestAccruals.
+func (mr *MockStoreMockRecorder) SumInterestAccruals(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumInterestAccruals", reflect.TypeOf((*MockStore)(nil).SumInterestAccruals), ctx, arg)
+}
+
+// TransferTx mocks base method.
+func (m *MockStore) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferTx indicates an expected call of TransferTx.
+func (mr *MockStoreMockRecorder) TransferTx(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), ctx, arg)
+}
+
+// UpdateBatchTransferStatus mocks base method.
+func (m *MockStore) UpdateBatchTransferStatus(ctx context.Context, arg db.UpdateBatchTransferStatusParams) (db.BatchTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBatchTransferStatus", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateTransfer indicates an expected call of UpdateTransfer.
-func (mr *MockStoreMockRecorder) UpdateTransfer(ctx, arg interface{}) *gomock.Call {
+// UpdateBatchTransferStatus indicates an expected call of UpdateBatchTransferStatus.
+func (mr *MockStoreMockRecorder) UpdateBatchTransferStatus(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransfer", reflect.TypeOf((*MockStore)(nil).UpdateTransfer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatchTransferStatus", reflect.TypeOf((*MockStore)(nil).UpdateBatchTransferStatus), ctx, arg)
 }
 
 // UpdateUser mocks base method.
@@ -392,3 +2075,108 @@ func (mr *MockStoreMockRecorder) UpdateUser(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), ctx, arg)
 }
+
+// UpdateUserKyc mocks base method.
+func (m *MockStore) UpdateUserKyc(ctx context.Context, arg db.UpdateUserKycParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserKyc", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserKyc indicates an expected call of UpdateUserKyc.
+func (mr *MockStoreMockRecorder) UpdateUserKyc(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserKyc", reflect.TypeOf((*MockStore)(nil).UpdateUserKyc), ctx, arg)
+}
+
+// UpdateUserLimitProfile mocks base method.
+func (m *MockStore) UpdateUserLimitProfile(ctx context.Context, arg db.UpdateUserLimitProfileParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserLimitProfile", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserLimitProfile indicates an expected call of UpdateUserLimitProfile.
+func (mr *MockStoreMockRecorder) UpdateUserLimitProfile(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserLimitProfile", reflect.TypeOf((*MockStore)(nil).UpdateUserLimitProfile), ctx, arg)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(ctx context.Context, arg db.UpdateUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), ctx, arg)
+}
+
+// UpsertCurrency mocks base method.
+func (m *MockStore) UpsertCurrency(ctx context.Context, arg db.UpsertCurrencyParams) (db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCurrency", ctx, arg)
+	ret0, _ := ret[0].(db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCurrency indicates an expected call of UpsertCurrency.
+func (mr *MockStoreMockRecorder) UpsertCurrency(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCurrency", reflect.TypeOf((*MockStore)(nil).UpsertCurrency), ctx, arg)
+}
+
+// UpsertNotificationPreference mocks base method.
+func (m *MockStore) UpsertNotificationPreference(ctx context.Context, arg db.UpsertNotificationPreferenceParams) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreference", ctx, arg)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNotificationPreference indicates an expected call of UpsertNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertNotificationPreference(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertNotificationPreference), ctx, arg)
+}
+
+// UpsertTransferLimit mocks base method.
+func (m *MockStore) UpsertTransferLimit(ctx context.Context, arg db.UpsertTransferLimitParams) (db.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTransferLimit", ctx, arg)
+	ret0, _ := ret[0].(db.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTransferLimit indicates an expected call of UpsertTransferLimit.
+func (mr *MockStoreMockRecorder) UpsertTransferLimit(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTransferLimit", reflect.TypeOf((*MockStore)(nil).UpsertTransferLimit), ctx, arg)
+}
+
+// UpsertUserAlias mocks base method.
+func (m *MockStore) UpsertUserAlias(ctx context.Context, arg db.UpsertUserAliasParams) (db.UserAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserAlias", ctx, arg)
+	ret0, _ := ret[0].(db.UserAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserAlias indicates an expected call of UpsertUserAlias.
+func (mr *MockStoreMockRecorder) UpsertUserAlias(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserAlias", reflect.TypeOf((*MockStore)(nil).UpsertUserAlias), ctx, arg)
+}
@@ -1,5 +1,7 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (owner, balance, currency) VALUES ($1, $2, $3) RETURNING *;
+-- accounts open empty; money only arrives through entries
+INSERT INTO accounts (owner, balance, currency, product)
+VALUES ($1, 0, $2, COALESCE(NULLIF(sqlc.arg(product)::varchar, ''), 'checking')) RETURNING *;
 
 -- name: GetAccount :one
 SELECT * FROM accounts WHERE id = $1 LIMIT  1;
@@ -8,13 +10,24 @@ SELECT * FROM accounts WHERE id = $1 LIMIT  1;
 SELECT * FROM accounts WHERE id = $1 LIMIT  1 FOR NO KEY UPDATE;
 
 -- name: ListAccounts :many
-SELECT * FROM accounts WHERE owner = $1 ORDER BY id LIMIT $2 offset $3;
+SELECT a.* FROM accounts a
+JOIN account_members m ON m.account_id = a.id
+WHERE m.username = sqlc.arg(username) AND m.status = 'active' AND a.kind = 'customer'
+ORDER BY a.id LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
--- name: UpdateAccount :one
-UPDATE accounts SET balance = $2 WHERE id = $1 RETURNING *;
-
--- name: AddAccountBalance :one
-UPDATE accounts SET balance = balance + sqlc.arg(amount) WHERE id = sqlc.arg(id) RETURNING *;
+-- name: CountAccountsByOwner :one
+SELECT count(*) FROM accounts WHERE owner = $1;
 
 -- name: DeleteAccount :exec
 DELETE FROM accounts WHERE id  = $1;
+
+-- name: ListUnbalancedAccounts :many
+SELECT a.id, a.owner, a.currency, a.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListAccountsByIDs :many
+SELECT * FROM accounts WHERE id = ANY(sqlc.arg(ids)::bigint[]) ORDER BY id;
@@ -0,0 +1,63 @@
+-- name: GetAccountMember :one
+SELECT * FROM account_members
+WHERE account_id = $1 AND username = $2 LIMIT 1;
+
+-- name: ListAccountMembers :many
+SELECT * FROM account_members
+WHERE account_id = $1
+ORDER BY created_at, username;
+
+-- name: InviteAccountMember :one
+INSERT INTO account_members (
+  account_id,
+  username,
+  role,
+  invited_by
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (account_id, username) DO UPDATE SET
+  role = EXCLUDED.role,
+  invited_by = EXCLUDED.invited_by
+WHERE account_members.status = 'invited'
+RETURNING *;
+
+-- name: AcceptAccountInvitation :one
+UPDATE account_members
+SET status = 'active', accepted_at = now()
+WHERE account_id = $1 AND username = $2 AND status = 'invited'
+RETURNING *;
+
+-- name: DeleteAccountMember :one
+DELETE FROM account_members
+WHERE account_id = $1 AND username = $2
+RETURNING *;
+
+-- name: CountAccountSigners :one
+SELECT count(*) FROM account_members
+WHERE account_id = $1 AND status = 'active' AND role IN ('owner', 'signer');
+
+-- name: SetAccountApprovalThreshold :one
+UPDATE accounts SET approval_threshold = $2 WHERE id = $1 RETURNING *;
+
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  requested_by,
+  risk_decision_id
+) VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM transfer_approvals WHERE id = $1 LIMIT 1 FOR UPDATE;
+
+-- name: DecideTransferApproval :one
+UPDATE transfer_approvals
+SET status = $2, decided_by = $3, decided_at = now(), transfer_id = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ListPendingTransferApprovals :many
+SELECT * FROM transfer_approvals
+WHERE from_account_id = $1 AND status = 'pending'
+ORDER BY id;
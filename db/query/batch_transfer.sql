@@ -0,0 +1,67 @@
+-- name: CreateBatchTransfer :one
+INSERT INTO batch_transfers (
+  owner,
+  from_account_id,
+  mode,
+  total_count,
+  total_amount,
+  idempotency_key
+) VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: GetBatchTransfer :one
+SELECT * FROM batch_transfers WHERE id = $1 LIMIT 1;
+
+-- name: GetBatchTransferByIdempotencyKey :one
+SELECT * FROM batch_transfers WHERE owner = $1 AND idempotency_key = $2 LIMIT 1;
+
+-- name: UpdateBatchTransferStatus :one
+UPDATE batch_transfers SET status = $2 WHERE id = $1 RETURNING *;
+
+-- name: CompleteBatchTransfer :one
+UPDATE batch_transfers
+SET status = $2,
+    succeeded_count = $3,
+    failed_count = $4,
+    completed_at = now()
+WHERE id = $1 RETURNING *;
+
+-- name: CreateBatchTransferItem :one
+INSERT INTO batch_transfer_items (
+  batch_id,
+  row_number,
+  to_account_id,
+  amount,
+  reference,
+  risk_decision_id
+) VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: GetBatchTransferItemForUpdate :one
+SELECT * FROM batch_transfer_items WHERE id = $1 LIMIT 1 FOR UPDATE;
+
+-- name: ListBatchTransferItems :many
+SELECT * FROM batch_transfer_items WHERE batch_id = $1 ORDER BY row_number LIMIT $2 OFFSET $3;
+
+-- name: ListPendingBatchTransferItems :many
+SELECT * FROM batch_transfer_items WHERE batch_id = $1 AND status = 'pending' ORDER BY row_number;
+
+-- name: CountBatchTransferItems :one
+SELECT
+  COUNT(*) FILTER (WHERE status = 'succeeded')::int AS succeeded,
+  COUNT(*) FILTER (WHERE status IN ('failed', 'skipped'))::int AS failed,
+  COUNT(*) FILTER (WHERE status = 'pending')::int AS pending
+FROM batch_transfer_items WHERE batch_id = $1;
+
+-- name: MarkBatchTransferItemSucceeded :one
+UPDATE batch_transfer_items
+SET status = 'succeeded', transfer_id = $2, error = '', processed_at = now()
+WHERE id = $1 RETURNING *;
+
+-- name: MarkBatchTransferItemFailed :one
+UPDATE batch_transfer_items
+SET status = 'failed', error = $2, processed_at = now()
+WHERE id = $1 RETURNING *;
+
+-- name: SkipPendingBatchTransferItems :exec
+UPDATE batch_transfer_items
+SET status = 'skipped', error = $2, processed_at = now()
+WHERE batch_id = $1 AND status = 'pending';
@@ -0,0 +1,56 @@
+-- name: UpsertUserAlias :one
+INSERT INTO user_aliases (
+  kind,
+  value,
+  username,
+  verified_at
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (kind, value) DO UPDATE SET
+  username = EXCLUDED.username,
+  verified_at = EXCLUDED.verified_at
+RETURNING *;
+
+-- name: GetVerifiedAliasUsername :one
+SELECT username FROM user_aliases
+WHERE kind = $1 AND value = $2 AND verified_at IS NOT NULL
+LIMIT 1;
+
+-- name: GetRecipientAccount :one
+-- checking accounts are preferred when the user has several in the currency
+SELECT a.id, a.owner, a.currency, u.full_name
+FROM accounts a
+JOIN users u ON u.username = a.owner
+WHERE a.owner = $1 AND a.currency = $2 AND a.kind = 'customer'
+ORDER BY (a.product = 'checking') DESC, a.id
+LIMIT 1;
+
+-- name: CreateBeneficiary :one
+-- general ledger accounts cannot be saved, no row is returned for them
+INSERT INTO beneficiaries (
+  owner,
+  nickname,
+  account_id
+)
+SELECT sqlc.arg(owner), sqlc.arg(nickname), a.id FROM accounts a
+WHERE a.id = sqlc.arg(account_id) AND a.kind = 'customer'
+RETURNING *;
+
+-- name: GetBeneficiary :one
+SELECT b.*, a.owner AS account_owner, a.currency, u.full_name
+FROM beneficiaries b
+JOIN accounts a ON a.id = b.account_id
+JOIN users u ON u.username = a.owner
+WHERE b.id = $1 AND b.owner = $2
+LIMIT 1;
+
+-- name: ListBeneficiaries :many
+SELECT b.*, a.owner AS account_owner, a.currency, u.full_name
+FROM beneficiaries b
+JOIN accounts a ON a.id = b.account_id
+JOIN users u ON u.username = a.owner
+WHERE b.owner = $1
+ORDER BY b.nickname;
+
+-- name: DeleteBeneficiary :execrows
+DELETE FROM beneficiaries
+WHERE id = $1 AND owner = $2;
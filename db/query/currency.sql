@@ -0,0 +1,22 @@
+-- name: GetCurrency :one
+SELECT * FROM currencies WHERE code = $1 LIMIT 1;
+
+-- name: ListCurrencies :many
+SELECT * FROM currencies ORDER BY code;
+
+-- name: UpsertCurrency :one
+INSERT INTO currencies (
+  code,
+  name,
+  minor_units,
+  symbol,
+  enabled,
+  risk_large_amount
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (code) DO UPDATE SET
+  name = EXCLUDED.name,
+  minor_units = EXCLUDED.minor_units,
+  symbol = EXCLUDED.symbol,
+  enabled = EXCLUDED.enabled,
+  risk_large_amount = EXCLUDED.risk_large_amount
+RETURNING *;
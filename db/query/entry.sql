@@ -1,14 +1,62 @@
--- name: CreateEntry :one
-INSERT INTO entries (account_id, amount) VALUES ($1, $2) RETURNING *;
-
 -- name: GetEntry :one
 SELECT * FROM entries WHERE id = $1 LIMIT  1;
 
 -- name: ListEntries :many
 SELECT * FROM entries ORDER BY id LIMIT $1 offset $2;
 
--- name: UpdateEntry :one
-UPDATE entries SET amount = $2 WHERE id = $1 RETURNING *;
+-- name: ListAccountEntriesAfter :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id) AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit_count);
+
+-- name: ListAccountActivityAfter :many
+-- entries of an account after after_id with the balance of the account right
+-- after each of them. Entries of an account commit in id order, because the
+-- account row is locked before they are written, so the running sum is exact.
+SELECT e.*,
+  ((SELECT COALESCE(SUM(b.amount), 0) FROM entries b
+    WHERE b.account_id = sqlc.arg(account_id) AND b.id <= sqlc.arg(after_id))
+   + SUM(e.amount) OVER (ORDER BY e.id))::bigint AS balance
+FROM entries e
+WHERE e.account_id = sqlc.arg(account_id) AND e.id > sqlc.arg(after_id)
+ORDER BY e.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetLastAccountEntryID :one
+SELECT COALESCE(MAX(id), 0)::bigint AS last_entry_id FROM entries WHERE account_id = $1;
+
+-- name: GetCorrectedEntryAmount :one
+-- the amount of an entry with all its corrections applied
+SELECT (e.amount + COALESCE(SUM(c.amount), 0))::bigint AS amount
+FROM entries e
+LEFT JOIN entries c ON c.corrects_entry_id = e.id
+WHERE e.id = $1
+GROUP BY e.id;
+
+-- name: ListAccountStatement :many
+-- entries of an account in [from_time, to_time), newest first. The
+-- counterparty is the other account of a transfer entry.
+SELECT
+  e.id,
+  e.account_id,
+  e.amount,
+  e.entry_type,
+  e.transfer_id,
+  e.corrects_entry_id,
+  e.created_at,
+  c.id AS counterparty_account_id,
+  c.owner AS counterparty_owner,
+  u.full_name AS counterparty_name
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id AND e.entry_type = 'transfer'
+LEFT JOIN accounts c ON c.id = CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END
+LEFT JOIN users u ON u.username = c.owner
+WHERE e.account_id = sqlc.arg(account_id)
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+ORDER BY e.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
--- name: DeleteEntry :exec
-DELETE FROM entries WHERE id  = $1;
+-- name: ListJournalEntries :many
+SELECT * FROM entries WHERE journal_id = $1 ORDER BY id;
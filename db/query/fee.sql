@@ -0,0 +1,9 @@
+-- name: GetAccountFeeSchedule :one
+SELECT s.* FROM accounts a
+JOIN fee_schedules s ON s.product = a.product AND s.currency = a.currency
+WHERE a.id = $1;
+
+-- name: ListFeeTiers :many
+SELECT * FROM fee_tiers
+WHERE schedule_id = $1
+ORDER BY from_amount;
@@ -0,0 +1,19 @@
+-- name: ListChartOfAccounts :many
+SELECT * FROM chart_of_accounts ORDER BY number;
+
+-- name: GetTrialBalance :many
+-- the balance of every account of the chart from the entries posted before
+-- as_of; the customer accounts are summed into customer_deposits
+WITH balances AS (
+  SELECT CASE WHEN a.kind = 'customer' THEN 'customer_deposits' ELSE i.name END AS code,
+    SUM(e.amount) AS balance
+  FROM entries e
+  JOIN accounts a ON a.id = e.account_id
+  LEFT JOIN internal_accounts i ON i.account_id = a.id
+  WHERE a.currency = sqlc.arg(currency) AND e.created_at < sqlc.arg(as_of)
+  GROUP BY 1
+)
+SELECT c.code, c.number, c.name, c.category, COALESCE(b.balance, 0)::bigint AS balance
+FROM chart_of_accounts c
+LEFT JOIN balances b ON b.code = c.code
+ORDER BY c.number;
@@ -0,0 +1,93 @@
+-- name: GetProduct :one
+SELECT * FROM products WHERE code = $1 LIMIT 1;
+
+-- name: ListProducts :many
+SELECT * FROM products ORDER BY code;
+
+-- name: GetInternalAccount :one
+SELECT a.* FROM internal_accounts i
+JOIN accounts a ON a.id = i.account_id
+WHERE i.name = $1 AND i.currency = $2;
+
+-- name: CreateInternalAccount :one
+WITH created AS (
+  INSERT INTO accounts (owner, balance, currency, kind)
+  VALUES ('simplebank', 0, sqlc.arg(currency), 'gl')
+  RETURNING id
+)
+INSERT INTO internal_accounts (name, currency, account_id)
+SELECT sqlc.arg(name), sqlc.arg(currency), id FROM created
+RETURNING *;
+
+-- name: ListInterestBearingBalances :many
+SELECT a.id AS account_id, a.currency, p.annual_rate_bps, p.day_count,
+  (SELECT COALESCE(SUM(e.amount), 0) FROM entries e
+   WHERE e.account_id = a.id AND e.created_at < sqlc.arg(day_end))::bigint AS balance
+FROM accounts a
+JOIN products p ON p.code = a.product
+WHERE p.annual_rate_bps > 0 AND a.created_at < sqlc.arg(day_end) AND a.id > sqlc.arg(after_id)
+ORDER BY a.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: CreateInterestAccrual :execrows
+INSERT INTO interest_accruals (
+  account_id,
+  day,
+  balance,
+  annual_rate_bps,
+  day_count,
+  amount_micros
+)
+SELECT $1, $2, $3, $4, $5, $6
+WHERE NOT EXISTS (
+  SELECT 1 FROM interest_postings
+  WHERE account_id = $1 AND month = date_trunc('month', $2::date)::date
+)
+ON CONFLICT (account_id, day) DO NOTHING;
+
+-- name: GetLastInterestAccrualDay :one
+SELECT day FROM interest_accruals ORDER BY day DESC LIMIT 1;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1 AND day >= sqlc.arg(from_day) AND day <= sqlc.arg(to_day)
+ORDER BY day;
+
+-- name: SumInterestAccruals :one
+SELECT COALESCE(SUM(amount_micros), 0)::bigint AS amount_micros
+FROM interest_accruals
+WHERE account_id = $1 AND day >= sqlc.arg(from_day) AND day <= sqlc.arg(to_day);
+
+-- name: ListAccountsToPostInterest :many
+SELECT DISTINCT r.account_id FROM interest_accruals r
+WHERE r.day >= sqlc.arg(from_day) AND r.day <= sqlc.arg(to_day)
+AND NOT EXISTS (
+  SELECT 1 FROM interest_postings p WHERE p.account_id = r.account_id AND p.month = sqlc.arg(from_day)
+)
+ORDER BY r.account_id;
+
+-- name: GetLastInterestCarry :one
+SELECT COALESCE((
+  SELECT carried_out_micros FROM interest_postings
+  WHERE account_id = $1 AND month < $2
+  ORDER BY month DESC
+  LIMIT 1
+), 0)::bigint AS carried_out_micros;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+  account_id,
+  month,
+  accrued_micros,
+  carried_in_micros,
+  amount,
+  carried_out_micros
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (account_id, month) DO NOTHING
+RETURNING *;
+
+-- name: GetInterestPosting :one
+SELECT * FROM interest_postings WHERE account_id = $1 AND month = $2 LIMIT 1;
+
+-- name: SetInterestPostingEntries :one
+UPDATE interest_postings SET from_entry_id = $2, to_entry_id = $3 WHERE id = $1 RETURNING *;
@@ -0,0 +1,5 @@
+-- name: GetJournal :one
+SELECT * FROM journals WHERE id = $1 LIMIT 1;
+
+-- name: ListTransferJournals :many
+SELECT * FROM journals WHERE transfer_id = $1 ORDER BY id;
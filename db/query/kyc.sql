@@ -0,0 +1,49 @@
+-- name: CreateKycDocument :one
+INSERT INTO kyc_documents (
+  username,
+  kind,
+  file_name,
+  content_type,
+  size_bytes,
+  sha256,
+  storage_key
+) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: GetKycDocumentForUpdate :one
+SELECT * FROM kyc_documents WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
+
+-- name: ListKycDocuments :many
+SELECT * FROM kyc_documents WHERE username = $1 ORDER BY id DESC;
+
+-- name: ListPendingKycDocuments :many
+SELECT * FROM kyc_documents
+WHERE status = 'pending'
+ORDER BY id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ReviewKycDocument :one
+UPDATE kyc_documents
+SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetUserKyc :one
+-- the tier only counts once the email the user signed up with is verified
+SELECT u.username, u.kyc_status, u.kyc_tier, EXISTS (
+  SELECT 1 FROM user_aliases ua
+  WHERE ua.kind = 'email' AND ua.value = lower(u.email) AND ua.username = u.username AND ua.verified_at IS NOT NULL
+) AS email_verified
+FROM users u
+WHERE u.username = $1;
+
+-- name: GetUserKycForUpdate :one
+SELECT u.username, u.kyc_status, u.kyc_tier, EXISTS (
+  SELECT 1 FROM user_aliases ua
+  WHERE ua.kind = 'email' AND ua.value = lower(u.email) AND ua.username = u.username AND ua.verified_at IS NOT NULL
+) AS email_verified
+FROM users u
+WHERE u.username = $1
+FOR NO KEY UPDATE OF u;
+
+-- name: UpdateUserKyc :one
+UPDATE users SET kyc_status = $2, kyc_tier = $3 WHERE username = $1 RETURNING *;
@@ -0,0 +1,49 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+  username,
+  event_type,
+  title,
+  body,
+  data,
+  in_app,
+  dedup_key
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (dedup_key) DO NOTHING
+RETURNING *;
+
+-- name: ListNotifications :many
+SELECT * FROM notifications
+WHERE username = sqlc.arg(username) AND in_app
+  AND (NOT sqlc.arg(unread_only)::boolean OR read_at IS NULL)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE username = $1 AND in_app AND read_at IS NULL;
+
+-- name: MarkNotificationsRead :execrows
+UPDATE notifications SET read_at = now()
+WHERE username = sqlc.arg(username) AND in_app AND read_at IS NULL
+  AND id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications SET read_at = now()
+WHERE username = $1 AND in_app AND read_at IS NULL;
+
+-- name: ListNotificationPreferences :many
+SELECT * FROM notification_preferences
+WHERE username = $1
+ORDER BY event_type, channel;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (
+  username,
+  event_type,
+  channel,
+  enabled
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (username, event_type, channel) DO UPDATE SET
+  enabled = EXCLUDED.enabled,
+  updated_at = now()
+RETURNING *;
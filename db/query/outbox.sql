@@ -0,0 +1,30 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox (
+  event_type,
+  dedup_key,
+  payload,
+  trace_context
+) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetOutboxEvent :one
+SELECT * FROM outbox WHERE id = $1 LIMIT 1;
+
+-- name: GetOutboxEventByDedupKey :one
+SELECT * FROM outbox WHERE dedup_key = $1 LIMIT 1;
+
+-- name: ListPendingOutboxEvents :many
+SELECT * FROM outbox
+WHERE published_at IS NULL AND available_at <= now()
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventPublished :one
+UPDATE outbox SET published_at = now() WHERE id = $1 RETURNING *;
+
+-- name: MarkOutboxEventFailed :one
+UPDATE outbox
+    SET attempts = attempts + 1,
+        last_error = sqlc.arg(last_error),
+        available_at = now() + make_interval(secs => LEAST(power(2, attempts), 300))
+    WHERE id = sqlc.arg(id) RETURNING *;
@@ -0,0 +1,57 @@
+-- name: CreateRiskDecision :one
+INSERT INTO risk_decisions (
+  username,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  user_agent,
+  client_ip,
+  decision,
+  hits,
+  review_status,
+  memo,
+  reference,
+  metadata
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING *;
+
+-- name: GetRiskDecision :one
+SELECT * FROM risk_decisions WHERE id = $1 LIMIT 1;
+
+-- name: GetRiskDecisionForUpdate :one
+SELECT * FROM risk_decisions WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
+
+-- name: SetRiskDecisionTransfer :one
+UPDATE risk_decisions SET transfer_id = $2 WHERE id = $1 RETURNING *;
+
+-- name: ReviewRiskDecision :one
+UPDATE risk_decisions
+SET review_status = $2, reviewed_by = $3, review_note = $4, transfer_id = $5, reviewed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListPendingRiskDecisions :many
+SELECT * FROM risk_decisions
+WHERE review_status = 'pending'
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: GetTransferRiskSignals :one
+SELECT
+  (SELECT COUNT(*) FROM transfers t JOIN accounts a ON a.id = t.from_account_id
+   WHERE a.owner = sqlc.arg(username) AND t.created_at > sqlc.arg(velocity_since))::bigint AS recent_transfers,
+  (SELECT COUNT(*) FROM transfers t JOIN accounts a ON a.id = t.from_account_id
+   WHERE a.owner = sqlc.arg(username) AND t.to_account_id = sqlc.arg(to_account_id))::bigint AS payee_transfers,
+  (SELECT COUNT(*) FROM sessions s
+   WHERE s.username = sqlc.arg(username) AND s.created_at < sqlc.arg(known_before))::bigint AS previous_sessions,
+  (SELECT COUNT(*) FROM sessions s
+   WHERE s.username = sqlc.arg(username) AND s.created_at < sqlc.arg(known_before)
+   AND s.user_agent = sqlc.arg(user_agent)::varchar)::bigint AS device_sessions,
+  (SELECT COUNT(*) FROM sessions s
+   WHERE s.username = sqlc.arg(username) AND s.created_at < sqlc.arg(known_before)
+   AND (s.client_ip = sqlc.arg(client_ip)::varchar
+     OR s.client_ip LIKE sqlc.arg(client_ip)::varchar || ':%'
+     OR s.client_ip LIKE '[' || sqlc.arg(client_ip)::varchar || ']:%'))::bigint AS ip_sessions,
+  COALESCE((SELECT c.risk_large_amount FROM currencies c
+   WHERE c.code = sqlc.arg(currency)::varchar), 0)::bigint AS large_amount;
@@ -10,4 +10,14 @@ INSERT INTO sessions (
 ) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 
 -- name: GetSession :one
-SELECT * FROM sessions WHERE id = $1 LIMIT  1;
\ No newline at end of file
+SELECT * FROM sessions WHERE id = $1 LIMIT  1;
+
+-- name: BlockAllSessions :execrows
+UPDATE sessions SET is_blocked = true WHERE is_blocked = false;
+
+-- name: CountUserDeviceSessions :one
+SELECT
+  COUNT(*)::bigint AS sessions,
+  (COUNT(*) FILTER (WHERE user_agent = sqlc.arg(user_agent)))::bigint AS device_sessions
+FROM sessions
+WHERE username = sqlc.arg(username);
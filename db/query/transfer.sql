@@ -1,5 +1,6 @@
 -- name: CreateTransfer :one
-INSERT INTO transfers (from_account_id, to_account_id, amount) VALUES ($1, $2, $3) RETURNING *;
+INSERT INTO transfers (from_account_id, to_account_id, amount, fee, memo, reference, metadata)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers WHERE id = $1 LIMIT  1;
@@ -7,8 +8,55 @@ SELECT * FROM transfers WHERE id = $1 LIMIT  1;
 -- name: ListTransfers :many
 SELECT * FROM transfers ORDER BY id LIMIT $1 offset $2;
 
--- name: UpdateTransfer :one
-UPDATE transfers SET amount = $2 WHERE id = $1 RETURNING *;
-
--- name: DeleteTransfer :exec
-DELETE FROM transfers WHERE id  = $1;
+-- name: SearchTransferActivity :many
+-- transfers touching the accounts the user is an active member of, or only
+-- account_id when set. Held transfers are only shown to the sending side.
+-- The cursor is the sort key of the last row of the previous page.
+WITH scope AS (
+  SELECT a.id FROM accounts a
+  WHERE a.owner = sqlc.arg(username)::varchar AND a.kind = 'customer'
+    AND (sqlc.narg(account_id)::bigint IS NULL OR a.id = sqlc.narg(account_id)::bigint)
+  UNION
+  SELECT m.account_id FROM account_members m
+  WHERE m.username = sqlc.arg(username)::varchar AND m.status = 'active'
+    AND (sqlc.narg(account_id)::bigint IS NULL OR m.account_id = sqlc.narg(account_id)::bigint)
+), activity AS (
+  SELECT
+    ta.*,
+    (CASE
+      WHEN ta.from_account_id IN (SELECT id FROM scope) AND ta.to_account_id IN (SELECT id FROM scope) THEN 'internal'
+      WHEN ta.from_account_id IN (SELECT id FROM scope) THEN 'outgoing'
+      ELSE 'incoming'
+    END)::varchar AS direction
+  FROM transfer_activity ta
+  WHERE ta.from_account_id IN (SELECT id FROM scope)
+    OR (ta.kind = 'transfer' AND ta.to_account_id IN (SELECT id FROM scope))
+)
+SELECT * FROM activity
+WHERE (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time)::timestamptz)
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time)::timestamptz)
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount)::bigint)
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount)::bigint)
+  AND (sqlc.narg(counterparty_account_id)::bigint IS NULL
+    OR sqlc.narg(counterparty_account_id)::bigint IN (from_account_id, to_account_id))
+  AND (sqlc.narg(direction)::varchar IS NULL OR direction = sqlc.narg(direction)::varchar)
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+  AND (sqlc.narg(reference)::varchar IS NULL OR reference = sqlc.narg(reference)::varchar)
+  AND (sqlc.narg(query)::varchar IS NULL
+    OR to_tsvector('simple', memo) @@ plainto_tsquery('simple', sqlc.narg(query)::varchar))
+  AND (sqlc.narg(cursor_id)::bigint IS NULL OR CASE sqlc.arg(sort)::varchar
+    WHEN 'created_at_asc' THEN (created_at, kind, id) > (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_kind)::varchar, sqlc.narg(cursor_id)::bigint)
+    WHEN 'amount_desc' THEN (amount, kind, id) < (sqlc.narg(cursor_amount)::bigint, sqlc.narg(cursor_kind)::varchar, sqlc.narg(cursor_id)::bigint)
+    WHEN 'amount_asc' THEN (amount, kind, id) > (sqlc.narg(cursor_amount)::bigint, sqlc.narg(cursor_kind)::varchar, sqlc.narg(cursor_id)::bigint)
+    ELSE (created_at, kind, id) < (sqlc.narg(cursor_time)::timestamptz, sqlc.narg(cursor_kind)::varchar, sqlc.narg(cursor_id)::bigint)
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort)::varchar = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort)::varchar = 'amount_desc' THEN amount END DESC,
+  CASE WHEN sqlc.arg(sort)::varchar = 'amount_asc' THEN amount END ASC,
+  CASE WHEN sqlc.arg(sort)::varchar NOT IN ('created_at_asc', 'amount_desc', 'amount_asc') THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort)::varchar IN ('created_at_asc', 'amount_asc') THEN kind END ASC,
+  CASE WHEN sqlc.arg(sort)::varchar IN ('created_at_asc', 'amount_asc') THEN id END ASC,
+  kind DESC,
+  id DESC
+LIMIT sqlc.arg(page_limit)::int;
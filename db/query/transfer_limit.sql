@@ -0,0 +1,57 @@
+-- name: GetAccountTransferLimit :one
+-- the limits are null when the profile has no row for the currency
+SELECT r.username, r.profile, r.currency, l.per_transaction, l.daily, l.monthly
+FROM (
+  SELECT a.owner AS username, a.currency, (CASE
+    -- users below tier 1, or whose email is not verified, get the tier0 limits
+    WHEN u.kyc_tier > 0 AND EXISTS (
+      SELECT 1 FROM user_aliases ua
+      WHERE ua.kind = 'email' AND ua.value = lower(u.email) AND ua.username = u.username AND ua.verified_at IS NOT NULL
+    ) THEN u.limit_profile
+    ELSE 'tier0'
+  END)::varchar AS profile
+  FROM accounts a
+  JOIN users u ON u.username = a.owner
+  WHERE a.id = $1
+) r
+LEFT JOIN transfer_limits l ON l.currency = r.currency AND l.profile = r.profile;
+
+-- name: UpsertTransferLimit :one
+INSERT INTO transfer_limits (
+  profile,
+  currency,
+  per_transaction,
+  daily,
+  monthly
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (profile, currency) DO UPDATE SET
+  per_transaction = EXCLUDED.per_transaction,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly
+RETURNING *;
+
+-- name: AddTransferUsage :one
+INSERT INTO transfer_usage (
+  username,
+  currency,
+  day,
+  amount,
+  count
+) VALUES ($1, $2, $3, sqlc.arg(amount), 1)
+ON CONFLICT (username, currency, day)
+DO UPDATE SET amount = transfer_usage.amount + EXCLUDED.amount, count = transfer_usage.count + 1
+RETURNING *;
+
+-- name: GetTransferUsageBetween :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS amount
+FROM transfer_usage
+WHERE username = $1 AND currency = $2 AND day >= sqlc.arg(from_day) AND day <= sqlc.arg(to_day);
+
+-- name: GetLimitProfile :one
+SELECT * FROM limit_profiles WHERE name = $1 LIMIT 1;
+
+-- name: ListTransferLimits :many
+SELECT * FROM transfer_limits WHERE profile = $1 ORDER BY currency;
+
+-- name: UpdateUserLimitProfile :one
+UPDATE users SET limit_profile = $2 WHERE username = $1 RETURNING *;
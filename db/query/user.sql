@@ -16,3 +16,6 @@ UPDATE users
         full_name = COALESCE(sqlc.narg(full_name), full_name),
         email = COALESCE(sqlc.narg(email), email)
     WHERE username = sqlc.arg(username) RETURNING *;
+
+-- name: UpdateUserRole :one
+UPDATE users SET role = $2 WHERE username = $1 RETURNING *;
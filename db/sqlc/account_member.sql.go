@@ -0,0 +1,356 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: account_member.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const acceptAccountInvitation = `-- name: AcceptAccountInvitation :one
+UPDATE account_members
+SET status = 'active', accepted_at = now()
+WHERE account_id = $1 AND username = $2 AND status = 'invited'
+RETURNING account_id, username, role, status, invited_by, created_at, accepted_at
+`
+
+type AcceptAccountInvitationParams struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+}
+
+func (q *Queries) AcceptAccountInvitation(ctx context.Context, arg AcceptAccountInvitationParams) (AccountMember, error) {
+	row := q.db.QueryRowContext(ctx, acceptAccountInvitation, arg.AccountID, arg.Username)
+	var i AccountMember
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.AcceptedAt,
+	)
+	return i, err
+}
+
+const countAccountSigners = `-- name: CountAccountSigners :one
+SELECT count(*) FROM account_members
+WHERE account_id = $1 AND status = 'active' AND role IN ('owner', 'signer')
+`
+
+func (q *Queries) CountAccountSigners(ctx context.Context, accountID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccountSigners, accountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createTransferApproval = `-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  requested_by,
+  risk_decision_id
+) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, from_account_id, to_account_id, amount, currency, requested_by, risk_decision_id, status, decided_by, decided_at, transfer_id, created_at
+`
+
+type CreateTransferApprovalParams struct {
+	FromAccountID  int64  `json:"from_account_id"`
+	ToAccountID    int64  `json:"to_account_id"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+	RequestedBy    string `json:"requested_by"`
+	RiskDecisionID int64  `json:"risk_decision_id"`
+}
+
+func (q *Queries) CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error) {
+	row := q.db.QueryRowContext(ctx, createTransferApproval,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.RequestedBy,
+		arg.RiskDecisionID,
+	)
+	var i TransferApproval
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.RequestedBy,
+		&i.RiskDecisionID,
+		&i.Status,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const decideTransferApproval = `-- name: DecideTransferApproval :one
+UPDATE transfer_approvals
+SET status = $2, decided_by = $3, decided_at = now(), transfer_id = $4
+WHERE id = $1
+RETURNING id, from_account_id, to_account_id, amount, currency, requested_by, risk_decision_id, status, decided_by, decided_at, transfer_id, created_at
+`
+
+type DecideTransferApprovalParams struct {
+	ID         int64          `json:"id"`
+	Status     string         `json:"status"`
+	DecidedBy  sql.NullString `json:"decided_by"`
+	TransferID sql.NullInt64  `json:"transfer_id"`
+}
+
+func (q *Queries) DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error) {
+	row := q.db.QueryRowContext(ctx, decideTransferApproval,
+		arg.ID,
+		arg.Status,
+		arg.DecidedBy,
+		arg.TransferID,
+	)
+	var i TransferApproval
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.RequestedBy,
+		&i.RiskDecisionID,
+		&i.Status,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAccountMember = `-- name: DeleteAccountMember :one
+DELETE FROM account_members
+WHERE account_id = $1 AND username = $2
+RETURNING account_id, username, role, status, invited_by, created_at, accepted_at
+`
+
+type DeleteAccountMemberParams struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+}
+
+func (q *Queries) DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) (AccountMember, error) {
+	row := q.db.QueryRowContext(ctx, deleteAccountMember, arg.AccountID, arg.Username)
+	var i AccountMember
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.AcceptedAt,
+	)
+	return i, err
+}
+
+const getAccountMember = `-- name: GetAccountMember :one
+SELECT account_id, username, role, status, invited_by, created_at, accepted_at FROM account_members
+WHERE account_id = $1 AND username = $2 LIMIT 1
+`
+
+type GetAccountMemberParams struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+}
+
+func (q *Queries) GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error) {
+	row := q.db.QueryRowContext(ctx, getAccountMember, arg.AccountID, arg.Username)
+	var i AccountMember
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.AcceptedAt,
+	)
+	return i, err
+}
+
+const getTransferApprovalForUpdate = `-- name: GetTransferApprovalForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, currency, requested_by, risk_decision_id, status, decided_by, decided_at, transfer_id, created_at FROM transfer_approvals WHERE id = $1 LIMIT 1 FOR UPDATE
+`
+
+func (q *Queries) GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error) {
+	row := q.db.QueryRowContext(ctx, getTransferApprovalForUpdate, id)
+	var i TransferApproval
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.RequestedBy,
+		&i.RiskDecisionID,
+		&i.Status,
+		&i.DecidedBy,
+		&i.DecidedAt,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const inviteAccountMember = `-- name: InviteAccountMember :one
+INSERT INTO account_members (
+  account_id,
+  username,
+  role,
+  invited_by
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (account_id, username) DO UPDATE SET
+  role = EXCLUDED.role,
+  invited_by = EXCLUDED.invited_by
+WHERE account_members.status = 'invited'
+RETURNING account_id, username, role, status, invited_by, created_at, accepted_at
+`
+
+type InviteAccountMemberParams struct {
+	AccountID int64          `json:"account_id"`
+	Username  string         `json:"username"`
+	Role      string         `json:"role"`
+	InvitedBy sql.NullString `json:"invited_by"`
+}
+
+func (q *Queries) InviteAccountMember(ctx context.Context, arg InviteAccountMemberParams) (AccountMember, error) {
+	row := q.db.QueryRowContext(ctx, inviteAccountMember,
+		arg.AccountID,
+		arg.Username,
+		arg.Role,
+		arg.InvitedBy,
+	)
+	var i AccountMember
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Role,
+		&i.Status,
+		&i.InvitedBy,
+		&i.CreatedAt,
+		&i.AcceptedAt,
+	)
+	return i, err
+}
+
+const listAccountMembers = `-- name: ListAccountMembers :many
+SELECT account_id, username, role, status, invited_by, created_at, accepted_at FROM account_members
+WHERE account_id = $1
+ORDER BY created_at, username
+`
+
+func (q *Queries) ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountMembers, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountMember{}
+	for rows.Next() {
+		var i AccountMember
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Username,
+			&i.Role,
+			&i.Status,
+			&i.InvitedBy,
+			&i.CreatedAt,
+			&i.AcceptedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingTransferApprovals = `-- name: ListPendingTransferApprovals :many
+SELECT id, from_account_id, to_account_id, amount, currency, requested_by, risk_decision_id, status, decided_by, decided_at, transfer_id, created_at FROM transfer_approvals
+WHERE from_account_id = $1 AND status = 'pending'
+ORDER BY id
+`
+
+func (q *Queries) ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]TransferApproval, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingTransferApprovals, fromAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferApproval{}
+	for rows.Next() {
+		var i TransferApproval
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.RequestedBy,
+			&i.RiskDecisionID,
+			&i.Status,
+			&i.DecidedBy,
+			&i.DecidedAt,
+			&i.TransferID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setAccountApprovalThreshold = `-- name: SetAccountApprovalThreshold :one
+UPDATE accounts SET approval_threshold = $2 WHERE id = $1 RETURNING id, owner, balance, currency, created_at, product, approval_threshold, kind
+`
+
+type SetAccountApprovalThresholdParams struct {
+	ID                int64 `json:"id"`
+	ApprovalThreshold int64 `json:"approval_threshold"`
+}
+
+func (q *Queries) SetAccountApprovalThreshold(ctx context.Context, arg SetAccountApprovalThresholdParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, setAccountApprovalThreshold, arg.ID, arg.ApprovalThreshold)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Product,
+		&i.ApprovalThreshold,
+		&i.Kind,
+	)
+	return i, err
+}
@@ -14,17 +14,19 @@ func createRandomAccount(t *testing.T) Account {
 
 	user := createRandomUser(t)
 
-	arg := CreateAccountParams{
-		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+	arg := CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Currency: util.RandomCurrency(),
+		},
+		OpeningBalance: util.RandomMoney(),
 	}
 
-	account, err := testQueries.CreateAccount(context.Background(), arg)
+	account, err := NewStore(testDB).CreateAccountTx(context.Background(), arg)
 	require.NoError(t, err)
 	require.NotEmpty(t, account)
 	require.Equal(t, arg.Owner, account.Owner)
-	require.Equal(t, arg.Balance, account.Balance)
+	require.Equal(t, arg.OpeningBalance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
 
 	require.NotZero(t, account.ID)
@@ -34,37 +36,35 @@ func createRandomAccount(t *testing.T) Account {
 
 }
 
-func TestQueries_GetAccount(t *testing.T) {
+// createRandomAccountLike creates an account of another user in the currency
+// of account, so that money can move between the two
+func createRandomAccountLike(t *testing.T, account Account) Account {
 
-	account1 := createRandomAccount(t)
-	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
-	require.NoError(t, err)
-	require.NotEmpty(t, account2)
+	user := createRandomUser(t)
 
-	require.Equal(t, account1.ID, account2.ID)
-	require.Equal(t, account1.Owner, account2.Owner)
-	require.Equal(t, account1.Balance, account2.Balance)
-	require.Equal(t, account1.Currency, account2.Currency)
-	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
+	other, err := NewStore(testDB).CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Currency: account.Currency,
+		},
+		OpeningBalance: util.RandomMoney(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Currency, other.Currency)
 
+	return other
 }
 
-func TestQueries_UpdateAccount(t *testing.T) {
+func TestQueries_GetAccount(t *testing.T) {
 
 	account1 := createRandomAccount(t)
-
-	arg := UpdateAccountParams{
-		ID:      account1.ID,
-		Balance: util.RandomMoney(),
-	}
-
-	account2, err := testQueries.UpdateAccount(context.Background(), arg)
+	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
 	require.NoError(t, err)
-
 	require.NotEmpty(t, account2)
+
 	require.Equal(t, account1.ID, account2.ID)
 	require.Equal(t, account1.Owner, account2.Owner)
-	require.Equal(t, arg.Balance, account2.Balance)
+	require.Equal(t, account1.Balance, account2.Balance)
 	require.Equal(t, account1.Currency, account2.Currency)
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 
@@ -72,9 +72,15 @@ func TestQueries_UpdateAccount(t *testing.T) {
 
 func TestQueries_DeleteAccount(t *testing.T) {
 
-	account := createRandomAccount(t)
+	// an account with entries cannot be deleted, so open an empty one
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: util.RandomCurrency(),
+	})
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
 
-	err := testQueries.DeleteAccount(context.Background(), account.ID)
+	err = testQueries.DeleteAccount(context.Background(), account.ID)
 	require.NoError(t, err)
 
 	account, err = testQueries.GetAccount(context.Background(), account.ID)
@@ -92,9 +98,9 @@ func TestQueries_ListAccount(t *testing.T) {
 	}
 
 	arg := ListAccountsParams{
-		Owner: lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Username: lastAccount.Owner,
+		Limit:    5,
+		Offset:   0,
 	}
 
 	accounts, err := testQueries.ListAccounts(context.Background(), arg)
@@ -107,3 +113,57 @@ func TestQueries_ListAccount(t *testing.T) {
 	}
 
 }
+
+func TestQueries_ListAccountsOfMember(t *testing.T) {
+
+	account := createRandomAccount(t)
+	member := addActiveMember(t, account, MemberRoleViewer)
+
+	// an account is listed for its active members, not only its owner
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{
+		Username: member.Username,
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	removed, err := testQueries.DeleteAccountMember(context.Background(), DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  member.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberStatusActive, removed.Status)
+
+	accounts, err = testQueries.ListAccounts(context.Background(), ListAccountsParams{
+		Username: member.Username,
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
+
+func TestQueries_ListUnbalancedAccounts(t *testing.T) {
+
+	balanced := createRandomAccount(t)
+
+	// only a change outside the ledger functions can unbalance an account
+	unbalanced := createRandomAccount(t)
+	_, err := testDB.Exec("UPDATE accounts SET balance = balance + 1 WHERE id = $1", unbalanced.ID)
+	require.NoError(t, err)
+
+	accounts, err := testQueries.ListUnbalancedAccounts(context.Background())
+	require.NoError(t, err)
+
+	found := map[int64]ListUnbalancedAccountsRow{}
+	for _, account := range accounts {
+		found[account.ID] = account
+	}
+
+	require.NotContains(t, found, balanced.ID)
+	require.Contains(t, found, unbalanced.ID)
+	require.Equal(t, unbalanced.Balance+1, found[unbalanced.ID].Balance)
+	require.Equal(t, unbalanced.Balance, found[unbalanced.ID].EntriesTotal)
+}
@@ -0,0 +1,431 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: batch_transfer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const completeBatchTransfer = `-- name: CompleteBatchTransfer :one
+UPDATE batch_transfers
+SET status = $2,
+    succeeded_count = $3,
+    failed_count = $4,
+    completed_at = now()
+WHERE id = $1 RETURNING id, owner, from_account_id, mode, status, total_count, total_amount, succeeded_count, failed_count, idempotency_key, created_at, completed_at
+`
+
+type CompleteBatchTransferParams struct {
+	ID             int64  `json:"id"`
+	Status         string `json:"status"`
+	SucceededCount int32  `json:"succeeded_count"`
+	FailedCount    int32  `json:"failed_count"`
+}
+
+func (q *Queries) CompleteBatchTransfer(ctx context.Context, arg CompleteBatchTransferParams) (BatchTransfer, error) {
+	row := q.db.QueryRowContext(ctx, completeBatchTransfer,
+		arg.ID,
+		arg.Status,
+		arg.SucceededCount,
+		arg.FailedCount,
+	)
+	var i BatchTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Mode,
+		&i.Status,
+		&i.TotalCount,
+		&i.TotalAmount,
+		&i.SucceededCount,
+		&i.FailedCount,
+		&i.IdempotencyKey,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const countBatchTransferItems = `-- name: CountBatchTransferItems :one
+SELECT
+  COUNT(*) FILTER (WHERE status = 'succeeded')::int AS succeeded,
+  COUNT(*) FILTER (WHERE status IN ('failed', 'skipped'))::int AS failed,
+  COUNT(*) FILTER (WHERE status = 'pending')::int AS pending
+FROM batch_transfer_items WHERE batch_id = $1
+`
+
+type CountBatchTransferItemsRow struct {
+	Succeeded int32 `json:"succeeded"`
+	Failed    int32 `json:"failed"`
+	Pending   int32 `json:"pending"`
+}
+
+func (q *Queries) CountBatchTransferItems(ctx context.Context, batchID int64) (CountBatchTransferItemsRow, error) {
+	row := q.db.QueryRowContext(ctx, countBatchTransferItems, batchID)
+	var i CountBatchTransferItemsRow
+	err := row.Scan(&i.Succeeded, &i.Failed, &i.Pending)
+	return i, err
+}
+
+const createBatchTransfer = `-- name: CreateBatchTransfer :one
+INSERT INTO batch_transfers (
+  owner,
+  from_account_id,
+  mode,
+  total_count,
+  total_amount,
+  idempotency_key
+) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, owner, from_account_id, mode, status, total_count, total_amount, succeeded_count, failed_count, idempotency_key, created_at, completed_at
+`
+
+type CreateBatchTransferParams struct {
+	Owner          string         `json:"owner"`
+	FromAccountID  int64          `json:"from_account_id"`
+	Mode           string         `json:"mode"`
+	TotalCount     int32          `json:"total_count"`
+	TotalAmount    int64          `json:"total_amount"`
+	IdempotencyKey sql.NullString `json:"idempotency_key"`
+}
+
+func (q *Queries) CreateBatchTransfer(ctx context.Context, arg CreateBatchTransferParams) (BatchTransfer, error) {
+	row := q.db.QueryRowContext(ctx, createBatchTransfer,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.Mode,
+		arg.TotalCount,
+		arg.TotalAmount,
+		arg.IdempotencyKey,
+	)
+	var i BatchTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Mode,
+		&i.Status,
+		&i.TotalCount,
+		&i.TotalAmount,
+		&i.SucceededCount,
+		&i.FailedCount,
+		&i.IdempotencyKey,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const createBatchTransferItem = `-- name: CreateBatchTransferItem :one
+INSERT INTO batch_transfer_items (
+  batch_id,
+  row_number,
+  to_account_id,
+  amount,
+  reference,
+  risk_decision_id
+) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, batch_id, row_number, to_account_id, amount, reference, status, transfer_id, error, processed_at, risk_decision_id
+`
+
+type CreateBatchTransferItemParams struct {
+	BatchID        int64         `json:"batch_id"`
+	RowNumber      int32         `json:"row_number"`
+	ToAccountID    int64         `json:"to_account_id"`
+	Amount         int64         `json:"amount"`
+	Reference      string        `json:"reference"`
+	RiskDecisionID sql.NullInt64 `json:"risk_decision_id"`
+}
+
+func (q *Queries) CreateBatchTransferItem(ctx context.Context, arg CreateBatchTransferItemParams) (BatchTransferItem, error) {
+	row := q.db.QueryRowContext(ctx, createBatchTransferItem,
+		arg.BatchID,
+		arg.RowNumber,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Reference,
+		arg.RiskDecisionID,
+	)
+	var i BatchTransferItem
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.RowNumber,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reference,
+		&i.Status,
+		&i.TransferID,
+		&i.Error,
+		&i.ProcessedAt,
+		&i.RiskDecisionID,
+	)
+	return i, err
+}
+
+const getBatchTransfer = `-- name: GetBatchTransfer :one
+SELECT id, owner, from_account_id, mode, status, total_count, total_amount, succeeded_count, failed_count, idempotency_key, created_at, completed_at FROM batch_transfers WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetBatchTransfer(ctx context.Context, id int64) (BatchTransfer, error) {
+	row := q.db.QueryRowContext(ctx, getBatchTransfer, id)
+	var i BatchTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Mode,
+		&i.Status,
+		&i.TotalCount,
+		&i.TotalAmount,
+		&i.SucceededCount,
+		&i.FailedCount,
+		&i.IdempotencyKey,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getBatchTransferByIdempotencyKey = `-- name: GetBatchTransferByIdempotencyKey :one
+SELECT id, owner, from_account_id, mode, status, total_count, total_amount, succeeded_count, failed_count, idempotency_key, created_at, completed_at FROM batch_transfers WHERE owner = $1 AND idempotency_key = $2 LIMIT 1
+`
+
+type GetBatchTransferByIdempotencyKeyParams struct {
+	Owner          string         `json:"owner"`
+	IdempotencyKey sql.NullString `json:"idempotency_key"`
+}
+
+func (q *Queries) GetBatchTransferByIdempotencyKey(ctx context.Context, arg GetBatchTransferByIdempotencyKeyParams) (BatchTransfer, error) {
+	row := q.db.QueryRowContext(ctx, getBatchTransferByIdempotencyKey, arg.Owner, arg.IdempotencyKey)
+	var i BatchTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Mode,
+		&i.Status,
+		&i.TotalCount,
+		&i.TotalAmount,
+		&i.SucceededCount,
+		&i.FailedCount,
+		&i.IdempotencyKey,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getBatchTransferItemForUpdate = `-- name: GetBatchTransferItemForUpdate :one
+SELECT id, batch_id, row_number, to_account_id, amount, reference, status, transfer_id, error, processed_at, risk_decision_id FROM batch_transfer_items WHERE id = $1 LIMIT 1 FOR UPDATE
+`
+
+func (q *Queries) GetBatchTransferItemForUpdate(ctx context.Context, id int64) (BatchTransferItem, error) {
+	row := q.db.QueryRowContext(ctx, getBatchTransferItemForUpdate, id)
+	var i BatchTransferItem
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.RowNumber,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reference,
+		&i.Status,
+		&i.TransferID,
+		&i.Error,
+		&i.ProcessedAt,
+		&i.RiskDecisionID,
+	)
+	return i, err
+}
+
+const listBatchTransferItems = `-- name: ListBatchTransferItems :many
+SELECT id, batch_id, row_number, to_account_id, amount, reference, status, transfer_id, error, processed_at, risk_decision_id FROM batch_transfer_items WHERE batch_id = $1 ORDER BY row_number LIMIT $2 OFFSET $3
+`
+
+type ListBatchTransferItemsParams struct {
+	BatchID int64 `json:"batch_id"`
+	Limit   int32 `json:"limit"`
+	Offset  int32 `json:"offset"`
+}
+
+func (q *Queries) ListBatchTransferItems(ctx context.Context, arg ListBatchTransferItemsParams) ([]BatchTransferItem, error) {
+	rows, err := q.db.QueryContext(ctx, listBatchTransferItems, arg.BatchID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BatchTransferItem{}
+	for rows.Next() {
+		var i BatchTransferItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.RowNumber,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Reference,
+			&i.Status,
+			&i.TransferID,
+			&i.Error,
+			&i.ProcessedAt,
+			&i.RiskDecisionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingBatchTransferItems = `-- name: ListPendingBatchTransferItems :many
+SELECT id, batch_id, row_number, to_account_id, amount, reference, status, transfer_id, error, processed_at, risk_decision_id FROM batch_transfer_items WHERE batch_id = $1 AND status = 'pending' ORDER BY row_number
+`
+
+func (q *Queries) ListPendingBatchTransferItems(ctx context.Context, batchID int64) ([]BatchTransferItem, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingBatchTransferItems, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BatchTransferItem{}
+	for rows.Next() {
+		var i BatchTransferItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.RowNumber,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Reference,
+			&i.Status,
+			&i.TransferID,
+			&i.Error,
+			&i.ProcessedAt,
+			&i.RiskDecisionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markBatchTransferItemFailed = `-- name: MarkBatchTransferItemFailed :one
+UPDATE batch_transfer_items
+SET status = 'failed', error = $2, processed_at = now()
+WHERE id = $1 RETURNING id, batch_id, row_number, to_account_id, amount, reference, status, transfer_id, error, processed_at, risk_decision_id
+`
+
+type MarkBatchTransferItemFailedParams struct {
+	ID    int64  `json:"id"`
+	Error string `json:"error"`
+}
+
+func (q *Queries) MarkBatchTransferItemFailed(ctx context.Context, arg MarkBatchTransferItemFailedParams) (BatchTransferItem, error) {
+	row := q.db.QueryRowContext(ctx, markBatchTransferItemFailed, arg.ID, arg.Error)
+	var i BatchTransferItem
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.RowNumber,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reference,
+		&i.Status,
+		&i.TransferID,
+		&i.Error,
+		&i.ProcessedAt,
+		&i.RiskDecisionID,
+	)
+	return i, err
+}
+
+const markBatchTransferItemSucceeded = `-- name: MarkBatchTransferItemSucceeded :one
+UPDATE batch_transfer_items
+SET status = 'succeeded', transfer_id = $2, error = '', processed_at = now()
+WHERE id = $1 RETURNING id, batch_id, row_number, to_account_id, amount, reference, status, transfer_id, error, processed_at, risk_decision_id
+`
+
+type MarkBatchTransferItemSucceededParams struct {
+	ID         int64         `json:"id"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+func (q *Queries) MarkBatchTransferItemSucceeded(ctx context.Context, arg MarkBatchTransferItemSucceededParams) (BatchTransferItem, error) {
+	row := q.db.QueryRowContext(ctx, markBatchTransferItemSucceeded, arg.ID, arg.TransferID)
+	var i BatchTransferItem
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.RowNumber,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reference,
+		&i.Status,
+		&i.TransferID,
+		&i.Error,
+		&i.ProcessedAt,
+		&i.RiskDecisionID,
+	)
+	return i, err
+}
+
+const skipPendingBatchTransferItems = `-- name: SkipPendingBatchTransferItems :exec
+UPDATE batch_transfer_items
+SET status = 'skipped', error = $2, processed_at = now()
+WHERE batch_id = $1 AND status = 'pending'
+`
+
+type SkipPendingBatchTransferItemsParams struct {
+	BatchID int64  `json:"batch_id"`
+	Error   string `json:"error"`
+}
+
+func (q *Queries) SkipPendingBatchTransferItems(ctx context.Context, arg SkipPendingBatchTransferItemsParams) error {
+	_, err := q.db.ExecContext(ctx, skipPendingBatchTransferItems, arg.BatchID, arg.Error)
+	return err
+}
+
+const updateBatchTransferStatus = `-- name: UpdateBatchTransferStatus :one
+UPDATE batch_transfers SET status = $2 WHERE id = $1 RETURNING id, owner, from_account_id, mode, status, total_count, total_amount, succeeded_count, failed_count, idempotency_key, created_at, completed_at
+`
+
+type UpdateBatchTransferStatusParams struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) UpdateBatchTransferStatus(ctx context.Context, arg UpdateBatchTransferStatusParams) (BatchTransfer, error) {
+	row := q.db.QueryRowContext(ctx, updateBatchTransferStatus, arg.ID, arg.Status)
+	var i BatchTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Mode,
+		&i.Status,
+		&i.TotalCount,
+		&i.TotalAmount,
+		&i.SucceededCount,
+		&i.FailedCount,
+		&i.IdempotencyKey,
+		&i.CreatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,244 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: beneficiary.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createBeneficiary = `-- name: CreateBeneficiary :one
+INSERT INTO beneficiaries (
+  owner,
+  nickname,
+  account_id
+)
+SELECT $1, $2, a.id FROM accounts a
+WHERE a.id = $3 AND a.kind = 'customer'
+RETURNING id, owner, nickname, account_id, created_at
+`
+
+type CreateBeneficiaryParams struct {
+	Owner     string `json:"owner"`
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"account_id"`
+}
+
+// general ledger accounts cannot be saved, no row is returned for them
+func (q *Queries) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRowContext(ctx, createBeneficiary, arg.Owner, arg.Nickname, arg.AccountID)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteBeneficiary = `-- name: DeleteBeneficiary :execrows
+DELETE FROM beneficiaries
+WHERE id = $1 AND owner = $2
+`
+
+type DeleteBeneficiaryParams struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+}
+
+func (q *Queries) DeleteBeneficiary(ctx context.Context, arg DeleteBeneficiaryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBeneficiary, arg.ID, arg.Owner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getBeneficiary = `-- name: GetBeneficiary :one
+SELECT b.id, b.owner, b.nickname, b.account_id, b.created_at, a.owner AS account_owner, a.currency, u.full_name
+FROM beneficiaries b
+JOIN accounts a ON a.id = b.account_id
+JOIN users u ON u.username = a.owner
+WHERE b.id = $1 AND b.owner = $2
+LIMIT 1
+`
+
+type GetBeneficiaryParams struct {
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+}
+
+type GetBeneficiaryRow struct {
+	ID           int64     `json:"id"`
+	Owner        string    `json:"owner"`
+	Nickname     string    `json:"nickname"`
+	AccountID    int64     `json:"account_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	AccountOwner string    `json:"account_owner"`
+	Currency     string    `json:"currency"`
+	FullName     string    `json:"full_name"`
+}
+
+func (q *Queries) GetBeneficiary(ctx context.Context, arg GetBeneficiaryParams) (GetBeneficiaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getBeneficiary, arg.ID, arg.Owner)
+	var i GetBeneficiaryRow
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Nickname,
+		&i.AccountID,
+		&i.CreatedAt,
+		&i.AccountOwner,
+		&i.Currency,
+		&i.FullName,
+	)
+	return i, err
+}
+
+const getRecipientAccount = `-- name: GetRecipientAccount :one
+SELECT a.id, a.owner, a.currency, u.full_name
+FROM accounts a
+JOIN users u ON u.username = a.owner
+WHERE a.owner = $1 AND a.currency = $2 AND a.kind = 'customer'
+ORDER BY (a.product = 'checking') DESC, a.id
+LIMIT 1
+`
+
+type GetRecipientAccountParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+type GetRecipientAccountRow struct {
+	ID       int64  `json:"id"`
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+	FullName string `json:"full_name"`
+}
+
+// checking accounts are preferred when the user has several in the currency
+func (q *Queries) GetRecipientAccount(ctx context.Context, arg GetRecipientAccountParams) (GetRecipientAccountRow, error) {
+	row := q.db.QueryRowContext(ctx, getRecipientAccount, arg.Owner, arg.Currency)
+	var i GetRecipientAccountRow
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.FullName,
+	)
+	return i, err
+}
+
+const getVerifiedAliasUsername = `-- name: GetVerifiedAliasUsername :one
+SELECT username FROM user_aliases
+WHERE kind = $1 AND value = $2 AND verified_at IS NOT NULL
+LIMIT 1
+`
+
+type GetVerifiedAliasUsernameParams struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+func (q *Queries) GetVerifiedAliasUsername(ctx context.Context, arg GetVerifiedAliasUsernameParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getVerifiedAliasUsername, arg.Kind, arg.Value)
+	var username string
+	err := row.Scan(&username)
+	return username, err
+}
+
+const listBeneficiaries = `-- name: ListBeneficiaries :many
+SELECT b.id, b.owner, b.nickname, b.account_id, b.created_at, a.owner AS account_owner, a.currency, u.full_name
+FROM beneficiaries b
+JOIN accounts a ON a.id = b.account_id
+JOIN users u ON u.username = a.owner
+WHERE b.owner = $1
+ORDER BY b.nickname
+`
+
+type ListBeneficiariesRow struct {
+	ID           int64     `json:"id"`
+	Owner        string    `json:"owner"`
+	Nickname     string    `json:"nickname"`
+	AccountID    int64     `json:"account_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	AccountOwner string    `json:"account_owner"`
+	Currency     string    `json:"currency"`
+	FullName     string    `json:"full_name"`
+}
+
+func (q *Queries) ListBeneficiaries(ctx context.Context, owner string) ([]ListBeneficiariesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBeneficiaries, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBeneficiariesRow{}
+	for rows.Next() {
+		var i ListBeneficiariesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Nickname,
+			&i.AccountID,
+			&i.CreatedAt,
+			&i.AccountOwner,
+			&i.Currency,
+			&i.FullName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertUserAlias = `-- name: UpsertUserAlias :one
+INSERT INTO user_aliases (
+  kind,
+  value,
+  username,
+  verified_at
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (kind, value) DO UPDATE SET
+  username = EXCLUDED.username,
+  verified_at = EXCLUDED.verified_at
+RETURNING kind, value, username, verified_at, created_at
+`
+
+type UpsertUserAliasParams struct {
+	Kind       string       `json:"kind"`
+	Value      string       `json:"value"`
+	Username   string       `json:"username"`
+	VerifiedAt sql.NullTime `json:"verified_at"`
+}
+
+func (q *Queries) UpsertUserAlias(ctx context.Context, arg UpsertUserAliasParams) (UserAlias, error) {
+	row := q.db.QueryRowContext(ctx, upsertUserAlias,
+		arg.Kind,
+		arg.Value,
+		arg.Username,
+		arg.VerifiedAt,
+	)
+	var i UserAlias
+	err := row.Scan(
+		&i.Kind,
+		&i.Value,
+		&i.Username,
+		&i.VerifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
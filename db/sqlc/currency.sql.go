@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: currency.sql
+
+package db
+
+import (
+	"context"
+)
+
+const getCurrency = `-- name: GetCurrency :one
+SELECT code, name, minor_units, symbol, enabled, created_at, risk_large_amount FROM currencies WHERE code = $1 LIMIT 1
+`
+
+func (q *Queries) GetCurrency(ctx context.Context, code string) (Currency, error) {
+	row := q.db.QueryRowContext(ctx, getCurrency, code)
+	var i Currency
+	err := row.Scan(
+		&i.Code,
+		&i.Name,
+		&i.MinorUnits,
+		&i.Symbol,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.RiskLargeAmount,
+	)
+	return i, err
+}
+
+const listCurrencies = `-- name: ListCurrencies :many
+SELECT code, name, minor_units, symbol, enabled, created_at, risk_large_amount FROM currencies ORDER BY code
+`
+
+func (q *Queries) ListCurrencies(ctx context.Context) ([]Currency, error) {
+	rows, err := q.db.QueryContext(ctx, listCurrencies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Currency{}
+	for rows.Next() {
+		var i Currency
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.MinorUnits,
+			&i.Symbol,
+			&i.Enabled,
+			&i.CreatedAt,
+			&i.RiskLargeAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCurrency = `-- name: UpsertCurrency :one
+INSERT INTO currencies (
+  code,
+  name,
+  minor_units,
+  symbol,
+  enabled,
+  risk_large_amount
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (code) DO UPDATE SET
+  name = EXCLUDED.name,
+  minor_units = EXCLUDED.minor_units,
+  symbol = EXCLUDED.symbol,
+  enabled = EXCLUDED.enabled,
+  risk_large_amount = EXCLUDED.risk_large_amount
+RETURNING code, name, minor_units, symbol, enabled, created_at, risk_large_amount
+`
+
+type UpsertCurrencyParams struct {
+	Code            string `json:"code"`
+	Name            string `json:"name"`
+	MinorUnits      int16  `json:"minor_units"`
+	Symbol          string `json:"symbol"`
+	Enabled         bool   `json:"enabled"`
+	RiskLargeAmount int64  `json:"risk_large_amount"`
+}
+
+func (q *Queries) UpsertCurrency(ctx context.Context, arg UpsertCurrencyParams) (Currency, error) {
+	row := q.db.QueryRowContext(ctx, upsertCurrency,
+		arg.Code,
+		arg.Name,
+		arg.MinorUnits,
+		arg.Symbol,
+		arg.Enabled,
+		arg.RiskLargeAmount,
+	)
+	var i Currency
+	err := row.Scan(
+		&i.Code,
+		&i.Name,
+		&i.MinorUnits,
+		&i.Symbol,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.RiskLargeAmount,
+	)
+	return i, err
+}
@@ -7,56 +7,255 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
-const createEntry = `-- name: CreateEntry :one
-INSERT INTO entries (account_id, amount) VALUES ($1, $2) RETURNING id, account_id, amount, created_at
+const getCorrectedEntryAmount = `-- name: GetCorrectedEntryAmount :one
+SELECT (e.amount + COALESCE(SUM(c.amount), 0))::bigint AS amount
+FROM entries e
+LEFT JOIN entries c ON c.corrects_entry_id = e.id
+WHERE e.id = $1
+GROUP BY e.id
 `
 
-type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+// the amount of an entry with all its corrections applied
+func (q *Queries) GetCorrectedEntryAmount(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getCorrectedEntryAmount, id)
+	var amount int64
+	err := row.Scan(&amount)
+	return amount, err
 }
 
-func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+const getEntry = `-- name: GetEntry :one
+SELECT id, account_id, amount, created_at, corrects_entry_id, transfer_id, entry_type, journal_id FROM entries WHERE id = $1 LIMIT  1
+`
+
+func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, getEntry, id)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.CorrectsEntryID,
+		&i.TransferID,
+		&i.EntryType,
+		&i.JournalID,
 	)
 	return i, err
 }
 
-const deleteEntry = `-- name: DeleteEntry :exec
-DELETE FROM entries WHERE id  = $1
+const getLastAccountEntryID = `-- name: GetLastAccountEntryID :one
+SELECT COALESCE(MAX(id), 0)::bigint AS last_entry_id FROM entries WHERE account_id = $1
 `
 
-func (q *Queries) DeleteEntry(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteEntry, id)
-	return err
+func (q *Queries) GetLastAccountEntryID(ctx context.Context, accountID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLastAccountEntryID, accountID)
+	var last_entry_id int64
+	err := row.Scan(&last_entry_id)
+	return last_entry_id, err
 }
 
-const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries WHERE id = $1 LIMIT  1
+const listAccountActivityAfter = `-- name: ListAccountActivityAfter :many
+SELECT e.id, e.account_id, e.amount, e.created_at, e.corrects_entry_id, e.transfer_id, e.entry_type, e.journal_id,
+  ((SELECT COALESCE(SUM(b.amount), 0) FROM entries b
+    WHERE b.account_id = $1 AND b.id <= $2)
+   + SUM(e.amount) OVER (ORDER BY e.id))::bigint AS balance
+FROM entries e
+WHERE e.account_id = $1 AND e.id > $2
+ORDER BY e.id
+LIMIT $3
 `
 
-func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, getEntry, id)
-	var i Entry
-	err := row.Scan(
-		&i.ID,
-		&i.AccountID,
-		&i.Amount,
-		&i.CreatedAt,
+type ListAccountActivityAfterParams struct {
+	AccountID  int64 `json:"account_id"`
+	AfterID    int64 `json:"after_id"`
+	LimitCount int32 `json:"limit_count"`
+}
+
+type ListAccountActivityAfterRow struct {
+	ID              int64         `json:"id"`
+	AccountID       int64         `json:"account_id"`
+	Amount          int64         `json:"amount"`
+	CreatedAt       time.Time     `json:"created_at"`
+	CorrectsEntryID sql.NullInt64 `json:"corrects_entry_id"`
+	TransferID      sql.NullInt64 `json:"transfer_id"`
+	EntryType       string        `json:"entry_type"`
+	JournalID       sql.NullInt64 `json:"journal_id"`
+	Balance         int64         `json:"balance"`
+}
+
+// entries of an account after after_id with the balance of the account right
+// after each of them. Entries of an account commit in id order, because the
+// account row is locked before they are written, so the running sum is exact.
+func (q *Queries) ListAccountActivityAfter(ctx context.Context, arg ListAccountActivityAfterParams) ([]ListAccountActivityAfterRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountActivityAfter, arg.AccountID, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountActivityAfterRow{}
+	for rows.Next() {
+		var i ListAccountActivityAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.CorrectsEntryID,
+			&i.TransferID,
+			&i.EntryType,
+			&i.JournalID,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountEntriesAfter = `-- name: ListAccountEntriesAfter :many
+SELECT id, account_id, amount, created_at, corrects_entry_id, transfer_id, entry_type, journal_id FROM entries
+WHERE account_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListAccountEntriesAfterParams struct {
+	AccountID  int64 `json:"account_id"`
+	AfterID    int64 `json:"after_id"`
+	LimitCount int32 `json:"limit_count"`
+}
+
+func (q *Queries) ListAccountEntriesAfter(ctx context.Context, arg ListAccountEntriesAfterParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountEntriesAfter, arg.AccountID, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.CorrectsEntryID,
+			&i.TransferID,
+			&i.EntryType,
+			&i.JournalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountStatement = `-- name: ListAccountStatement :many
+SELECT
+  e.id,
+  e.account_id,
+  e.amount,
+  e.entry_type,
+  e.transfer_id,
+  e.corrects_entry_id,
+  e.created_at,
+  c.id AS counterparty_account_id,
+  c.owner AS counterparty_owner,
+  u.full_name AS counterparty_name
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id AND e.entry_type = 'transfer'
+LEFT JOIN accounts c ON c.id = CASE WHEN e.account_id = t.from_account_id THEN t.to_account_id ELSE t.from_account_id END
+LEFT JOIN users u ON u.username = c.owner
+WHERE e.account_id = $1
+  AND e.created_at >= $2
+  AND e.created_at < $3
+ORDER BY e.id DESC
+LIMIT $5 OFFSET $4
+`
+
+type ListAccountStatementParams struct {
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+	Offset    int32     `json:"offset"`
+	Limit     int32     `json:"limit"`
+}
+
+type ListAccountStatementRow struct {
+	ID                    int64          `json:"id"`
+	AccountID             int64          `json:"account_id"`
+	Amount                int64          `json:"amount"`
+	EntryType             string         `json:"entry_type"`
+	TransferID            sql.NullInt64  `json:"transfer_id"`
+	CorrectsEntryID       sql.NullInt64  `json:"corrects_entry_id"`
+	CreatedAt             time.Time      `json:"created_at"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	CounterpartyOwner     sql.NullString `json:"counterparty_owner"`
+	CounterpartyName      sql.NullString `json:"counterparty_name"`
+}
+
+// entries of an account in [from_time, to_time), newest first. The
+// counterparty is the other account of a transfer entry.
+func (q *Queries) ListAccountStatement(ctx context.Context, arg ListAccountStatementParams) ([]ListAccountStatementRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountStatement,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Offset,
+		arg.Limit,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountStatementRow{}
+	for rows.Next() {
+		var i ListAccountStatementRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.EntryType,
+			&i.TransferID,
+			&i.CorrectsEntryID,
+			&i.CreatedAt,
+			&i.CounterpartyAccountID,
+			&i.CounterpartyOwner,
+			&i.CounterpartyName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries ORDER BY id LIMIT $1 offset $2
+SELECT id, account_id, amount, created_at, corrects_entry_id, transfer_id, entry_type, journal_id FROM entries ORDER BY id LIMIT $1 offset $2
 `
 
 type ListEntriesParams struct {
@@ -78,6 +277,10 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.CorrectsEntryID,
+			&i.TransferID,
+			&i.EntryType,
+			&i.JournalID,
 		); err != nil {
 			return nil, err
 		}
@@ -92,23 +295,38 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 	return items, nil
 }
 
-const updateEntry = `-- name: UpdateEntry :one
-UPDATE entries SET amount = $2 WHERE id = $1 RETURNING id, account_id, amount, created_at
+const listJournalEntries = `-- name: ListJournalEntries :many
+SELECT id, account_id, amount, created_at, corrects_entry_id, transfer_id, entry_type, journal_id FROM entries WHERE journal_id = $1 ORDER BY id
 `
 
-type UpdateEntryParams struct {
-	ID     int64 `json:"id"`
-	Amount int64 `json:"amount"`
-}
-
-func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, updateEntry, arg.ID, arg.Amount)
-	var i Entry
-	err := row.Scan(
-		&i.ID,
-		&i.AccountID,
-		&i.Amount,
-		&i.CreatedAt,
-	)
-	return i, err
+func (q *Queries) ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listJournalEntries, journalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.CorrectsEntryID,
+			&i.TransferID,
+			&i.EntryType,
+			&i.JournalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
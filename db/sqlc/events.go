@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/joefazee/simplebank/tracing"
+)
+
+// Domain event types written to the outbox
+const (
+	EventUserCreated           = "user.created"
+	EventTransferCompleted     = "transfer.completed"
+	EventBatchTransferCreated  = "batch_transfer.created"
+	EventPaymentRequestSettled = "payment_request.settled"
+	EventNewDeviceLogin        = "user.new_device_login"
+)
+
+// UserCreatedEvent is the payload of EventUserCreated
+type UserCreatedEvent struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// TransferCompletedEvent is the payload of EventTransferCompleted
+type TransferCompletedEvent struct {
+	TransferID    int64 `json:"transfer_id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+	Fee           int64 `json:"fee,omitempty"`
+}
+
+// BatchTransferCreatedEvent is the payload of EventBatchTransferCreated
+type BatchTransferCreatedEvent struct {
+	BatchID int64 `json:"batch_id"`
+}
+
+// PaymentRequestSettledEvent is the payload of EventPaymentRequestSettled
+type PaymentRequestSettledEvent struct {
+	RequestID  int64  `json:"request_id"`
+	Requester  string `json:"requester"`
+	Status     string `json:"status"`
+	TransferID int64  `json:"transfer_id,omitempty"`
+}
+
+// NewDeviceLoginEvent is the payload of EventNewDeviceLogin
+type NewDeviceLoginEvent struct {
+	Username  string `json:"username"`
+	SessionID string `json:"session_id"`
+	UserAgent string `json:"user_agent"`
+	ClientIP  string `json:"client_ip"`
+}
+
+// addOutboxEvent records an event in the outbox using the caller's transaction,
+// so the event is only published if the surrounding transaction commits. The
+// trace context of ctx is stored with it so consumers continue the same trace.
+func addOutboxEvent(ctx context.Context, q *Queries, eventType string, dedupKey string, payload interface{}) error {
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+
+	traceContext, err := json.Marshal(tracing.Inject(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace context: %w", err)
+	}
+
+	_, err = q.CreateOutboxEvent(ctx, CreateOutboxEventParams{
+		EventType:    eventType,
+		DedupKey:     dedupKey,
+		Payload:      data,
+		TraceContext: traceContext,
+	})
+
+	return err
+}
@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: fee.sql
+
+package db
+
+import (
+	"context"
+)
+
+const getAccountFeeSchedule = `-- name: GetAccountFeeSchedule :one
+SELECT s.id, s.product, s.currency, s.description, s.min_fee, s.max_fee, s.created_at FROM accounts a
+JOIN fee_schedules s ON s.product = a.product AND s.currency = a.currency
+WHERE a.id = $1
+`
+
+func (q *Queries) GetAccountFeeSchedule(ctx context.Context, id int64) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, getAccountFeeSchedule, id)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Product,
+		&i.Currency,
+		&i.Description,
+		&i.MinFee,
+		&i.MaxFee,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listFeeTiers = `-- name: ListFeeTiers :many
+SELECT schedule_id, from_amount, flat_amount, percent_bps FROM fee_tiers
+WHERE schedule_id = $1
+ORDER BY from_amount
+`
+
+func (q *Queries) ListFeeTiers(ctx context.Context, scheduleID int64) ([]FeeTier, error) {
+	rows, err := q.db.QueryContext(ctx, listFeeTiers, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeeTier{}
+	for rows.Next() {
+		var i FeeTier
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.FromAmount,
+			&i.FlatAmount,
+			&i.PercentBps,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
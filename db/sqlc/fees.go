@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// FeeIncomeAccount names the internal account transfer fees are paid into
+const FeeIncomeAccount = "fee_income"
+
+// basisPoints is the number of basis points in 100%
+const basisPoints = 10_000
+
+// Fee is what the sender of a transfer pays on top of the amount, and how it
+// was worked out
+type Fee struct {
+	// ScheduleID is zero when the sending account has no fee schedule
+	ScheduleID    int64 `json:"schedule_id"`
+	FlatAmount    int64 `json:"flat_amount"`
+	PercentBps    int32 `json:"percent_bps"`
+	PercentAmount int64 `json:"percent_amount"`
+	// Adjustment raises the fee to the schedule minimum or lowers it to its maximum
+	Adjustment int64 `json:"adjustment"`
+	Total      int64 `json:"total"`
+}
+
+// computeFee applies a fee schedule to a transfer amount. The tier with the
+// highest starting amount not above the transfer amount applies; when there
+// is none the transfer is free. The percentage is rounded half up.
+func computeFee(schedule FeeSchedule, tiers []FeeTier, amount int64) Fee {
+
+	fee := Fee{ScheduleID: schedule.ID}
+
+	var tier *FeeTier
+	for i := range tiers {
+		if tiers[i].FromAmount <= amount && (tier == nil || tiers[i].FromAmount > tier.FromAmount) {
+			tier = &tiers[i]
+		}
+	}
+	if tier == nil {
+		return fee
+	}
+
+	fee.FlatAmount = tier.FlatAmount
+	fee.PercentBps = tier.PercentBps
+	// split the amount so large transfers cannot overflow the multiplication
+	bps := int64(tier.PercentBps)
+	fee.PercentAmount = amount/basisPoints*bps + (amount%basisPoints*bps+basisPoints/2)/basisPoints
+
+	total := fee.FlatAmount + fee.PercentAmount
+	switch {
+	case total < schedule.MinFee:
+		fee.Adjustment = schedule.MinFee - total
+	case schedule.MaxFee.Valid && total > schedule.MaxFee.Int64:
+		fee.Adjustment = schedule.MaxFee.Int64 - total
+	}
+	fee.Total = total + fee.Adjustment
+
+	return fee
+}
+
+// transferFee works out the fee of a transfer from the schedule of the
+// product and currency of the sending account. Schedules have no transfer
+// kind: every transfer is a same-currency book transfer, so there is nothing
+// for a cross-currency or instant transfer fee to apply to yet, and an
+// amount threshold is expressed with tiers.
+func transferFee(ctx context.Context, q *Queries, fromAccountID int64, amount int64) (Fee, error) {
+
+	fees, err := transferFees(ctx, q, fromAccountID, []int64{amount})
+	if err != nil {
+		return Fee{}, err
+	}
+	return fees[0], nil
+}
+
+// transferFees works out the fees of several transfers out of one account,
+// reading its fee schedule once
+func transferFees(ctx context.Context, q *Queries, fromAccountID int64, amounts []int64) ([]Fee, error) {
+
+	fees := make([]Fee, len(amounts))
+
+	schedule, err := q.GetAccountFeeSchedule(ctx, fromAccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fees, nil
+		}
+		return nil, err
+	}
+
+	tiers, err := q.ListFeeTiers(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, amount := range amounts {
+		fees[i] = computeFee(schedule, tiers, amount)
+	}
+	return fees, nil
+}
+
+// PreviewTransferFee returns the fee a transfer of amount out of an account
+// would be charged right now
+func (store *SQLStore) PreviewTransferFee(ctx context.Context, fromAccountID int64, amount int64) (Fee, error) {
+	return transferFee(ctx, store.Queries, fromAccountID, amount)
+}
+
+// PreviewTransferFees returns the fees transfers of amounts out of an account
+// would be charged right now, in the order of amounts
+func (store *SQLStore) PreviewTransferFees(ctx context.Context, fromAccountID int64, amounts []int64) ([]Fee, error) {
+	return transferFees(ctx, store.Queries, fromAccountID, amounts)
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeFee(t *testing.T) {
+
+	schedule := FeeSchedule{ID: 1, MinFee: 50, MaxFee: sql.NullInt64{Int64: 2500, Valid: true}}
+	tiers := []FeeTier{
+		{ScheduleID: 1, FromAmount: 100, FlatAmount: 100},
+		{ScheduleID: 1, FromAmount: 100000, PercentBps: 50},
+		{ScheduleID: 1, FromAmount: 10000, FlatAmount: 10, PercentBps: 25},
+	}
+
+	testCases := []struct {
+		name   string
+		amount int64
+		want   Fee
+	}{
+		{"BelowFirstTier", 99, Fee{ScheduleID: 1}},
+		{"Flat", 100, Fee{ScheduleID: 1, FlatAmount: 100, Total: 100}},
+		// 0.25% of 10002 is 25.005, rounded to 25
+		{"MinFee", 10002, Fee{ScheduleID: 1, FlatAmount: 10, PercentBps: 25, PercentAmount: 25, Adjustment: 15, Total: 50}},
+		{"FlatAndPercent", 20002, Fee{ScheduleID: 1, FlatAmount: 10, PercentBps: 25, PercentAmount: 50, Total: 60}},
+		{"Percent", 200000, Fee{ScheduleID: 1, PercentBps: 50, PercentAmount: 1000, Total: 1000}},
+		{"MaxFee", 1000000, Fee{ScheduleID: 1, PercentBps: 50, PercentAmount: 5000, Adjustment: -2500, Total: 2500}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, computeFee(schedule, tiers, tc.amount))
+		})
+	}
+
+	uncapped := computeFee(FeeSchedule{ID: 1}, tiers, 1000000)
+	require.Equal(t, int64(5000), uncapped.Total)
+}
+
+func TestTransferTxWithFee(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	from, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    user.Username,
+			Currency: util.USD,
+			Product:  "savings",
+		},
+		OpeningBalance: 1000000,
+	})
+	require.NoError(t, err)
+	to := createRandomAccountLike(t, from)
+
+	feeIncome, err := store.GetInternalAccount(context.Background(), GetInternalAccountParams{
+		Name:     FeeIncomeAccount,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+
+	preview, err := store.PreviewTransferFee(context.Background(), from.ID, 50)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), preview.Total)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+	require.Equal(t, preview, result.Fee)
+	require.Equal(t, int64(100), result.Transfer.Fee)
+	require.Equal(t, int64(-100), result.FeeEntry.Amount)
+	require.Equal(t, EntryTypeFee, result.FeeEntry.EntryType)
+	require.Equal(t, result.Transfer.ID, result.FeeEntry.TransferID.Int64)
+	require.Equal(t, from.Balance-150, result.FromAccount.Balance)
+	require.Equal(t, to.Balance+50, result.ToAccount.Balance)
+
+	updated, err := store.GetAccount(context.Background(), feeIncome.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), updated.Balance-feeIncome.Balance)
+
+	// checking accounts have no fee schedule
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: to.ID,
+		ToAccountID:   from.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Fee.Total)
+	require.Zero(t, result.FeeEntry.ID)
+}
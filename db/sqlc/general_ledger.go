@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Kinds of accounts
+const (
+	AccountKindCustomer = "customer"
+	// AccountKindGL accounts are the internal accounts of the general ledger
+	AccountKindGL = "gl"
+)
+
+// SystemOwner owns every general ledger account. The user cannot log in.
+const SystemOwner = "simplebank"
+
+// Codes of the chart of accounts, besides FeeIncomeAccount and
+// InterestExpenseAccount
+const (
+	// CashAccount is where money enters and leaves the bank
+	CashAccount = "cash"
+	// CustomerDepositsAccount has no internal account; it is the sum of the
+	// customer accounts in a currency
+	CustomerDepositsAccount = "customer_deposits"
+	RetainedEarningsAccount = "retained_earnings"
+	FXSpreadAccount         = "fx_spread"
+	// AdjustmentsAccount takes the other side of entry corrections
+	AdjustmentsAccount = "adjustments"
+)
+
+// Categories of the chart of accounts
+const (
+	GLCategoryAsset     = "asset"
+	GLCategoryLiability = "liability"
+	GLCategoryEquity    = "equity"
+	GLCategoryIncome    = "income"
+	GLCategoryExpense   = "expense"
+)
+
+// ErrGLAccount is returned when a customer transfer touches a general ledger account
+var ErrGLAccount = errors.New("general ledger accounts cannot take part in customer transfers")
+
+// TrialBalanceLine is one account of the chart of accounts. A positive
+// balance is a credit and a negative one a debit, so that customer deposits
+// and income are credits while cash and expenses are debits.
+type TrialBalanceLine struct {
+	GetTrialBalanceRow
+	Debit  int64 `json:"debit"`
+	Credit int64 `json:"credit"`
+}
+
+type TrialBalance struct {
+	Currency string             `json:"currency"`
+	AsOf     time.Time          `json:"as_of"`
+	Lines    []TrialBalanceLine `json:"lines"`
+	// TotalDebit and TotalCredit are equal when every posting was balanced
+	TotalDebit  int64 `json:"total_debit"`
+	TotalCredit int64 `json:"total_credit"`
+}
+
+// Balanced tells whether the debits and credits of the trial balance agree
+func (tb TrialBalance) Balanced() bool {
+	return tb.TotalDebit == tb.TotalCredit
+}
+
+// GetTrialBalance lists every account of the chart of accounts with its
+// balance in currency from the entries posted before asOf
+func GetTrialBalance(ctx context.Context, q Querier, currency string, asOf time.Time) (TrialBalance, error) {
+
+	rows, err := q.GetTrialBalance(ctx, GetTrialBalanceParams{
+		Currency: currency,
+		AsOf:     asOf,
+	})
+	if err != nil {
+		return TrialBalance{}, err
+	}
+
+	tb := TrialBalance{
+		Currency: currency,
+		AsOf:     asOf,
+		Lines:    make([]TrialBalanceLine, 0, len(rows)),
+	}
+	for _, row := range rows {
+		line := TrialBalanceLine{GetTrialBalanceRow: row}
+		if row.Balance < 0 {
+			line.Debit = -row.Balance
+		} else {
+			line.Credit = row.Balance
+		}
+
+		tb.TotalDebit += line.Debit
+		tb.TotalCredit += line.Credit
+		tb.Lines = append(tb.Lines, line)
+	}
+
+	return tb, nil
+}
+
+// glAccount is the internal account of the chart of accounts code in currency
+func glAccount(ctx context.Context, q *Queries, code string, currency string) (Account, error) {
+	return q.GetInternalAccount(ctx, GetInternalAccountParams{
+		Name:     code,
+		Currency: currency,
+	})
+}
+
+// checkCustomerAccounts fails with ErrGLAccount when any of the accounts
+// belongs to the general ledger
+func checkCustomerAccounts(ctx context.Context, q *Queries, ids ...int64) error {
+
+	accounts, err := q.ListAccountsByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		if account.Kind == AccountKindGL {
+			return ErrGLAccount
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: general_ledger.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const getTrialBalance = `-- name: GetTrialBalance :many
+WITH balances AS (
+  SELECT CASE WHEN a.kind = 'customer' THEN 'customer_deposits' ELSE i.name END AS code,
+    SUM(e.amount) AS balance
+  FROM entries e
+  JOIN accounts a ON a.id = e.account_id
+  LEFT JOIN internal_accounts i ON i.account_id = a.id
+  WHERE a.currency = $1 AND e.created_at < $2
+  GROUP BY 1
+)
+SELECT c.code, c.number, c.name, c.category, COALESCE(b.balance, 0)::bigint AS balance
+FROM chart_of_accounts c
+LEFT JOIN balances b ON b.code = c.code
+ORDER BY c.number
+`
+
+type GetTrialBalanceParams struct {
+	Currency string    `json:"currency"`
+	AsOf     time.Time `json:"as_of"`
+}
+
+type GetTrialBalanceRow struct {
+	Code     string `json:"code"`
+	Number   string `json:"number"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Balance  int64  `json:"balance"`
+}
+
+// the balance of every account of the chart from the entries posted before
+// as_of; the customer accounts are summed into customer_deposits
+func (q *Queries) GetTrialBalance(ctx context.Context, arg GetTrialBalanceParams) ([]GetTrialBalanceRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTrialBalance, arg.Currency, arg.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTrialBalanceRow{}
+	for rows.Next() {
+		var i GetTrialBalanceRow
+		if err := rows.Scan(
+			&i.Code,
+			&i.Number,
+			&i.Name,
+			&i.Category,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChartOfAccounts = `-- name: ListChartOfAccounts :many
+SELECT code, number, name, category, created_at FROM chart_of_accounts ORDER BY number
+`
+
+func (q *Queries) ListChartOfAccounts(ctx context.Context) ([]ChartOfAccount, error) {
+	rows, err := q.db.QueryContext(ctx, listChartOfAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ChartOfAccount{}
+	for rows.Next() {
+		var i ChartOfAccount
+		if err := rows.Scan(
+			&i.Code,
+			&i.Number,
+			&i.Name,
+			&i.Category,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
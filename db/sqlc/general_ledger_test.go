@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrialBalance(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountLike(t, account1)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	tb, err := GetTrialBalance(context.Background(), store, account1.Currency, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, tb.Balanced(), "debits %d, credits %d", tb.TotalDebit, tb.TotalCredit)
+
+	lines := map[string]TrialBalanceLine{}
+	for _, line := range tb.Lines {
+		lines[line.Code] = line
+	}
+
+	// opening balances came in as cash and are owed to the customers
+	require.GreaterOrEqual(t, lines[CashAccount].Debit, account1.Balance+account2.Balance)
+	require.Zero(t, lines[CashAccount].Credit)
+	require.Equal(t, GLCategoryAsset, lines[CashAccount].Category)
+	require.GreaterOrEqual(t, lines[CustomerDepositsAccount].Credit, account1.Balance+account2.Balance)
+	require.Equal(t, GLCategoryLiability, lines[CustomerDepositsAccount].Category)
+	require.Contains(t, lines, FXSpreadAccount)
+	require.Contains(t, lines, RetainedEarningsAccount)
+
+	// nothing was posted before the bank opened
+	tb, err = GetTrialBalance(context.Background(), store, account1.Currency, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NotEmpty(t, tb.Lines)
+	require.Zero(t, tb.TotalDebit)
+	require.Zero(t, tb.TotalCredit)
+}
+
+func TestGLAccountsAreNotCustomerAccounts(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	customer := createRandomAccount(t)
+	cash, err := store.GetInternalAccount(context.Background(), GetInternalAccountParams{
+		Name:     CashAccount,
+		Currency: customer.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountKindGL, cash.Kind)
+	require.Equal(t, SystemOwner, cash.Owner)
+	require.Equal(t, AccountKindCustomer, customer.Kind)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: cash.ID,
+		ToAccountID:   customer.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrGLAccount)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: customer.ID,
+		ToAccountID:   cash.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrGLAccount)
+
+	accounts, err := store.ListAccounts(context.Background(), ListAccountsParams{
+		Username: SystemOwner,
+		Limit:    10,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	role, err := MemberRole(context.Background(), store, cash, SystemOwner)
+	require.NoError(t, err)
+	require.Empty(t, role)
+
+	_, err = ResolveRecipient(context.Background(), store, SystemOwner, customer.Currency)
+	require.ErrorIs(t, err, ErrRecipientNotFound)
+
+	_, err = store.CreateBeneficiary(context.Background(), CreateBeneficiaryParams{
+		Owner:     customer.Owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: cash.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,440 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: interest.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createInterestAccrual = `-- name: CreateInterestAccrual :execrows
+INSERT INTO interest_accruals (
+  account_id,
+  day,
+  balance,
+  annual_rate_bps,
+  day_count,
+  amount_micros
+)
+SELECT $1, $2, $3, $4, $5, $6
+WHERE NOT EXISTS (
+  SELECT 1 FROM interest_postings
+  WHERE account_id = $1 AND month = date_trunc('month', $2::date)::date
+)
+ON CONFLICT (account_id, day) DO NOTHING
+`
+
+type CreateInterestAccrualParams struct {
+	AccountID     int64     `json:"account_id"`
+	Day           time.Time `json:"day"`
+	Balance       int64     `json:"balance"`
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	DayCount      string    `json:"day_count"`
+	AmountMicros  int64     `json:"amount_micros"`
+}
+
+func (q *Queries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createInterestAccrual,
+		arg.AccountID,
+		arg.Day,
+		arg.Balance,
+		arg.AnnualRateBps,
+		arg.DayCount,
+		arg.AmountMicros,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createInterestPosting = `-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+  account_id,
+  month,
+  accrued_micros,
+  carried_in_micros,
+  amount,
+  carried_out_micros
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (account_id, month) DO NOTHING
+RETURNING id, account_id, month, accrued_micros, carried_in_micros, amount, carried_out_micros, from_entry_id, to_entry_id, created_at
+`
+
+type CreateInterestPostingParams struct {
+	AccountID        int64     `json:"account_id"`
+	Month            time.Time `json:"month"`
+	AccruedMicros    int64     `json:"accrued_micros"`
+	CarriedInMicros  int64     `json:"carried_in_micros"`
+	Amount           int64     `json:"amount"`
+	CarriedOutMicros int64     `json:"carried_out_micros"`
+}
+
+func (q *Queries) CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, createInterestPosting,
+		arg.AccountID,
+		arg.Month,
+		arg.AccruedMicros,
+		arg.CarriedInMicros,
+		arg.Amount,
+		arg.CarriedOutMicros,
+	)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Month,
+		&i.AccruedMicros,
+		&i.CarriedInMicros,
+		&i.Amount,
+		&i.CarriedOutMicros,
+		&i.FromEntryID,
+		&i.ToEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createInternalAccount = `-- name: CreateInternalAccount :one
+WITH created AS (
+  INSERT INTO accounts (owner, balance, currency, kind)
+  VALUES ('simplebank', 0, $2, 'gl')
+  RETURNING id
+)
+INSERT INTO internal_accounts (name, currency, account_id)
+SELECT $1, $2, id FROM created
+RETURNING name, currency, account_id
+`
+
+type CreateInternalAccountParams struct {
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) CreateInternalAccount(ctx context.Context, arg CreateInternalAccountParams) (InternalAccount, error) {
+	row := q.db.QueryRowContext(ctx, createInternalAccount, arg.Name, arg.Currency)
+	var i InternalAccount
+	err := row.Scan(&i.Name, &i.Currency, &i.AccountID)
+	return i, err
+}
+
+const getInterestPosting = `-- name: GetInterestPosting :one
+SELECT id, account_id, month, accrued_micros, carried_in_micros, amount, carried_out_micros, from_entry_id, to_entry_id, created_at FROM interest_postings WHERE account_id = $1 AND month = $2 LIMIT 1
+`
+
+type GetInterestPostingParams struct {
+	AccountID int64     `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+func (q *Queries) GetInterestPosting(ctx context.Context, arg GetInterestPostingParams) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, getInterestPosting, arg.AccountID, arg.Month)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Month,
+		&i.AccruedMicros,
+		&i.CarriedInMicros,
+		&i.Amount,
+		&i.CarriedOutMicros,
+		&i.FromEntryID,
+		&i.ToEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getInternalAccount = `-- name: GetInternalAccount :one
+SELECT a.id, a.owner, a.balance, a.currency, a.created_at, a.product, a.approval_threshold, a.kind FROM internal_accounts i
+JOIN accounts a ON a.id = i.account_id
+WHERE i.name = $1 AND i.currency = $2
+`
+
+type GetInternalAccountParams struct {
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetInternalAccount(ctx context.Context, arg GetInternalAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getInternalAccount, arg.Name, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Product,
+		&i.ApprovalThreshold,
+		&i.Kind,
+	)
+	return i, err
+}
+
+const getLastInterestAccrualDay = `-- name: GetLastInterestAccrualDay :one
+SELECT day FROM interest_accruals ORDER BY day DESC LIMIT 1
+`
+
+func (q *Queries) GetLastInterestAccrualDay(ctx context.Context) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getLastInterestAccrualDay)
+	var day time.Time
+	err := row.Scan(&day)
+	return day, err
+}
+
+const getLastInterestCarry = `-- name: GetLastInterestCarry :one
+SELECT COALESCE((
+  SELECT carried_out_micros FROM interest_postings
+  WHERE account_id = $1 AND month < $2
+  ORDER BY month DESC
+  LIMIT 1
+), 0)::bigint AS carried_out_micros
+`
+
+type GetLastInterestCarryParams struct {
+	AccountID int64     `json:"account_id"`
+	Month     time.Time `json:"month"`
+}
+
+func (q *Queries) GetLastInterestCarry(ctx context.Context, arg GetLastInterestCarryParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLastInterestCarry, arg.AccountID, arg.Month)
+	var carried_out_micros int64
+	err := row.Scan(&carried_out_micros)
+	return carried_out_micros, err
+}
+
+const getProduct = `-- name: GetProduct :one
+SELECT code, name, annual_rate_bps, day_count, created_at FROM products WHERE code = $1 LIMIT 1
+`
+
+func (q *Queries) GetProduct(ctx context.Context, code string) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProduct, code)
+	var i Product
+	err := row.Scan(
+		&i.Code,
+		&i.Name,
+		&i.AnnualRateBps,
+		&i.DayCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountsToPostInterest = `-- name: ListAccountsToPostInterest :many
+SELECT DISTINCT r.account_id FROM interest_accruals r
+WHERE r.day >= $1 AND r.day <= $2
+AND NOT EXISTS (
+  SELECT 1 FROM interest_postings p WHERE p.account_id = r.account_id AND p.month = $1
+)
+ORDER BY r.account_id
+`
+
+type ListAccountsToPostInterestParams struct {
+	FromDay time.Time `json:"from_day"`
+	ToDay   time.Time `json:"to_day"`
+}
+
+func (q *Queries) ListAccountsToPostInterest(ctx context.Context, arg ListAccountsToPostInterestParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsToPostInterest, arg.FromDay, arg.ToDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var account_id int64
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestAccruals = `-- name: ListInterestAccruals :many
+SELECT account_id, day, balance, annual_rate_bps, day_count, amount_micros, created_at FROM interest_accruals
+WHERE account_id = $1 AND day >= $2 AND day <= $3
+ORDER BY day
+`
+
+type ListInterestAccrualsParams struct {
+	AccountID int64     `json:"account_id"`
+	FromDay   time.Time `json:"from_day"`
+	ToDay     time.Time `json:"to_day"`
+}
+
+func (q *Queries) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestAccruals, arg.AccountID, arg.FromDay, arg.ToDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InterestAccrual{}
+	for rows.Next() {
+		var i InterestAccrual
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Day,
+			&i.Balance,
+			&i.AnnualRateBps,
+			&i.DayCount,
+			&i.AmountMicros,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestBearingBalances = `-- name: ListInterestBearingBalances :many
+SELECT a.id AS account_id, a.currency, p.annual_rate_bps, p.day_count,
+  (SELECT COALESCE(SUM(e.amount), 0) FROM entries e
+   WHERE e.account_id = a.id AND e.created_at < $1)::bigint AS balance
+FROM accounts a
+JOIN products p ON p.code = a.product
+WHERE p.annual_rate_bps > 0 AND a.created_at < $1 AND a.id > $2
+ORDER BY a.id
+LIMIT $3
+`
+
+type ListInterestBearingBalancesParams struct {
+	DayEnd     time.Time `json:"day_end"`
+	AfterID    int64     `json:"after_id"`
+	LimitCount int32     `json:"limit_count"`
+}
+
+type ListInterestBearingBalancesRow struct {
+	AccountID     int64  `json:"account_id"`
+	Currency      string `json:"currency"`
+	AnnualRateBps int32  `json:"annual_rate_bps"`
+	DayCount      string `json:"day_count"`
+	Balance       int64  `json:"balance"`
+}
+
+func (q *Queries) ListInterestBearingBalances(ctx context.Context, arg ListInterestBearingBalancesParams) ([]ListInterestBearingBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestBearingBalances, arg.DayEnd, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInterestBearingBalancesRow{}
+	for rows.Next() {
+		var i ListInterestBearingBalancesRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.Currency,
+			&i.AnnualRateBps,
+			&i.DayCount,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProducts = `-- name: ListProducts :many
+SELECT code, name, annual_rate_bps, day_count, created_at FROM products ORDER BY code
+`
+
+func (q *Queries) ListProducts(ctx context.Context) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProducts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.AnnualRateBps,
+			&i.DayCount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setInterestPostingEntries = `-- name: SetInterestPostingEntries :one
+UPDATE interest_postings SET from_entry_id = $2, to_entry_id = $3 WHERE id = $1 RETURNING id, account_id, month, accrued_micros, carried_in_micros, amount, carried_out_micros, from_entry_id, to_entry_id, created_at
+`
+
+type SetInterestPostingEntriesParams struct {
+	ID          int64         `json:"id"`
+	FromEntryID sql.NullInt64 `json:"from_entry_id"`
+	ToEntryID   sql.NullInt64 `json:"to_entry_id"`
+}
+
+func (q *Queries) SetInterestPostingEntries(ctx context.Context, arg SetInterestPostingEntriesParams) (InterestPosting, error) {
+	row := q.db.QueryRowContext(ctx, setInterestPostingEntries, arg.ID, arg.FromEntryID, arg.ToEntryID)
+	var i InterestPosting
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Month,
+		&i.AccruedMicros,
+		&i.CarriedInMicros,
+		&i.Amount,
+		&i.CarriedOutMicros,
+		&i.FromEntryID,
+		&i.ToEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const sumInterestAccruals = `-- name: SumInterestAccruals :one
+SELECT COALESCE(SUM(amount_micros), 0)::bigint AS amount_micros
+FROM interest_accruals
+WHERE account_id = $1 AND day >= $2 AND day <= $3
+`
+
+type SumInterestAccrualsParams struct {
+	AccountID int64     `json:"account_id"`
+	FromDay   time.Time `json:"from_day"`
+	ToDay     time.Time `json:"to_day"`
+}
+
+func (q *Queries) SumInterestAccruals(ctx context.Context, arg SumInterestAccrualsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumInterestAccruals, arg.AccountID, arg.FromDay, arg.ToDay)
+	var amount_micros int64
+	err := row.Scan(&amount_micros)
+	return amount_micros, err
+}
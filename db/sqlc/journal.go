@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// JournalLeg moves money in or out of one account: a positive amount
+// credits the account and a negative one debits it
+type JournalLeg struct {
+	AccountID int64
+	Amount    int64
+	// EntryType defaults to the type of the journal
+	EntryType       string
+	CorrectsEntryID sql.NullInt64
+}
+
+type PostJournalParams struct {
+	// JournalType is one of the entry types
+	JournalType string
+	Description string
+	// TransferID links the journal and its entries to a transfer
+	TransferID sql.NullInt64
+	Legs       []JournalLeg
+}
+
+type PostJournalResult struct {
+	Journal Journal
+	// Entries are in the order of the legs
+	Entries []Entry
+	// Accounts holds the accounts of the legs after posting, by ID
+	Accounts map[int64]Account
+}
+
+var (
+	// ErrJournalTooFewLegs is returned for a journal of less than two legs
+	ErrJournalTooFewLegs = errors.New("a journal needs at least two legs")
+	// ErrJournalZeroLeg is returned for a leg that moves no money
+	ErrJournalZeroLeg = errors.New("journal legs must move a non-zero amount")
+)
+
+// UnbalancedJournalError is returned when the legs of a journal do not sum
+// to zero in one of the currencies of their accounts
+type UnbalancedJournalError struct {
+	Currency string
+	Sum      int64
+}
+
+func (err *UnbalancedJournalError) Error() string {
+	return fmt.Sprintf("journal legs sum to %d %s instead of zero", err.Sum, err.Currency)
+}
+
+const insertJournal = `
+INSERT INTO journals (journal_type, description, transfer_id) VALUES ($1, $2, $3)
+RETURNING id, journal_type, description, transfer_id, created_at
+`
+
+// createJournal is not a sqlc query for the reason given in ledger.go
+func (q *Queries) createJournal(ctx context.Context, arg PostJournalParams) (Journal, error) {
+	row := q.db.QueryRowContext(ctx, insertJournal, arg.JournalType, arg.Description, arg.TransferID)
+	var i Journal
+	err := row.Scan(
+		&i.ID,
+		&i.JournalType,
+		&i.Description,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+// PostJournal posts a balanced journal in its own transaction
+func (store *SQLStore) PostJournal(ctx context.Context, arg PostJournalParams) (PostJournalResult, error) {
+
+	var result PostJournalResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = postJournal(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// postJournal writes a journal and one entry per leg using the caller's
+// transaction, and moves the balances of the accounts by the net of their
+// legs. The accounts are locked in ID order whatever the order of the legs,
+// so journals touching the same accounts never deadlock each other. The locks
+// are taken before any entry is written, so the entries of an account commit
+// in ID order; WatchAccount resumes after the last entry ID it sent and
+// relies on that.
+func postJournal(ctx context.Context, q *Queries, arg PostJournalParams) (result PostJournalResult, err error) {
+
+	if len(arg.Legs) < 2 {
+		return result, ErrJournalTooFewLegs
+	}
+
+	net := make(map[int64]int64, len(arg.Legs))
+	for _, leg := range arg.Legs {
+		if leg.Amount == 0 {
+			return result, ErrJournalZeroLeg
+		}
+		net[leg.AccountID] += leg.Amount
+	}
+
+	accountIDs := make([]int64, 0, len(net))
+	for id := range net {
+		accountIDs = append(accountIDs, id)
+	}
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+
+	result.Accounts = make(map[int64]Account, len(accountIDs))
+	sums := map[string]int64{}
+	for _, id := range accountIDs {
+		account, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return result, err
+		}
+		result.Accounts[id] = account
+		sums[account.Currency] += net[id]
+	}
+
+	currencies := make([]string, 0, len(sums))
+	for currency := range sums {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		if sums[currency] != 0 {
+			return result, &UnbalancedJournalError{Currency: currency, Sum: sums[currency]}
+		}
+	}
+
+	result.Journal, err = q.createJournal(ctx, arg)
+	if err != nil {
+		return
+	}
+	journalID := sql.NullInt64{Int64: result.Journal.ID, Valid: true}
+
+	result.Entries = make([]Entry, 0, len(arg.Legs))
+	for _, leg := range arg.Legs {
+		entryType := leg.EntryType
+		if entryType == "" {
+			entryType = arg.JournalType
+		}
+
+		entry, err := q.createEntry(ctx, createEntryParams{
+			AccountID:       leg.AccountID,
+			Amount:          leg.Amount,
+			EntryType:       entryType,
+			TransferID:      arg.TransferID,
+			CorrectsEntryID: leg.CorrectsEntryID,
+			JournalID:       journalID,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	for _, id := range accountIDs {
+		if net[id] == 0 {
+			continue
+		}
+
+		account, err := q.addAccountBalance(ctx, addAccountBalanceParams{
+			Amount: net[id],
+			ID:     id,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Accounts[id] = account
+	}
+
+	return result, nil
+}
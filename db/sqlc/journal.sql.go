@@ -0,0 +1,61 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: journal.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getJournal = `-- name: GetJournal :one
+SELECT id, journal_type, description, transfer_id, created_at FROM journals WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetJournal(ctx context.Context, id int64) (Journal, error) {
+	row := q.db.QueryRowContext(ctx, getJournal, id)
+	var i Journal
+	err := row.Scan(
+		&i.ID,
+		&i.JournalType,
+		&i.Description,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTransferJournals = `-- name: ListTransferJournals :many
+SELECT id, journal_type, description, transfer_id, created_at FROM journals WHERE transfer_id = $1 ORDER BY id
+`
+
+func (q *Queries) ListTransferJournals(ctx context.Context, transferID sql.NullInt64) ([]Journal, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferJournals, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Journal{}
+	for rows.Next() {
+		var i Journal
+		if err := rows.Scan(
+			&i.ID,
+			&i.JournalType,
+			&i.Description,
+			&i.TransferID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostJournal(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountLike(t, account1)
+	account3 := createRandomAccountLike(t, account1)
+
+	result, err := store.PostJournal(context.Background(), PostJournalParams{
+		JournalType: EntryTypeTransfer,
+		Description: "split bill",
+		Legs: []JournalLeg{
+			{AccountID: account1.ID, Amount: -30},
+			{AccountID: account2.ID, Amount: 20},
+			{AccountID: account3.ID, Amount: 10, EntryType: EntryTypeFee},
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, result.Journal.ID)
+	require.Equal(t, EntryTypeTransfer, result.Journal.JournalType)
+	require.Equal(t, "split bill", result.Journal.Description)
+
+	require.Len(t, result.Entries, 3)
+	require.Equal(t, EntryTypeTransfer, result.Entries[0].EntryType)
+	require.Equal(t, EntryTypeFee, result.Entries[2].EntryType)
+	for _, entry := range result.Entries {
+		require.Equal(t, sql.NullInt64{Int64: result.Journal.ID, Valid: true}, entry.JournalID)
+	}
+
+	require.Equal(t, account1.Balance-30, result.Accounts[account1.ID].Balance)
+	require.Equal(t, account2.Balance+20, result.Accounts[account2.ID].Balance)
+	require.Equal(t, account3.Balance+10, result.Accounts[account3.ID].Balance)
+
+	entries, err := store.ListJournalEntries(context.Background(), result.Entries[0].JournalID)
+	require.NoError(t, err)
+	require.Equal(t, result.Entries, entries)
+
+	_, err = store.PostJournal(context.Background(), PostJournalParams{
+		JournalType: EntryTypeTransfer,
+		Legs:        []JournalLeg{{AccountID: account1.ID, Amount: -1}},
+	})
+	require.ErrorIs(t, err, ErrJournalTooFewLegs)
+
+	_, err = store.PostJournal(context.Background(), PostJournalParams{
+		JournalType: EntryTypeTransfer,
+		Legs: []JournalLeg{
+			{AccountID: account1.ID, Amount: 0},
+			{AccountID: account2.ID, Amount: 0},
+		},
+	})
+	require.ErrorIs(t, err, ErrJournalZeroLeg)
+
+	// legs in different currencies never balance each other
+	currency := util.USD
+	if account1.Currency == util.USD {
+		currency = util.EUR
+	}
+	foreign, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    createRandomUser(t).Username,
+			Currency: currency,
+		},
+		OpeningBalance: 100,
+	})
+	require.NoError(t, err)
+
+	_, err = store.PostJournal(context.Background(), PostJournalParams{
+		JournalType: EntryTypeTransfer,
+		Legs: []JournalLeg{
+			{AccountID: account1.ID, Amount: -5},
+			{AccountID: foreign.ID, Amount: 5},
+		},
+	})
+	var unbalanced *UnbalancedJournalError
+	require.ErrorAs(t, err, &unbalanced)
+	require.Equal(t, int64(5), unbalanced.Sum)
+
+	// nothing of a rejected journal is written
+	account, err := store.GetAccount(context.Background(), foreign.ID)
+	require.NoError(t, err)
+	require.Equal(t, foreign.Balance, account.Balance)
+}
+
+func TestTransferTxPostsOneJournal(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountLike(t, account1)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.True(t, result.FromEntry.JournalID.Valid)
+	require.Equal(t, result.FromEntry.JournalID, result.ToEntry.JournalID)
+
+	journals, err := store.ListTransferJournals(context.Background(), sql.NullInt64{Int64: result.Transfer.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, journals, 1)
+	require.Equal(t, EntryTypeTransfer, journals[0].JournalType)
+
+	entries, err := store.ListJournalEntries(context.Background(), result.FromEntry.JournalID)
+	require.NoError(t, err)
+
+	var sum int64
+	for _, entry := range entries {
+		sum += entry.Amount
+	}
+	require.Zero(t, sum)
+}
@@ -0,0 +1,303 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: kyc.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createKycDocument = `-- name: CreateKycDocument :one
+INSERT INTO kyc_documents (
+  username,
+  kind,
+  file_name,
+  content_type,
+  size_bytes,
+  sha256,
+  storage_key
+) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, username, kind, file_name, content_type, size_bytes, sha256, storage_key, status, reviewed_by, review_note, reviewed_at, created_at
+`
+
+type CreateKycDocumentParams struct {
+	Username    string `json:"username"`
+	Kind        string `json:"kind"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	Sha256      string `json:"sha256"`
+	StorageKey  string `json:"storage_key"`
+}
+
+func (q *Queries) CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error) {
+	row := q.db.QueryRowContext(ctx, createKycDocument,
+		arg.Username,
+		arg.Kind,
+		arg.FileName,
+		arg.ContentType,
+		arg.SizeBytes,
+		arg.Sha256,
+		arg.StorageKey,
+	)
+	var i KycDocument
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Kind,
+		&i.FileName,
+		&i.ContentType,
+		&i.SizeBytes,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getKycDocumentForUpdate = `-- name: GetKycDocumentForUpdate :one
+SELECT id, username, kind, file_name, content_type, size_bytes, sha256, storage_key, status, reviewed_by, review_note, reviewed_at, created_at FROM kyc_documents WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetKycDocumentForUpdate(ctx context.Context, id int64) (KycDocument, error) {
+	row := q.db.QueryRowContext(ctx, getKycDocumentForUpdate, id)
+	var i KycDocument
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Kind,
+		&i.FileName,
+		&i.ContentType,
+		&i.SizeBytes,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserKyc = `-- name: GetUserKyc :one
+SELECT u.username, u.kyc_status, u.kyc_tier, EXISTS (
+  SELECT 1 FROM user_aliases ua
+  WHERE ua.kind = 'email' AND ua.value = lower(u.email) AND ua.username = u.username AND ua.verified_at IS NOT NULL
+) AS email_verified
+FROM users u
+WHERE u.username = $1
+`
+
+type GetUserKycRow struct {
+	Username      string `json:"username"`
+	KycStatus     string `json:"kyc_status"`
+	KycTier       int32  `json:"kyc_tier"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// the tier only counts once the email the user signed up with is verified
+func (q *Queries) GetUserKyc(ctx context.Context, username string) (GetUserKycRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserKyc, username)
+	var i GetUserKycRow
+	err := row.Scan(
+		&i.Username,
+		&i.KycStatus,
+		&i.KycTier,
+		&i.EmailVerified,
+	)
+	return i, err
+}
+
+const getUserKycForUpdate = `-- name: GetUserKycForUpdate :one
+SELECT u.username, u.kyc_status, u.kyc_tier, EXISTS (
+  SELECT 1 FROM user_aliases ua
+  WHERE ua.kind = 'email' AND ua.value = lower(u.email) AND ua.username = u.username AND ua.verified_at IS NOT NULL
+) AS email_verified
+FROM users u
+WHERE u.username = $1
+FOR NO KEY UPDATE OF u
+`
+
+type GetUserKycForUpdateRow struct {
+	Username      string `json:"username"`
+	KycStatus     string `json:"kyc_status"`
+	KycTier       int32  `json:"kyc_tier"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+func (q *Queries) GetUserKycForUpdate(ctx context.Context, username string) (GetUserKycForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserKycForUpdate, username)
+	var i GetUserKycForUpdateRow
+	err := row.Scan(
+		&i.Username,
+		&i.KycStatus,
+		&i.KycTier,
+		&i.EmailVerified,
+	)
+	return i, err
+}
+
+const listKycDocuments = `-- name: ListKycDocuments :many
+SELECT id, username, kind, file_name, content_type, size_bytes, sha256, storage_key, status, reviewed_by, review_note, reviewed_at, created_at FROM kyc_documents WHERE username = $1 ORDER BY id DESC
+`
+
+func (q *Queries) ListKycDocuments(ctx context.Context, username string) ([]KycDocument, error) {
+	rows, err := q.db.QueryContext(ctx, listKycDocuments, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KycDocument{}
+	for rows.Next() {
+		var i KycDocument
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Kind,
+			&i.FileName,
+			&i.ContentType,
+			&i.SizeBytes,
+			&i.Sha256,
+			&i.StorageKey,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingKycDocuments = `-- name: ListPendingKycDocuments :many
+SELECT id, username, kind, file_name, content_type, size_bytes, sha256, storage_key, status, reviewed_by, review_note, reviewed_at, created_at FROM kyc_documents
+WHERE status = 'pending'
+ORDER BY id
+LIMIT $2 OFFSET $1
+`
+
+type ListPendingKycDocumentsParams struct {
+	Offset int32 `json:"offset"`
+	Limit  int32 `json:"limit"`
+}
+
+func (q *Queries) ListPendingKycDocuments(ctx context.Context, arg ListPendingKycDocumentsParams) ([]KycDocument, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingKycDocuments, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KycDocument{}
+	for rows.Next() {
+		var i KycDocument
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Kind,
+			&i.FileName,
+			&i.ContentType,
+			&i.SizeBytes,
+			&i.Sha256,
+			&i.StorageKey,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewKycDocument = `-- name: ReviewKycDocument :one
+UPDATE kyc_documents
+SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = now()
+WHERE id = $1
+RETURNING id, username, kind, file_name, content_type, size_bytes, sha256, storage_key, status, reviewed_by, review_note, reviewed_at, created_at
+`
+
+type ReviewKycDocumentParams struct {
+	ID         int64          `json:"id"`
+	Status     string         `json:"status"`
+	ReviewedBy sql.NullString `json:"reviewed_by"`
+	ReviewNote string         `json:"review_note"`
+}
+
+func (q *Queries) ReviewKycDocument(ctx context.Context, arg ReviewKycDocumentParams) (KycDocument, error) {
+	row := q.db.QueryRowContext(ctx, reviewKycDocument,
+		arg.ID,
+		arg.Status,
+		arg.ReviewedBy,
+		arg.ReviewNote,
+	)
+	var i KycDocument
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Kind,
+		&i.FileName,
+		&i.ContentType,
+		&i.SizeBytes,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateUserKyc = `-- name: UpdateUserKyc :one
+UPDATE users SET kyc_status = $2, kyc_tier = $3 WHERE username = $1 RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, limit_profile, kyc_status, kyc_tier
+`
+
+type UpdateUserKycParams struct {
+	Username  string `json:"username"`
+	KycStatus string `json:"kyc_status"`
+	KycTier   int32  `json:"kyc_tier"`
+}
+
+func (q *Queries) UpdateUserKyc(ctx context.Context, arg UpdateUserKycParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserKyc, arg.Username, arg.KycStatus, arg.KycTier)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.LimitProfile,
+		&i.KycStatus,
+		&i.KycTier,
+	)
+	return i, err
+}
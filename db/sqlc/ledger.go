@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// The ledger writes below are deliberately not sqlc queries: generated queries
+// end up on Querier, and so on every Store. Entries and balances must only
+// change together, inside the transaction functions of this package.
+
+// Types of entries
+const (
+	EntryTypeTransfer   = "transfer"
+	EntryTypeFee        = "fee"
+	EntryTypeInterest   = "interest"
+	EntryTypeAdjustment = "adjustment"
+	EntryTypeReversal   = "reversal"
+	EntryTypeFX         = "fx"
+)
+
+const insertEntry = `
+INSERT INTO entries (account_id, amount, entry_type, transfer_id, corrects_entry_id, journal_id) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, account_id, amount, created_at, corrects_entry_id, transfer_id, entry_type, journal_id
+`
+
+type createEntryParams struct {
+	AccountID int64
+	Amount    int64
+	EntryType string
+	// TransferID is set on transfer and fee entries
+	TransferID      sql.NullInt64
+	CorrectsEntryID sql.NullInt64
+	// JournalID is set on entries posted by postJournal
+	JournalID sql.NullInt64
+}
+
+func (q *Queries) createEntry(ctx context.Context, arg createEntryParams) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, insertEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.EntryType,
+		arg.TransferID,
+		arg.CorrectsEntryID,
+		arg.JournalID,
+	)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.CorrectsEntryID,
+		&i.TransferID,
+		&i.EntryType,
+		&i.JournalID,
+	)
+	return i, err
+}
+
+const addBalance = `
+UPDATE accounts SET balance = balance + $1 WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, product, approval_threshold, kind
+`
+
+type addAccountBalanceParams struct {
+	Amount int64
+	ID     int64
+}
+
+func (q *Queries) addAccountBalance(ctx context.Context, arg addAccountBalanceParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, addBalance, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Product,
+		&i.ApprovalThreshold,
+		&i.Kind,
+	)
+	return i, err
+}
+
+var (
+	// ErrEntryIsCorrection is returned when a correcting entry is corrected;
+	// correct the original entry instead
+	ErrEntryIsCorrection = errors.New("entry is a correction, correct the original entry")
+	// ErrNothingToCorrect is returned when an entry already has the amount
+	ErrNothingToCorrect = errors.New("entry already has this amount")
+	// ErrCorrectAdjustment is returned for entries of the adjustments account,
+	// which takes the other side of every correction
+	ErrCorrectAdjustment = errors.New("entries of the adjustments account cannot be corrected")
+)
+
+type CorrectEntryTxParams struct {
+	EntryID int64
+	// Amount is what the entry should have been
+	Amount int64
+}
+
+type CorrectEntryTxResult struct {
+	Original   Entry
+	Correction Entry
+	Account    Account
+}
+
+// CorrectEntryTx fixes the amount of an entry by posting a correcting entry
+// for the difference, balanced against the adjustments account of the
+// general ledger. The account row is locked first, so corrections of one
+// entry are applied in turn.
+func (store *SQLStore) CorrectEntryTx(ctx context.Context, arg CorrectEntryTxParams) (CorrectEntryTxResult, error) {
+
+	var result CorrectEntryTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Original, err = q.GetEntry(ctx, arg.EntryID)
+		if err != nil {
+			return err
+		}
+
+		if result.Original.CorrectsEntryID.Valid {
+			return ErrEntryIsCorrection
+		}
+
+		account, err := q.GetAccountForUpdate(ctx, result.Original.AccountID)
+		if err != nil {
+			return err
+		}
+
+		current, err := q.GetCorrectedEntryAmount(ctx, arg.EntryID)
+		if err != nil {
+			return err
+		}
+
+		difference := arg.Amount - current
+		if difference == 0 {
+			return ErrNothingToCorrect
+		}
+
+		adjustments, err := glAccount(ctx, q, AdjustmentsAccount, account.Currency)
+		if err != nil {
+			return err
+		}
+		if adjustments.ID == account.ID {
+			return ErrCorrectAdjustment
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			JournalType: EntryTypeAdjustment,
+			Description: fmt.Sprintf("correction of entry %d", arg.EntryID),
+			Legs: []JournalLeg{
+				{
+					AccountID:       account.ID,
+					Amount:          difference,
+					CorrectsEntryID: sql.NullInt64{Int64: arg.EntryID, Valid: true},
+				},
+				{AccountID: adjustments.ID, Amount: -difference},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Correction = journal.Entries[0]
+		result.Account = journal.Accounts[account.ID]
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerIsAppendOnly(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountLike(t, account1)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	for _, statement := range []struct {
+		query string
+		id    int64
+	}{
+		{"UPDATE entries SET amount = 0 WHERE id = $1", result.FromEntry.ID},
+		{"DELETE FROM entries WHERE id = $1", result.ToEntry.ID},
+		{"UPDATE transfers SET amount = 0 WHERE id = $1", result.Transfer.ID},
+		{"DELETE FROM transfers WHERE id = $1", result.Transfer.ID},
+	} {
+		_, err = testDB.Exec(statement.query, statement.id)
+
+		var pqErr *pq.Error
+		require.ErrorAs(t, err, &pqErr, statement.query)
+		require.Equal(t, "restrict_violation", pqErr.Code.Name())
+	}
+
+	entry, err := store.GetEntry(context.Background(), result.FromEntry.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.FromEntry, entry)
+}
+
+func TestCorrectEntryTx(t *testing.T) {
+
+	store := NewStore(testDB)
+	account, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    createRandomUser(t).Username,
+			Currency: util.USD,
+		},
+		OpeningBalance: 100,
+	})
+	require.NoError(t, err)
+
+	entries, err := store.ListAccountEntriesAfter(context.Background(), ListAccountEntriesAfterParams{
+		AccountID:  account.ID,
+		AfterID:    0,
+		LimitCount: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	opening := entries[0]
+
+	result, err := store.CorrectEntryTx(context.Background(), CorrectEntryTxParams{
+		EntryID: opening.ID,
+		Amount:  opening.Amount + 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, opening, result.Original)
+	require.Equal(t, int64(5), result.Correction.Amount)
+	require.Equal(t, EntryTypeAdjustment, result.Correction.EntryType)
+	require.Equal(t, sql.NullInt64{Int64: opening.ID, Valid: true}, result.Correction.CorrectsEntryID)
+	require.Equal(t, account.Balance+5, result.Account.Balance)
+
+	// the other side of the correction is on the adjustments account
+	journal, err := store.ListJournalEntries(context.Background(), result.Correction.JournalID)
+	require.NoError(t, err)
+	require.Len(t, journal, 2)
+	require.Equal(t, int64(-5), journal[1].Amount)
+
+	// corrections are applied on top of earlier ones
+	result, err = store.CorrectEntryTx(context.Background(), CorrectEntryTxParams{
+		EntryID: opening.ID,
+		Amount:  opening.Amount - 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-8), result.Correction.Amount)
+	require.Equal(t, account.Balance-3, result.Account.Balance)
+
+	_, err = store.CorrectEntryTx(context.Background(), CorrectEntryTxParams{EntryID: opening.ID, Amount: opening.Amount - 3})
+	require.ErrorIs(t, err, ErrNothingToCorrect)
+
+	_, err = store.CorrectEntryTx(context.Background(), CorrectEntryTxParams{EntryID: result.Correction.ID, Amount: 0})
+	require.ErrorIs(t, err, ErrEntryIsCorrection)
+
+	_, err = store.CorrectEntryTx(context.Background(), CorrectEntryTxParams{EntryID: -1, Amount: 1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// the account still reconciles
+	unbalanced, err := store.ListUnbalancedAccounts(context.Background())
+	require.NoError(t, err)
+	for _, row := range unbalanced {
+		require.NotEqual(t, account.ID, row.ID)
+	}
+}
+
+func TestListAccountStatement(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountLike(t, account1)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	owner2, err := store.GetUser(context.Background(), account2.Owner)
+	require.NoError(t, err)
+
+	rows, err := store.ListAccountStatement(context.Background(), ListAccountStatementParams{
+		AccountID: account1.ID,
+		FromTime:  time.Now().Add(-time.Hour),
+		ToTime:    time.Now().Add(time.Hour),
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+
+	// newest first, with the opening balance last
+	require.Equal(t, EntryTypeAdjustment, rows[len(rows)-1].EntryType)
+	for i := 1; i < len(rows); i++ {
+		require.Greater(t, rows[i-1].ID, rows[i].ID)
+	}
+
+	var transferRows int
+	for _, row := range rows {
+		require.Equal(t, account1.ID, row.AccountID)
+
+		if row.EntryType != EntryTypeTransfer {
+			require.False(t, row.CounterpartyAccountID.Valid)
+			continue
+		}
+
+		transferRows++
+		require.Equal(t, result.FromEntry.ID, row.ID)
+		require.Equal(t, result.Transfer.ID, row.TransferID.Int64)
+		require.Equal(t, account2.ID, row.CounterpartyAccountID.Int64)
+		require.Equal(t, account2.Owner, row.CounterpartyOwner.String)
+		require.Equal(t, owner2.FullName, row.CounterpartyName.String)
+	}
+	require.Equal(t, 1, transferRows)
+
+	// transfer entries cannot lose the link to their transfer
+	_, err = testDB.Exec("INSERT INTO entries (account_id, amount, entry_type) VALUES ($1, 1, 'transfer')", account1.ID)
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "check_violation", string(pqErr.Code.Name()))
+}
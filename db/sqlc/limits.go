@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Transfer limit periods
+const (
+	LimitPerTransaction = "per_transaction"
+	LimitDaily          = "daily"
+	LimitMonthly        = "monthly"
+)
+
+// LimitProfileTier0 is the limit profile of users who did not complete KYC
+const LimitProfileTier0 = "tier0"
+
+// LimitExceededError is returned when a transfer would go over one of the
+// limits of the sender's limit profile
+type LimitExceededError struct {
+	Period    string `json:"period"`
+	Currency  string `json:"currency"`
+	Limit     int64  `json:"limit"`
+	Used      int64  `json:"used"`
+	Remaining int64  `json:"remaining"`
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s transfer limit of %d %s exceeded: used %d, remaining %d",
+		e.Period, e.Limit, e.Currency, e.Used, e.Remaining)
+}
+
+// checkTransferLimits records the amount against the sender's usage and fails
+// if it goes over a limit. It runs in the transfer transaction: the usage row
+// stays locked until commit, so concurrent transfers of the same user are
+// counted one after the other, and a failed transfer leaves no usage behind.
+// A currency without limits in the profile has none, except in the tier0
+// profile: users who did not complete KYC cannot send it at all.
+func checkTransferLimits(ctx context.Context, q *Queries, fromAccountID int64, amount int64, now time.Time) error {
+
+	row, err := q.GetAccountTransferLimit(ctx, fromAccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if !row.PerTransaction.Valid {
+		if row.Profile != LimitProfileTier0 {
+			return nil
+		}
+		return &LimitExceededError{
+			Period:   LimitPerTransaction,
+			Currency: row.Currency,
+		}
+	}
+
+	limit := TransferLimit{
+		Profile:        row.Profile,
+		Currency:       row.Currency,
+		PerTransaction: row.PerTransaction.Int64,
+		Daily:          row.Daily.Int64,
+		Monthly:        row.Monthly.Int64,
+	}
+
+	exceeded := func(period string, max int64, used int64) error {
+		remaining := max - used
+		if remaining < 0 {
+			// the profile was lowered after the usage was recorded
+			remaining = 0
+		}
+		return &LimitExceededError{
+			Period:    period,
+			Currency:  limit.Currency,
+			Limit:     max,
+			Used:      used,
+			Remaining: remaining,
+		}
+	}
+
+	if amount > limit.PerTransaction {
+		return exceeded(LimitPerTransaction, limit.PerTransaction, 0)
+	}
+
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	usage, err := q.AddTransferUsage(ctx, AddTransferUsageParams{
+		Username: row.Username,
+		Currency: limit.Currency,
+		Day:      today,
+		Amount:   amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	if usage.Amount > limit.Daily {
+		return exceeded(LimitDaily, limit.Daily, usage.Amount-amount)
+	}
+
+	monthly, err := q.GetTransferUsageBetween(ctx, GetTransferUsageBetweenParams{
+		Username: row.Username,
+		Currency: limit.Currency,
+		FromDay:  today.AddDate(0, 0, 1-today.Day()),
+		ToDay:    today,
+	})
+	if err != nil {
+		return err
+	}
+
+	if monthly > limit.Monthly {
+		return exceeded(LimitMonthly, limit.Monthly, monthly-amount)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxLimits(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountLike(t, account1)
+
+	// users who did not complete KYC get the tier0 limits
+	limit, err := testQueries.GetAccountTransferLimit(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, "tier0", limit.Profile)
+
+	verifyKyc(t, account1.Owner, KycTierBasic)
+
+	limit, err = testQueries.GetAccountTransferLimit(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, "standard", limit.Profile)
+	require.Equal(t, account1.Owner, limit.Username)
+	require.True(t, limit.PerTransaction.Valid)
+
+	transfer := func(amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	var limitErr *LimitExceededError
+	err = transfer(limit.PerTransaction.Int64 + 1)
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, LimitPerTransaction, limitErr.Period)
+	require.Equal(t, limit.PerTransaction.Int64, limitErr.Limit)
+
+	var used int64
+	for used+limit.PerTransaction.Int64 <= limit.Daily.Int64 {
+		require.NoError(t, transfer(limit.PerTransaction.Int64))
+		used += limit.PerTransaction.Int64
+	}
+
+	err = transfer(limit.Daily.Int64 - used + 1)
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, &LimitExceededError{
+		Period:    LimitDaily,
+		Currency:  account1.Currency,
+		Limit:     limit.Daily.Int64,
+		Used:      used,
+		Remaining: limit.Daily.Int64 - used,
+	}, limitErr)
+
+	// the rejected transfer is not counted
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	total, err := testQueries.GetTransferUsageBetween(context.Background(), GetTransferUsageBetweenParams{
+		Username: account1.Owner,
+		Currency: account1.Currency,
+		FromDay:  today,
+		ToDay:    today,
+	})
+	require.NoError(t, err)
+	require.Equal(t, used, total)
+
+	_, err = testQueries.UpdateUserLimitProfile(context.Background(), UpdateUserLimitProfileParams{
+		Username:     account1.Owner,
+		LimitProfile: "premium",
+	})
+	require.NoError(t, err)
+	require.NoError(t, transfer(limit.PerTransaction.Int64+1))
+}
+
+func TestTransferTxTier0WithoutLimits(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	// a currency that has no tier0 limits, like one enabled without them
+	_, err := testQueries.UpsertCurrency(context.Background(), UpsertCurrencyParams{
+		Code:       "XTS",
+		Name:       "Testing currency",
+		MinorUnits: 2,
+		Symbol:     "XTS ",
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+
+	accounts := make([]Account, 2)
+	for i := range accounts {
+		accounts[i], err = store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+			CreateAccountParams: CreateAccountParams{
+				Owner:    createRandomUser(t).Username,
+				Currency: "XTS",
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	limit, err := testQueries.GetAccountTransferLimit(context.Background(), accounts[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, LimitProfileTier0, limit.Profile)
+	require.False(t, limit.PerTransaction.Valid)
+
+	// users who did not complete KYC cannot send a currency without tier0 limits
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: accounts[0].ID,
+		ToAccountID:   accounts[1].ID,
+		Amount:        1,
+	})
+	var limitErr *LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, &LimitExceededError{Period: LimitPerTransaction, Currency: "XTS"}, limitErr)
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Roles of account members
+const (
+	MemberRoleOwner  = "owner"
+	MemberRoleSigner = "signer"
+	MemberRoleViewer = "viewer"
+)
+
+// Statuses of account members
+const (
+	MemberStatusInvited = "invited"
+	MemberStatusActive  = "active"
+)
+
+// ErrNotSigner is returned when a user who may not move money decides on a transfer
+var ErrNotSigner = errors.New("user is not a signer of the account")
+
+// CanSign reports whether a member with the given role may move money
+func CanSign(role string) bool {
+	return role == MemberRoleOwner || role == MemberRoleSigner
+}
+
+// MemberRole returns the role username has on account, or an empty string
+// when the user is not an active member. The primary owner is always an
+// owner member, so it is answered without a query. Nobody is a member of a
+// general ledger account.
+func MemberRole(ctx context.Context, q Querier, account Account, username string) (string, error) {
+
+	if account.Kind == AccountKindGL {
+		return "", nil
+	}
+
+	if account.Owner == username {
+		return MemberRoleOwner, nil
+	}
+
+	return memberRole(ctx, q, account.ID, username)
+}
+
+func memberRole(ctx context.Context, q Querier, accountID int64, username string) (string, error) {
+
+	member, err := q.GetAccountMember(ctx, GetAccountMemberParams{
+		AccountID: accountID,
+		Username:  username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if member.Status != MemberStatusActive {
+		return "", nil
+	}
+	return member.Role, nil
+}
@@ -5,6 +5,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,88 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	Product   string    `json:"product"`
+	// transfers of at least this amount need a second signer when the account has one; 0 turns approvals off
+	ApprovalThreshold int64 `json:"approval_threshold"`
+	// customer, or gl for the internal accounts of the general ledger, which customers can never see or move money from
+	Kind string `json:"kind"`
+}
+
+type AccountMember struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	// owner: manages members and moves money, signer: moves money, viewer: read only
+	Role string `json:"role"`
+	// invited until the user accepts the invitation
+	Status     string         `json:"status"`
+	InvitedBy  sql.NullString `json:"invited_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+	AcceptedAt sql.NullTime   `json:"accepted_at"`
+}
+
+type BatchTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	// all_or_nothing or best_effort
+	Mode           string         `json:"mode"`
+	Status         string         `json:"status"`
+	TotalCount     int32          `json:"total_count"`
+	TotalAmount    int64          `json:"total_amount"`
+	SucceededCount int32          `json:"succeeded_count"`
+	FailedCount    int32          `json:"failed_count"`
+	IdempotencyKey sql.NullString `json:"idempotency_key"`
+	CreatedAt      time.Time      `json:"created_at"`
+	CompletedAt    sql.NullTime   `json:"completed_at"`
+}
+
+type BatchTransferItem struct {
+	ID          int64  `json:"id"`
+	BatchID     int64  `json:"batch_id"`
+	RowNumber   int32  `json:"row_number"`
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Reference   string `json:"reference"`
+	Status      string `json:"status"`
+	// set in the same transaction as the transfer, so a row is never paid twice
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	Error       string        `json:"error"`
+	ProcessedAt sql.NullTime  `json:"processed_at"`
+	// screening of the row when the batch was created; rows the screener did not allow are failed and never paid
+	RiskDecisionID sql.NullInt64 `json:"risk_decision_id"`
+}
+
+// accounts a user saved under a nickname to send money to
+type Beneficiary struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	Nickname  string    `json:"nickname"`
+	AccountID int64     `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// the general ledger; every currency has one internal account per code, except customer_deposits which is the sum of the customer accounts
+type ChartOfAccount struct {
+	Code string `json:"code"`
+	// orders the accounts in reports: 1 assets, 2 liabilities, 3 equity, 4 income, 5 expenses
+	Number    string    `json:"number"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Currency struct {
+	// ISO 4217 alphabetic code
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// ISO 4217 exponent: amounts are stored in units of 10^-minor_units
+	MinorUnits int16  `json:"minor_units"`
+	Symbol     string `json:"symbol"`
+	// only enabled currencies are accepted by the APIs, which load the list at startup
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	// transfers of at least this amount, in minor units, to a new payee are held for review; 0 turns the rule off
+	RiskLargeAmount int64 `json:"risk_large_amount"`
 }
 
 type Entry struct {
@@ -24,6 +108,187 @@ type Entry struct {
 	// it can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// set on correcting entries; entries are never updated, a mistake is fixed by posting the difference
+	CorrectsEntryID sql.NullInt64 `json:"corrects_entry_id"`
+	// the transfer the entry was written for, set on transfer and fee entries
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	EntryType  string        `json:"entry_type"`
+	// the journal the entry was posted in; single-sided adjustments have none
+	JournalID sql.NullInt64 `json:"journal_id"`
+}
+
+// fees charged to the sender of a transfer, by product and currency of the sending account
+type FeeSchedule struct {
+	ID          int64  `json:"id"`
+	Product     string `json:"product"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	MinFee      int64  `json:"min_fee"`
+	// no cap when null
+	MaxFee    sql.NullInt64 `json:"max_fee"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// the tier with the highest from_amount not above the transfer amount applies
+type FeeTier struct {
+	ScheduleID int64 `json:"schedule_id"`
+	FromAmount int64 `json:"from_amount"`
+	FlatAmount int64 `json:"flat_amount"`
+	// percentage of the transfer amount in basis points, 50 is 0.5%
+	PercentBps int32 `json:"percent_bps"`
+}
+
+type InterestAccrual struct {
+	AccountID     int64     `json:"account_id"`
+	Day           time.Time `json:"day"`
+	Balance       int64     `json:"balance"`
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	DayCount      string    `json:"day_count"`
+	// interest of the day in millionths of the minor unit
+	AmountMicros int64     `json:"amount_micros"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the interest was accrued in
+	Month           time.Time `json:"month"`
+	AccruedMicros   int64     `json:"accrued_micros"`
+	CarriedInMicros int64     `json:"carried_in_micros"`
+	Amount          int64     `json:"amount"`
+	// the part below one minor unit, added to the next posting
+	CarriedOutMicros int64         `json:"carried_out_micros"`
+	FromEntryID      sql.NullInt64 `json:"from_entry_id"`
+	ToEntryID        sql.NullInt64 `json:"to_entry_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type InternalAccount struct {
+	Name      string `json:"name"`
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"account_id"`
+}
+
+// balanced postings; the entries of a journal sum to zero per currency
+type Journal struct {
+	ID          int64         `json:"id"`
+	JournalType string        `json:"journal_type"`
+	Description string        `json:"description"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type KycDocument struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	Kind        string `json:"kind"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	Sha256      string `json:"sha256"`
+	// where the file is kept in the document storage
+	StorageKey string         `json:"storage_key"`
+	Status     string         `json:"status"`
+	ReviewedBy sql.NullString `json:"reviewed_by"`
+	ReviewNote string         `json:"review_note"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type LimitProfile struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Notification struct {
+	ID        int64           `json:"id"`
+	Username  string          `json:"username"`
+	EventType string          `json:"event_type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data"`
+	// shown in the inbox; every notification is stored so a redelivered event is not sent twice
+	InApp     bool         `json:"in_app"`
+	DedupKey  string       `json:"dedup_key"`
+	ReadAt    sql.NullTime `json:"read_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// overrides of the default channels, which are in_app and email
+type NotificationPreference struct {
+	Username  string    `json:"username"`
+	EventType string    `json:"event_type"`
+	Channel   string    `json:"channel"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Outbox struct {
+	ID        int64  `json:"id"`
+	EventType string `json:"event_type"`
+	// passed to every sink so redelivery is idempotent
+	DedupKey    string          `json:"dedup_key"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int32           `json:"attempts"`
+	LastError   string          `json:"last_error"`
+	AvailableAt time.Time       `json:"available_at"`
+	PublishedAt sql.NullTime    `json:"published_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	// W3C trace context of the transaction that wrote the event
+	TraceContext json.RawMessage `json:"trace_context"`
+}
+
+type PaymentRequest struct {
+	ID        int64  `json:"id"`
+	Requester string `json:"requester"`
+	Payer     string `json:"payer"`
+	// account of the requester the money is paid into
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+	Memo        string `json:"memo"`
+	// a pending request past expires_at can no longer be paid
+	Status     string        `json:"status"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	SettledAt  sql.NullTime  `json:"settled_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type Product struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// annual interest rate in basis points, 250 is 2.5%
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	DayCount      string    `json:"day_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type RiskDecision struct {
+	ID            int64  `json:"id"`
+	Username      string `json:"username"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	UserAgent     string `json:"user_agent"`
+	ClientIp      string `json:"client_ip"`
+	// allow, review or block
+	Decision string `json:"decision"`
+	// the rules that matched, with their decision and reason
+	Hits json.RawMessage `json:"hits"`
+	// pending, approved or rejected; only set for the review decision. refused when the transfer could not wait for a review
+	ReviewStatus sql.NullString  `json:"review_status"`
+	TransferID   sql.NullInt64   `json:"transfer_id"`
+	ReviewedBy   sql.NullString  `json:"reviewed_by"`
+	ReviewNote   string          `json:"review_note"`
+	ReviewedAt   sql.NullTime    `json:"reviewed_at"`
+	CreatedAt    time.Time       `json:"created_at"`
+	Memo         string          `json:"memo"`
+	Reference    string          `json:"reference"`
+	Metadata     json.RawMessage `json:"metadata"`
 }
 
 type Session struct {
@@ -44,6 +309,61 @@ type Transfer struct {
 	// it must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	Fee       int64     `json:"fee"`
+	Memo      string    `json:"memo"`
+	// set by the client to match the transfer with its own records
+	Reference string `json:"reference"`
+	// string keys and values set by the client
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+type TransferActivity struct {
+	Kind          string          `json:"kind"`
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Fee           int64           `json:"fee"`
+	Currency      string          `json:"currency"`
+	Memo          string          `json:"memo"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Status        string          `json:"status"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// transfers of joint accounts waiting for a second signer
+type TransferApproval struct {
+	ID             int64          `json:"id"`
+	FromAccountID  int64          `json:"from_account_id"`
+	ToAccountID    int64          `json:"to_account_id"`
+	Amount         int64          `json:"amount"`
+	Currency       string         `json:"currency"`
+	RequestedBy    string         `json:"requested_by"`
+	RiskDecisionID int64          `json:"risk_decision_id"`
+	Status         string         `json:"status"`
+	DecidedBy      sql.NullString `json:"decided_by"`
+	DecidedAt      sql.NullTime   `json:"decided_at"`
+	TransferID     sql.NullInt64  `json:"transfer_id"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// a currency without a row in the profile has no limits, except in tier0 where it cannot be sent
+type TransferLimit struct {
+	Profile        string `json:"profile"`
+	Currency       string `json:"currency"`
+	PerTransaction int64  `json:"per_transaction"`
+	Daily          int64  `json:"daily"`
+	Monthly        int64  `json:"monthly"`
+}
+
+// outgoing transfers per UTC day, updated in the transfer transaction
+type TransferUsage struct {
+	Username string    `json:"username"`
+	Currency string    `json:"currency"`
+	Day      time.Time `json:"day"`
+	Amount   int64     `json:"amount"`
+	Count    int32     `json:"count"`
 }
 
 type User struct {
@@ -53,4 +373,19 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
+	LimitProfile      string    `json:"limit_profile"`
+	KycStatus         string    `json:"kyc_status"`
+	// tier 0 users have one account and the tier0 transfer limits whatever their limit profile
+	KycTier int32 `json:"kyc_tier"`
+}
+
+type UserAlias struct {
+	Kind string `json:"kind"`
+	// lower case email, or phone number in E.164 format
+	Value    string `json:"value"`
+	Username string `json:"username"`
+	// transfers are only resolved through verified aliases
+	VerifiedAt sql.NullTime `json:"verified_at"`
+	CreatedAt  time.Time    `json:"created_at"`
 }
@@ -0,0 +1,234 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: notification.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/lib/pq"
+)
+
+const countUnreadNotifications = `-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE username = $1 AND in_app AND read_at IS NULL
+`
+
+func (q *Queries) CountUnreadNotifications(ctx context.Context, username string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnreadNotifications, username)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createNotification = `-- name: CreateNotification :one
+INSERT INTO notifications (
+  username,
+  event_type,
+  title,
+  body,
+  data,
+  in_app,
+  dedup_key
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (dedup_key) DO NOTHING
+RETURNING id, username, event_type, title, body, data, in_app, dedup_key, read_at, created_at
+`
+
+type CreateNotificationParams struct {
+	Username  string          `json:"username"`
+	EventType string          `json:"event_type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data"`
+	InApp     bool            `json:"in_app"`
+	DedupKey  string          `json:"dedup_key"`
+}
+
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, createNotification,
+		arg.Username,
+		arg.EventType,
+		arg.Title,
+		arg.Body,
+		arg.Data,
+		arg.InApp,
+		arg.DedupKey,
+	)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.EventType,
+		&i.Title,
+		&i.Body,
+		&i.Data,
+		&i.InApp,
+		&i.DedupKey,
+		&i.ReadAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listNotificationPreferences = `-- name: ListNotificationPreferences :many
+SELECT username, event_type, channel, enabled, updated_at FROM notification_preferences
+WHERE username = $1
+ORDER BY event_type, channel
+`
+
+func (q *Queries) ListNotificationPreferences(ctx context.Context, username string) ([]NotificationPreference, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationPreferences, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NotificationPreference{}
+	for rows.Next() {
+		var i NotificationPreference
+		if err := rows.Scan(
+			&i.Username,
+			&i.EventType,
+			&i.Channel,
+			&i.Enabled,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotifications = `-- name: ListNotifications :many
+SELECT id, username, event_type, title, body, data, in_app, dedup_key, read_at, created_at FROM notifications
+WHERE username = $1 AND in_app
+  AND (NOT $2::boolean OR read_at IS NULL)
+ORDER BY id DESC
+LIMIT $4 OFFSET $3
+`
+
+type ListNotificationsParams struct {
+	Username   string `json:"username"`
+	UnreadOnly bool   `json:"unread_only"`
+	Offset     int32  `json:"offset"`
+	Limit      int32  `json:"limit"`
+}
+
+func (q *Queries) ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error) {
+	rows, err := q.db.QueryContext(ctx, listNotifications,
+		arg.Username,
+		arg.UnreadOnly,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.EventType,
+			&i.Title,
+			&i.Body,
+			&i.Data,
+			&i.InApp,
+			&i.DedupKey,
+			&i.ReadAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAllNotificationsRead = `-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications SET read_at = now()
+WHERE username = $1 AND in_app AND read_at IS NULL
+`
+
+func (q *Queries) MarkAllNotificationsRead(ctx context.Context, username string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markAllNotificationsRead, username)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markNotificationsRead = `-- name: MarkNotificationsRead :execrows
+UPDATE notifications SET read_at = now()
+WHERE username = $1 AND in_app AND read_at IS NULL
+  AND id = ANY($2::bigint[])
+`
+
+type MarkNotificationsReadParams struct {
+	Username string  `json:"username"`
+	Ids      []int64 `json:"ids"`
+}
+
+func (q *Queries) MarkNotificationsRead(ctx context.Context, arg MarkNotificationsReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markNotificationsRead, arg.Username, pq.Array(arg.Ids))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertNotificationPreference = `-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (
+  username,
+  event_type,
+  channel,
+  enabled
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (username, event_type, channel) DO UPDATE SET
+  enabled = EXCLUDED.enabled,
+  updated_at = now()
+RETURNING username, event_type, channel, enabled, updated_at
+`
+
+type UpsertNotificationPreferenceParams struct {
+	Username  string `json:"username"`
+	EventType string `json:"event_type"`
+	Channel   string `json:"channel"`
+	Enabled   bool   `json:"enabled"`
+}
+
+func (q *Queries) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, upsertNotificationPreference,
+		arg.Username,
+		arg.EventType,
+		arg.Channel,
+		arg.Enabled,
+	)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.Username,
+		&i.EventType,
+		&i.Channel,
+		&i.Enabled,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
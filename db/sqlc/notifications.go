@@ -0,0 +1,59 @@
+package db
+
+// Events users are notified about. Preferences are kept per event and channel.
+const (
+	NotificationTransferReceived      = "transfer_received"
+	NotificationPaymentRequestSettled = "payment_request_settled"
+	NotificationNewDeviceLogin        = "new_device_login"
+)
+
+// NotificationEvents lists every event users are notified about
+var NotificationEvents = []string{
+	NotificationTransferReceived,
+	NotificationPaymentRequestSettled,
+	NotificationNewDeviceLogin,
+}
+
+// Channels notifications are delivered on
+const (
+	ChannelInApp = "in_app"
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+// NotificationChannels lists every channel, in the order they are delivered on
+var NotificationChannels = []string{ChannelInApp, ChannelEmail, ChannelSMS}
+
+// IsNotificationEvent reports whether users can be notified about eventType
+func IsNotificationEvent(eventType string) bool {
+	for _, event := range NotificationEvents {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotificationChannel reports whether channel is a known channel
+func IsNotificationChannel(channel string) bool {
+	for _, c := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationEnabled reports whether a user with the given preferences gets
+// notified about eventType on channel. Without a preference in-app and email
+// notifications are sent and SMS are not.
+func NotificationEnabled(preferences []NotificationPreference, eventType string, channel string) bool {
+
+	for _, preference := range preferences {
+		if preference.EventType == eventType && preference.Channel == channel {
+			return preference.Enabled
+		}
+	}
+
+	return channel != ChannelSMS
+}
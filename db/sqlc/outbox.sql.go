@@ -0,0 +1,189 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :one
+INSERT INTO outbox (
+  event_type,
+  dedup_key,
+  payload,
+  trace_context
+) VALUES ($1, $2, $3, $4) RETURNING id, event_type, dedup_key, payload, attempts, last_error, available_at, published_at, created_at, trace_context
+`
+
+type CreateOutboxEventParams struct {
+	EventType    string          `json:"event_type"`
+	DedupKey     string          `json:"dedup_key"`
+	Payload      json.RawMessage `json:"payload"`
+	TraceContext json.RawMessage `json:"trace_context"`
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, createOutboxEvent,
+		arg.EventType,
+		arg.DedupKey,
+		arg.Payload,
+		arg.TraceContext,
+	)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.DedupKey,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.AvailableAt,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.TraceContext,
+	)
+	return i, err
+}
+
+const getOutboxEvent = `-- name: GetOutboxEvent :one
+SELECT id, event_type, dedup_key, payload, attempts, last_error, available_at, published_at, created_at, trace_context FROM outbox WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOutboxEvent(ctx context.Context, id int64) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, getOutboxEvent, id)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.DedupKey,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.AvailableAt,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.TraceContext,
+	)
+	return i, err
+}
+
+const getOutboxEventByDedupKey = `-- name: GetOutboxEventByDedupKey :one
+SELECT id, event_type, dedup_key, payload, attempts, last_error, available_at, published_at, created_at, trace_context FROM outbox WHERE dedup_key = $1 LIMIT 1
+`
+
+func (q *Queries) GetOutboxEventByDedupKey(ctx context.Context, dedupKey string) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, getOutboxEventByDedupKey, dedupKey)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.DedupKey,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.AvailableAt,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.TraceContext,
+	)
+	return i, err
+}
+
+const listPendingOutboxEvents = `-- name: ListPendingOutboxEvents :many
+SELECT id, event_type, dedup_key, payload, attempts, last_error, available_at, published_at, created_at, trace_context FROM outbox
+WHERE published_at IS NULL AND available_at <= now()
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+func (q *Queries) ListPendingOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Outbox{}
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.DedupKey,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.AvailableAt,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.TraceContext,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :one
+UPDATE outbox
+    SET attempts = attempts + 1,
+        last_error = $1,
+        available_at = now() + make_interval(secs => LEAST(power(2, attempts), 300))
+    WHERE id = $2 RETURNING id, event_type, dedup_key, payload, attempts, last_error, available_at, published_at, created_at, trace_context
+`
+
+type MarkOutboxEventFailedParams struct {
+	LastError string `json:"last_error"`
+	ID        int64  `json:"id"`
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, markOutboxEventFailed, arg.LastError, arg.ID)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.DedupKey,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.AvailableAt,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.TraceContext,
+	)
+	return i, err
+}
+
+const markOutboxEventPublished = `-- name: MarkOutboxEventPublished :one
+UPDATE outbox SET published_at = now() WHERE id = $1 RETURNING id, event_type, dedup_key, payload, attempts, last_error, available_at, published_at, created_at, trace_context
+`
+
+func (q *Queries) MarkOutboxEventPublished(ctx context.Context, id int64) (Outbox, error) {
+	row := q.db.QueryRowContext(ctx, markOutboxEventPublished, id)
+	var i Outbox
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.DedupKey,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.AvailableAt,
+		&i.PublishedAt,
+		&i.CreatedAt,
+		&i.TraceContext,
+	)
+	return i, err
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUserTxWritesOutboxEvent(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	result, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: hashedPassword,
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+	})
+	require.NoError(t, err)
+
+	dedupKey := fmt.Sprintf("%s:%s", EventUserCreated, result.User.Username)
+	event, err := store.GetOutboxEventByDedupKey(context.Background(), dedupKey)
+	require.NoError(t, err)
+	require.Equal(t, EventUserCreated, event.EventType)
+	require.False(t, event.PublishedAt.Valid)
+
+	var payload UserCreatedEvent
+	require.NoError(t, json.Unmarshal(event.Payload, &payload))
+	require.Equal(t, result.User.Username, payload.Username)
+	require.Equal(t, result.User.Email, payload.Email)
+}
+
+func TestPublishOutboxTx(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountLike(t, account1)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	dedupKey := fmt.Sprintf("%s:%d", EventTransferCompleted, transfer.Transfer.ID)
+	event, err := store.GetOutboxEventByDedupKey(context.Background(), dedupKey)
+	require.NoError(t, err)
+
+	// the first attempt fails and reschedules the event with backoff
+	_, err = store.PublishOutboxTx(context.Background(), PublishOutboxTxParams{
+		Limit: 1000,
+		Publish: func(e Outbox) error {
+			if e.ID == event.ID {
+				return errors.New("sink unavailable")
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	failed, err := store.GetOutboxEvent(context.Background(), event.ID)
+	require.NoError(t, err)
+	require.False(t, failed.PublishedAt.Valid)
+	require.Equal(t, int32(1), failed.Attempts)
+	require.Equal(t, "sink unavailable", failed.LastError)
+	require.True(t, failed.AvailableAt.After(event.AvailableAt))
+
+	published, err := store.MarkOutboxEventPublished(context.Background(), event.ID)
+	require.NoError(t, err)
+	require.True(t, published.PublishedAt.Valid)
+}
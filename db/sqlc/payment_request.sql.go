@@ -0,0 +1,242 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: payment_request.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createPaymentRequest = `-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (
+  requester,
+  payer,
+  to_account_id,
+  amount,
+  currency,
+  memo,
+  expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, requester, payer, to_account_id, amount, currency, memo, status, expires_at, transfer_id, settled_at, created_at
+`
+
+type CreatePaymentRequestParams struct {
+	Requester   string    `json:"requester"`
+	Payer       string    `json:"payer"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	Memo        string    `json:"memo"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, createPaymentRequest,
+		arg.Requester,
+		arg.Payer,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Memo,
+		arg.ExpiresAt,
+	)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.SettledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPaymentRequest = `-- name: GetPaymentRequest :one
+SELECT id, requester, payer, to_account_id, amount, currency, memo, status, expires_at, transfer_id, settled_at, created_at FROM payment_requests WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, getPaymentRequest, id)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.SettledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPaymentRequestForUpdate = `-- name: GetPaymentRequestForUpdate :one
+SELECT id, requester, payer, to_account_id, amount, currency, memo, status, expires_at, transfer_id, settled_at, created_at FROM payment_requests WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, getPaymentRequestForUpdate, id)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.SettledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listIncomingPaymentRequests = `-- name: ListIncomingPaymentRequests :many
+SELECT id, requester, payer, to_account_id, amount, currency, memo, status, expires_at, transfer_id, settled_at, created_at FROM payment_requests
+WHERE payer = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListIncomingPaymentRequestsParams struct {
+	Payer  string `json:"payer"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListIncomingPaymentRequests(ctx context.Context, arg ListIncomingPaymentRequestsParams) ([]PaymentRequest, error) {
+	rows, err := q.db.QueryContext(ctx, listIncomingPaymentRequests, arg.Payer, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PaymentRequest{}
+	for rows.Next() {
+		var i PaymentRequest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Requester,
+			&i.Payer,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Memo,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TransferID,
+			&i.SettledAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOutgoingPaymentRequests = `-- name: ListOutgoingPaymentRequests :many
+SELECT id, requester, payer, to_account_id, amount, currency, memo, status, expires_at, transfer_id, settled_at, created_at FROM payment_requests
+WHERE requester = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListOutgoingPaymentRequestsParams struct {
+	Requester string `json:"requester"`
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
+}
+
+func (q *Queries) ListOutgoingPaymentRequests(ctx context.Context, arg ListOutgoingPaymentRequestsParams) ([]PaymentRequest, error) {
+	rows, err := q.db.QueryContext(ctx, listOutgoingPaymentRequests, arg.Requester, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PaymentRequest{}
+	for rows.Next() {
+		var i PaymentRequest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Requester,
+			&i.Payer,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Memo,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TransferID,
+			&i.SettledAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const settlePaymentRequest = `-- name: SettlePaymentRequest :one
+UPDATE payment_requests
+SET status = $2, transfer_id = $3, settled_at = now()
+WHERE id = $1
+RETURNING id, requester, payer, to_account_id, amount, currency, memo, status, expires_at, transfer_id, settled_at, created_at
+`
+
+type SettlePaymentRequestParams struct {
+	ID         int64         `json:"id"`
+	Status     string        `json:"status"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+func (q *Queries) SettlePaymentRequest(ctx context.Context, arg SettlePaymentRequestParams) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, settlePaymentRequest, arg.ID, arg.Status, arg.TransferID)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TransferID,
+		&i.SettledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
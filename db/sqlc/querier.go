@@ -6,33 +6,152 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
-	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AcceptAccountInvitation(ctx context.Context, arg AcceptAccountInvitationParams) (AccountMember, error)
+	AddTransferUsage(ctx context.Context, arg AddTransferUsageParams) (TransferUsage, error)
+	BlockAllSessions(ctx context.Context) (int64, error)
+	CompleteBatchTransfer(ctx context.Context, arg CompleteBatchTransferParams) (BatchTransfer, error)
+	CountAccountSigners(ctx context.Context, accountID int64) (int64, error)
+	CountAccountsByOwner(ctx context.Context, owner string) (int64, error)
+	CountBatchTransferItems(ctx context.Context, batchID int64) (CountBatchTransferItemsRow, error)
+	CountUnreadNotifications(ctx context.Context, username string) (int64, error)
+	CountUserDeviceSessions(ctx context.Context, arg CountUserDeviceSessionsParams) (CountUserDeviceSessionsRow, error)
+	// accounts open empty; money only arrives through entries
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
-	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateBatchTransfer(ctx context.Context, arg CreateBatchTransferParams) (BatchTransfer, error)
+	CreateBatchTransferItem(ctx context.Context, arg CreateBatchTransferItemParams) (BatchTransferItem, error)
+	// general ledger accounts cannot be saved, no row is returned for them
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateInternalAccount(ctx context.Context, arg CreateInternalAccountParams) (InternalAccount, error)
+	CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (Outbox, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreateRiskDecision(ctx context.Context, arg CreateRiskDecisionParams) (RiskDecision, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DecideTransferApproval(ctx context.Context, arg DecideTransferApprovalParams) (TransferApproval, error)
 	DeleteAccount(ctx context.Context, id int64) error
-	DeleteEntry(ctx context.Context, id int64) error
-	DeleteTransfer(ctx context.Context, id int64) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) (AccountMember, error)
+	DeleteBeneficiary(ctx context.Context, arg DeleteBeneficiaryParams) (int64, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountFeeSchedule(ctx context.Context, id int64) (FeeSchedule, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	// the limits are null when the profile has no row for the currency
+	GetAccountTransferLimit(ctx context.Context, id int64) (GetAccountTransferLimitRow, error)
+	GetBatchTransfer(ctx context.Context, id int64) (BatchTransfer, error)
+	GetBatchTransferByIdempotencyKey(ctx context.Context, arg GetBatchTransferByIdempotencyKeyParams) (BatchTransfer, error)
+	GetBatchTransferItemForUpdate(ctx context.Context, id int64) (BatchTransferItem, error)
+	GetBeneficiary(ctx context.Context, arg GetBeneficiaryParams) (GetBeneficiaryRow, error)
+	// the amount of an entry with all its corrections applied
+	GetCorrectedEntryAmount(ctx context.Context, id int64) (int64, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetInterestPosting(ctx context.Context, arg GetInterestPostingParams) (InterestPosting, error)
+	GetInternalAccount(ctx context.Context, arg GetInternalAccountParams) (Account, error)
+	GetJournal(ctx context.Context, id int64) (Journal, error)
+	GetKycDocumentForUpdate(ctx context.Context, id int64) (KycDocument, error)
+	GetLastAccountEntryID(ctx context.Context, accountID int64) (int64, error)
+	GetLastInterestAccrualDay(ctx context.Context) (time.Time, error)
+	GetLastInterestCarry(ctx context.Context, arg GetLastInterestCarryParams) (int64, error)
+	GetLimitProfile(ctx context.Context, name string) (LimitProfile, error)
+	GetOutboxEvent(ctx context.Context, id int64) (Outbox, error)
+	GetOutboxEventByDedupKey(ctx context.Context, dedupKey string) (Outbox, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
+	GetProduct(ctx context.Context, code string) (Product, error)
+	// checking accounts are preferred when the user has several in the currency
+	GetRecipientAccount(ctx context.Context, arg GetRecipientAccountParams) (GetRecipientAccountRow, error)
+	GetRiskDecision(ctx context.Context, id int64) (RiskDecision, error)
+	GetRiskDecisionForUpdate(ctx context.Context, id int64) (RiskDecision, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferRiskSignals(ctx context.Context, arg GetTransferRiskSignalsParams) (GetTransferRiskSignalsRow, error)
+	GetTransferUsageBetween(ctx context.Context, arg GetTransferUsageBetweenParams) (int64, error)
+	// the balance of every account of the chart from the entries posted before
+	// as_of; the customer accounts are summed into customer_deposits
+	GetTrialBalance(ctx context.Context, arg GetTrialBalanceParams) ([]GetTrialBalanceRow, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	// the tier only counts once the email the user signed up with is verified
+	GetUserKyc(ctx context.Context, username string) (GetUserKycRow, error)
+	GetUserKycForUpdate(ctx context.Context, username string) (GetUserKycForUpdateRow, error)
+	GetVerifiedAliasUsername(ctx context.Context, arg GetVerifiedAliasUsernameParams) (string, error)
+	InviteAccountMember(ctx context.Context, arg InviteAccountMemberParams) (AccountMember, error)
+	// entries of an account after after_id with the balance of the account right
+	// after each of them. Entries of an account commit in id order, because the
+	// account row is locked before they are written, so the running sum is exact.
+	ListAccountActivityAfter(ctx context.Context, arg ListAccountActivityAfterParams) ([]ListAccountActivityAfterRow, error)
+	ListAccountEntriesAfter(ctx context.Context, arg ListAccountEntriesAfterParams) ([]Entry, error)
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
+	// entries of an account in [from_time, to_time), newest first. The
+	// counterparty is the other account of a transfer entry.
+	ListAccountStatement(ctx context.Context, arg ListAccountStatementParams) ([]ListAccountStatementRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsByIDs(ctx context.Context, ids []int64) ([]Account, error)
+	ListAccountsToPostInterest(ctx context.Context, arg ListAccountsToPostInterestParams) ([]int64, error)
+	ListBatchTransferItems(ctx context.Context, arg ListBatchTransferItemsParams) ([]BatchTransferItem, error)
+	ListBeneficiaries(ctx context.Context, owner string) ([]ListBeneficiariesRow, error)
+	ListChartOfAccounts(ctx context.Context) ([]ChartOfAccount, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListFeeTiers(ctx context.Context, scheduleID int64) ([]FeeTier, error)
+	ListIncomingPaymentRequests(ctx context.Context, arg ListIncomingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	ListInterestBearingBalances(ctx context.Context, arg ListInterestBearingBalancesParams) ([]ListInterestBearingBalancesRow, error)
+	ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]Entry, error)
+	ListKycDocuments(ctx context.Context, username string) ([]KycDocument, error)
+	ListNotificationPreferences(ctx context.Context, username string) ([]NotificationPreference, error)
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListOutgoingPaymentRequests(ctx context.Context, arg ListOutgoingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListPendingBatchTransferItems(ctx context.Context, batchID int64) ([]BatchTransferItem, error)
+	ListPendingKycDocuments(ctx context.Context, arg ListPendingKycDocumentsParams) ([]KycDocument, error)
+	ListPendingOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error)
+	ListPendingRiskDecisions(ctx context.Context, arg ListPendingRiskDecisionsParams) ([]RiskDecision, error)
+	ListPendingTransferApprovals(ctx context.Context, fromAccountID int64) ([]TransferApproval, error)
+	ListProducts(ctx context.Context) ([]Product, error)
+	ListTransferJournals(ctx context.Context, transferID sql.NullInt64) ([]Journal, error)
+	ListTransferLimits(ctx context.Context, profile string) ([]TransferLimit, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
-	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
-	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error)
-	UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error)
+	ListUnbalancedAccounts(ctx context.Context) ([]ListUnbalancedAccountsRow, error)
+	MarkAllNotificationsRead(ctx context.Context, username string) (int64, error)
+	MarkBatchTransferItemFailed(ctx context.Context, arg MarkBatchTransferItemFailedParams) (BatchTransferItem, error)
+	MarkBatchTransferItemSucceeded(ctx context.Context, arg MarkBatchTransferItemSucceededParams) (BatchTransferItem, error)
+	MarkNotificationsRead(ctx context.Context, arg MarkNotificationsReadParams) (int64, error)
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) (Outbox, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) (Outbox, error)
+	ReviewKycDocument(ctx context.Context, arg ReviewKycDocumentParams) (KycDocument, error)
+	ReviewRiskDecision(ctx context.Context, arg ReviewRiskDecisionParams) (RiskDecision, error)
+	// transfers touching the accounts the user is an active member of, or only
+	// account_id when set. Held transfers are only shown to the sending side.
+	// The cursor is the sort key of the last row of the previous page.
+	SearchTransferActivity(ctx context.Context, arg SearchTransferActivityParams) ([]SearchTransferActivityRow, error)
+	SetAccountApprovalThreshold(ctx context.Context, arg SetAccountApprovalThresholdParams) (Account, error)
+	SetInterestPostingEntries(ctx context.Context, arg SetInterestPostingEntriesParams) (InterestPosting, error)
+	SetRiskDecisionTransfer(ctx context.Context, arg SetRiskDecisionTransferParams) (RiskDecision, error)
+	SettlePaymentRequest(ctx context.Context, arg SettlePaymentRequestParams) (PaymentRequest, error)
+	SkipPendingBatchTransferItems(ctx context.Context, arg SkipPendingBatchTransferItemsParams) error
+	SumInterestAccruals(ctx context.Context, arg SumInterestAccrualsParams) (int64, error)
+	UpdateBatchTransferStatus(ctx context.Context, arg UpdateBatchTransferStatusParams) (BatchTransfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserKyc(ctx context.Context, arg UpdateUserKycParams) (User, error)
+	UpdateUserLimitProfile(ctx context.Context, arg UpdateUserLimitProfileParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpsertCurrency(ctx context.Context, arg UpsertCurrencyParams) (Currency, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
+	UpsertTransferLimit(ctx context.Context, arg UpsertTransferLimitParams) (TransferLimit, error)
+	UpsertUserAlias(ctx context.Context, arg UpsertUserAliasParams) (UserAlias, error)
 }
 
 var _ Querier = (*Queries)(nil)
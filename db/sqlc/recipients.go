@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/joefazee/simplebank/util"
+)
+
+// Kinds of user aliases
+const (
+	AliasKindEmail = "email"
+	AliasKindPhone = "phone"
+)
+
+// ErrRecipientNotFound is returned when an alias does not lead to an account in the currency
+var ErrRecipientNotFound = errors.New("recipient not found")
+
+// Recipient is the account a transfer to a username or alias is sent to
+type Recipient struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	// MaskedName lets the sender confirm the recipient before sending
+	MaskedName string `json:"masked_name"`
+}
+
+// ParseAlias works out what kind of alias a recipient was given as. Emails
+// are lower cased and phone numbers, which must start with the country code,
+// lose their formatting. The kind is empty for usernames.
+func ParseAlias(alias string) (kind string, value string) {
+
+	alias = strings.TrimSpace(alias)
+
+	switch {
+	case strings.Contains(alias, "@"):
+		return AliasKindEmail, strings.ToLower(alias)
+	case strings.HasPrefix(alias, "+"):
+		digits := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, alias)
+		return AliasKindPhone, "+" + digits
+	}
+
+	return "", alias
+}
+
+// ResolveRecipient finds the account in currency of the user a username,
+// verified email or verified phone number belongs to
+func ResolveRecipient(ctx context.Context, q Querier, alias string, currency string) (Recipient, error) {
+
+	kind, username := ParseAlias(alias)
+	if kind != "" {
+		var err error
+		username, err = q.GetVerifiedAliasUsername(ctx, GetVerifiedAliasUsernameParams{
+			Kind:  kind,
+			Value: username,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return Recipient{}, ErrRecipientNotFound
+			}
+			return Recipient{}, err
+		}
+	}
+
+	account, err := q.GetRecipientAccount(ctx, GetRecipientAccountParams{
+		Owner:    username,
+		Currency: currency,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Recipient{}, ErrRecipientNotFound
+		}
+		return Recipient{}, err
+	}
+
+	return Recipient{
+		AccountID:  account.ID,
+		Currency:   account.Currency,
+		MaskedName: util.MaskName(account.FullName),
+	}, nil
+}
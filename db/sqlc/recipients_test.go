@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRecipient(t *testing.T) {
+
+	account := createRandomAccount(t)
+	user, err := testQueries.GetUser(context.Background(), account.Owner)
+	require.NoError(t, err)
+
+	byUsername, err := ResolveRecipient(context.Background(), testQueries, account.Owner, account.Currency)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, byUsername.AccountID)
+	require.Equal(t, util.MaskName(user.FullName), byUsername.MaskedName)
+
+	// an unverified email is not resolved
+	_, err = testQueries.UpsertUserAlias(context.Background(), UpsertUserAliasParams{
+		Kind:     AliasKindEmail,
+		Value:    user.Email,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = ResolveRecipient(context.Background(), testQueries, strings.ToUpper(user.Email), account.Currency)
+	require.ErrorIs(t, err, ErrRecipientNotFound)
+
+	_, err = testQueries.UpsertUserAlias(context.Background(), UpsertUserAliasParams{
+		Kind:       AliasKindEmail,
+		Value:      user.Email,
+		Username:   user.Username,
+		VerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	byEmail, err := ResolveRecipient(context.Background(), testQueries, strings.ToUpper(user.Email), account.Currency)
+	require.NoError(t, err)
+	require.Equal(t, byUsername, byEmail)
+}
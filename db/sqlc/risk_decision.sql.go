@@ -0,0 +1,351 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: risk_decision.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const createRiskDecision = `-- name: CreateRiskDecision :one
+INSERT INTO risk_decisions (
+  username,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  user_agent,
+  client_ip,
+  decision,
+  hits,
+  review_status,
+  memo,
+  reference,
+  metadata
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id, username, from_account_id, to_account_id, amount, currency, user_agent, client_ip, decision, hits, review_status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, memo, reference, metadata
+`
+
+type CreateRiskDecisionParams struct {
+	Username      string          `json:"username"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Currency      string          `json:"currency"`
+	UserAgent     string          `json:"user_agent"`
+	ClientIp      string          `json:"client_ip"`
+	Decision      string          `json:"decision"`
+	Hits          json.RawMessage `json:"hits"`
+	ReviewStatus  sql.NullString  `json:"review_status"`
+	Memo          string          `json:"memo"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+}
+
+func (q *Queries) CreateRiskDecision(ctx context.Context, arg CreateRiskDecisionParams) (RiskDecision, error) {
+	row := q.db.QueryRowContext(ctx, createRiskDecision,
+		arg.Username,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.UserAgent,
+		arg.ClientIp,
+		arg.Decision,
+		arg.Hits,
+		arg.ReviewStatus,
+		arg.Memo,
+		arg.Reference,
+		arg.Metadata,
+	)
+	var i RiskDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.Decision,
+		&i.Hits,
+		&i.ReviewStatus,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Memo,
+		&i.Reference,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const getRiskDecision = `-- name: GetRiskDecision :one
+SELECT id, username, from_account_id, to_account_id, amount, currency, user_agent, client_ip, decision, hits, review_status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, memo, reference, metadata FROM risk_decisions WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetRiskDecision(ctx context.Context, id int64) (RiskDecision, error) {
+	row := q.db.QueryRowContext(ctx, getRiskDecision, id)
+	var i RiskDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.Decision,
+		&i.Hits,
+		&i.ReviewStatus,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Memo,
+		&i.Reference,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const getRiskDecisionForUpdate = `-- name: GetRiskDecisionForUpdate :one
+SELECT id, username, from_account_id, to_account_id, amount, currency, user_agent, client_ip, decision, hits, review_status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, memo, reference, metadata FROM risk_decisions WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetRiskDecisionForUpdate(ctx context.Context, id int64) (RiskDecision, error) {
+	row := q.db.QueryRowContext(ctx, getRiskDecisionForUpdate, id)
+	var i RiskDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.Decision,
+		&i.Hits,
+		&i.ReviewStatus,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Memo,
+		&i.Reference,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const getTransferRiskSignals = `-- name: GetTransferRiskSignals :one
+SELECT
+  (SELECT COUNT(*) FROM transfers t JOIN accounts a ON a.id = t.from_account_id
+   WHERE a.owner = $1 AND t.created_at > $2)::bigint AS recent_transfers,
+  (SELECT COUNT(*) FROM transfers t JOIN accounts a ON a.id = t.from_account_id
+   WHERE a.owner = $1 AND t.to_account_id = $3)::bigint AS payee_transfers,
+  (SELECT COUNT(*) FROM sessions s
+   WHERE s.username = $1 AND s.created_at < $4)::bigint AS previous_sessions,
+  (SELECT COUNT(*) FROM sessions s
+   WHERE s.username = $1 AND s.created_at < $4
+   AND s.user_agent = $5::varchar)::bigint AS device_sessions,
+  (SELECT COUNT(*) FROM sessions s
+   WHERE s.username = $1 AND s.created_at < $4
+   AND (s.client_ip = $6::varchar
+     OR s.client_ip LIKE $6::varchar || ':%'
+     OR s.client_ip LIKE '[' || $6::varchar || ']:%'))::bigint AS ip_sessions,
+  COALESCE((SELECT c.risk_large_amount FROM currencies c
+   WHERE c.code = $7::varchar), 0)::bigint AS large_amount
+`
+
+type GetTransferRiskSignalsParams struct {
+	Username      string    `json:"username"`
+	VelocitySince time.Time `json:"velocity_since"`
+	ToAccountID   int64     `json:"to_account_id"`
+	KnownBefore   time.Time `json:"known_before"`
+	UserAgent     string    `json:"user_agent"`
+	ClientIp      string    `json:"client_ip"`
+	Currency      string    `json:"currency"`
+}
+
+type GetTransferRiskSignalsRow struct {
+	RecentTransfers  int64 `json:"recent_transfers"`
+	PayeeTransfers   int64 `json:"payee_transfers"`
+	PreviousSessions int64 `json:"previous_sessions"`
+	DeviceSessions   int64 `json:"device_sessions"`
+	IpSessions       int64 `json:"ip_sessions"`
+	LargeAmount      int64 `json:"large_amount"`
+}
+
+func (q *Queries) GetTransferRiskSignals(ctx context.Context, arg GetTransferRiskSignalsParams) (GetTransferRiskSignalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getTransferRiskSignals,
+		arg.Username,
+		arg.VelocitySince,
+		arg.ToAccountID,
+		arg.KnownBefore,
+		arg.UserAgent,
+		arg.ClientIp,
+		arg.Currency,
+	)
+	var i GetTransferRiskSignalsRow
+	err := row.Scan(
+		&i.RecentTransfers,
+		&i.PayeeTransfers,
+		&i.PreviousSessions,
+		&i.DeviceSessions,
+		&i.IpSessions,
+		&i.LargeAmount,
+	)
+	return i, err
+}
+
+const listPendingRiskDecisions = `-- name: ListPendingRiskDecisions :many
+SELECT id, username, from_account_id, to_account_id, amount, currency, user_agent, client_ip, decision, hits, review_status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, memo, reference, metadata FROM risk_decisions
+WHERE review_status = 'pending'
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListPendingRiskDecisionsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListPendingRiskDecisions(ctx context.Context, arg ListPendingRiskDecisionsParams) ([]RiskDecision, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingRiskDecisions, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RiskDecision{}
+	for rows.Next() {
+		var i RiskDecision
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.Decision,
+			&i.Hits,
+			&i.ReviewStatus,
+			&i.TransferID,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.Memo,
+			&i.Reference,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewRiskDecision = `-- name: ReviewRiskDecision :one
+UPDATE risk_decisions
+SET review_status = $2, reviewed_by = $3, review_note = $4, transfer_id = $5, reviewed_at = now()
+WHERE id = $1
+RETURNING id, username, from_account_id, to_account_id, amount, currency, user_agent, client_ip, decision, hits, review_status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, memo, reference, metadata
+`
+
+type ReviewRiskDecisionParams struct {
+	ID           int64          `json:"id"`
+	ReviewStatus sql.NullString `json:"review_status"`
+	ReviewedBy   sql.NullString `json:"reviewed_by"`
+	ReviewNote   string         `json:"review_note"`
+	TransferID   sql.NullInt64  `json:"transfer_id"`
+}
+
+func (q *Queries) ReviewRiskDecision(ctx context.Context, arg ReviewRiskDecisionParams) (RiskDecision, error) {
+	row := q.db.QueryRowContext(ctx, reviewRiskDecision,
+		arg.ID,
+		arg.ReviewStatus,
+		arg.ReviewedBy,
+		arg.ReviewNote,
+		arg.TransferID,
+	)
+	var i RiskDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.Decision,
+		&i.Hits,
+		&i.ReviewStatus,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Memo,
+		&i.Reference,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const setRiskDecisionTransfer = `-- name: SetRiskDecisionTransfer :one
+UPDATE risk_decisions SET transfer_id = $2 WHERE id = $1 RETURNING id, username, from_account_id, to_account_id, amount, currency, user_agent, client_ip, decision, hits, review_status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, memo, reference, metadata
+`
+
+type SetRiskDecisionTransferParams struct {
+	ID         int64         `json:"id"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+func (q *Queries) SetRiskDecisionTransfer(ctx context.Context, arg SetRiskDecisionTransferParams) (RiskDecision, error) {
+	row := q.db.QueryRowContext(ctx, setRiskDecisionTransfer, arg.ID, arg.TransferID)
+	var i RiskDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.Decision,
+		&i.Hits,
+		&i.ReviewStatus,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Memo,
+		&i.Reference,
+		&i.Metadata,
+	)
+	return i, err
+}
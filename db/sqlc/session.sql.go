@@ -12,6 +12,43 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockAllSessions = `-- name: BlockAllSessions :execrows
+UPDATE sessions SET is_blocked = true WHERE is_blocked = false
+`
+
+func (q *Queries) BlockAllSessions(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, blockAllSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countUserDeviceSessions = `-- name: CountUserDeviceSessions :one
+SELECT
+  COUNT(*)::bigint AS sessions,
+  (COUNT(*) FILTER (WHERE user_agent = $1))::bigint AS device_sessions
+FROM sessions
+WHERE username = $2
+`
+
+type CountUserDeviceSessionsParams struct {
+	UserAgent string `json:"user_agent"`
+	Username  string `json:"username"`
+}
+
+type CountUserDeviceSessionsRow struct {
+	Sessions       int64 `json:"sessions"`
+	DeviceSessions int64 `json:"device_sessions"`
+}
+
+func (q *Queries) CountUserDeviceSessions(ctx context.Context, arg CountUserDeviceSessionsParams) (CountUserDeviceSessionsRow, error) {
+	row := q.db.QueryRowContext(ctx, countUserDeviceSessions, arg.UserAgent, arg.Username)
+	var i CountUserDeviceSessionsRow
+	err := row.Scan(&i.Sessions, &i.DeviceSessions)
+	return i, err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
   id,
@@ -17,7 +17,7 @@ func TestMain(m *testing.M) {
 
 	var err error
 
-	config, err := util.LoadCondfig("../..")
+	config, err := util.LoadConfig("../..")
 
 	if err != nil {
 		log.Fatal(err)
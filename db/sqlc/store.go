@@ -4,12 +4,32 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
 )
 
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error)
+	PublishOutboxTx(ctx context.Context, arg PublishOutboxTxParams) (PublishOutboxTxResult, error)
+	CreateBatchTransferTx(ctx context.Context, arg CreateBatchTransferTxParams) (CreateBatchTransferTxResult, error)
+	BatchTransferItemTx(ctx context.Context, arg BatchTransferItemTxParams) (BatchTransferItemTxResult, error)
+	BatchTransferAllTx(ctx context.Context, arg BatchTransferAllTxParams) (BatchTransferAllTxResult, error)
+	ScreenedTransferTx(ctx context.Context, arg ScreenedTransferTxParams) (ScreenedTransferTxResult, error)
+	ReviewTransferTx(ctx context.Context, arg ReviewTransferTxParams) (ReviewTransferTxResult, error)
+	ApproveTransferTx(ctx context.Context, arg ApproveTransferTxParams) (ApproveTransferTxResult, error)
+	PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error)
+	DeclinePaymentRequestTx(ctx context.Context, arg DeclinePaymentRequestTxParams) (PaymentRequest, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error)
+	SubmitKycDocumentTx(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error)
+	ReviewKycDocumentTx(ctx context.Context, arg ReviewKycDocumentTxParams) (ReviewKycDocumentTxResult, error)
+	CorrectEntryTx(ctx context.Context, arg CorrectEntryTxParams) (CorrectEntryTxResult, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	PostJournal(ctx context.Context, arg PostJournalParams) (PostJournalResult, error)
+	PreviewTransferFee(ctx context.Context, fromAccountID int64, amount int64) (Fee, error)
+	PreviewTransferFees(ctx context.Context, fromAccountID int64, amounts []int64) ([]Fee, error)
 }
 
 type SQLStore struct {
@@ -20,19 +40,28 @@ type SQLStore struct {
 func NewStore(db *sql.DB) Store {
 	return &SQLStore{
 		db:      db,
-		Queries: New(db),
+		Queries: New(newTracedDB(db, nil)),
 	}
 }
 
 // execTx executes a function within a database transaction
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) (err error) {
+
+	ctx, span := tracer.Start(ctx, "db.execTx")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 
 	tx, err := store.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
-	q := New(tx)
+	q := New(newTracedDB(tx, span))
 
 	err = fn(q)
 	if err != nil {
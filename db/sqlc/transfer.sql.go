@@ -7,20 +7,36 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
-INSERT INTO transfers (from_account_id, to_account_id, amount) VALUES ($1, $2, $3) RETURNING id, from_account_id, to_account_id, amount, created_at
+INSERT INTO transfers (from_account_id, to_account_id, amount, fee, memo, reference, metadata)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, metadata
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Fee           int64           `json:"fee"`
+	Memo          string          `json:"memo"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Fee,
+		arg.Memo,
+		arg.Reference,
+		arg.Metadata,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -28,21 +44,16 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Fee,
+		&i.Memo,
+		&i.Reference,
+		&i.Metadata,
 	)
 	return i, err
 }
 
-const deleteTransfer = `-- name: DeleteTransfer :exec
-DELETE FROM transfers WHERE id  = $1
-`
-
-func (q *Queries) DeleteTransfer(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteTransfer, id)
-	return err
-}
-
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers WHERE id = $1 LIMIT  1
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, metadata FROM transfers WHERE id = $1 LIMIT  1
 `
 
 func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
@@ -54,12 +65,16 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Fee,
+		&i.Memo,
+		&i.Reference,
+		&i.Metadata,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers ORDER BY id LIMIT $1 offset $2
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, metadata FROM transfers ORDER BY id LIMIT $1 offset $2
 `
 
 type ListTransfersParams struct {
@@ -82,6 +97,10 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Fee,
+			&i.Memo,
+			&i.Reference,
+			&i.Metadata,
 		); err != nil {
 			return nil, err
 		}
@@ -96,24 +115,147 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	return items, nil
 }
 
-const updateTransfer = `-- name: UpdateTransfer :one
-UPDATE transfers SET amount = $2 WHERE id = $1 RETURNING id, from_account_id, to_account_id, amount, created_at
+const searchTransferActivity = `-- name: SearchTransferActivity :many
+WITH scope AS (
+  SELECT a.id FROM accounts a
+  WHERE a.owner = $16::varchar AND a.kind = 'customer'
+    AND ($17::bigint IS NULL OR a.id = $17::bigint)
+  UNION
+  SELECT m.account_id FROM account_members m
+  WHERE m.username = $16::varchar AND m.status = 'active'
+    AND ($17::bigint IS NULL OR m.account_id = $17::bigint)
+), activity AS (
+  SELECT
+    ta.kind, ta.id, ta.from_account_id, ta.to_account_id, ta.amount, ta.fee, ta.currency, ta.memo, ta.reference, ta.metadata, ta.status, ta.created_at,
+    (CASE
+      WHEN ta.from_account_id IN (SELECT id FROM scope) AND ta.to_account_id IN (SELECT id FROM scope) THEN 'internal'
+      WHEN ta.from_account_id IN (SELECT id FROM scope) THEN 'outgoing'
+      ELSE 'incoming'
+    END)::varchar AS direction
+  FROM transfer_activity ta
+  WHERE ta.from_account_id IN (SELECT id FROM scope)
+    OR (ta.kind = 'transfer' AND ta.to_account_id IN (SELECT id FROM scope))
+)
+SELECT kind, id, from_account_id, to_account_id, amount, fee, currency, memo, reference, metadata, status, created_at, direction FROM activity
+WHERE ($1::timestamptz IS NULL OR created_at >= $1::timestamptz)
+  AND ($2::timestamptz IS NULL OR created_at < $2::timestamptz)
+  AND ($3::bigint IS NULL OR amount >= $3::bigint)
+  AND ($4::bigint IS NULL OR amount <= $4::bigint)
+  AND ($5::bigint IS NULL
+    OR $5::bigint IN (from_account_id, to_account_id))
+  AND ($6::varchar IS NULL OR direction = $6::varchar)
+  AND ($7::varchar IS NULL OR status = $7::varchar)
+  AND ($8::varchar IS NULL OR reference = $8::varchar)
+  AND ($9::varchar IS NULL
+    OR to_tsvector('simple', memo) @@ plainto_tsquery('simple', $9::varchar))
+  AND ($10::bigint IS NULL OR CASE $11::varchar
+    WHEN 'created_at_asc' THEN (created_at, kind, id) > ($12::timestamptz, $13::varchar, $10::bigint)
+    WHEN 'amount_desc' THEN (amount, kind, id) < ($14::bigint, $13::varchar, $10::bigint)
+    WHEN 'amount_asc' THEN (amount, kind, id) > ($14::bigint, $13::varchar, $10::bigint)
+    ELSE (created_at, kind, id) < ($12::timestamptz, $13::varchar, $10::bigint)
+  END)
+ORDER BY
+  CASE WHEN $11::varchar = 'created_at_asc' THEN created_at END ASC,
+  CASE WHEN $11::varchar = 'amount_desc' THEN amount END DESC,
+  CASE WHEN $11::varchar = 'amount_asc' THEN amount END ASC,
+  CASE WHEN $11::varchar NOT IN ('created_at_asc', 'amount_desc', 'amount_asc') THEN created_at END DESC,
+  CASE WHEN $11::varchar IN ('created_at_asc', 'amount_asc') THEN kind END ASC,
+  CASE WHEN $11::varchar IN ('created_at_asc', 'amount_asc') THEN id END ASC,
+  kind DESC,
+  id DESC
+LIMIT $15::int
 `
 
-type UpdateTransferParams struct {
-	ID     int64 `json:"id"`
-	Amount int64 `json:"amount"`
+type SearchTransferActivityParams struct {
+	FromTime              sql.NullTime   `json:"from_time"`
+	ToTime                sql.NullTime   `json:"to_time"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Direction             sql.NullString `json:"direction"`
+	Status                sql.NullString `json:"status"`
+	Reference             sql.NullString `json:"reference"`
+	Query                 sql.NullString `json:"query"`
+	CursorID              sql.NullInt64  `json:"cursor_id"`
+	Sort                  string         `json:"sort"`
+	CursorTime            sql.NullTime   `json:"cursor_time"`
+	CursorKind            sql.NullString `json:"cursor_kind"`
+	CursorAmount          sql.NullInt64  `json:"cursor_amount"`
+	PageLimit             int32          `json:"page_limit"`
+	Username              string         `json:"username"`
+	AccountID             sql.NullInt64  `json:"account_id"`
 }
 
-func (q *Queries) UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, updateTransfer, arg.ID, arg.Amount)
-	var i Transfer
-	err := row.Scan(
-		&i.ID,
-		&i.FromAccountID,
-		&i.ToAccountID,
-		&i.Amount,
-		&i.CreatedAt,
+type SearchTransferActivityRow struct {
+	Kind          string          `json:"kind"`
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Fee           int64           `json:"fee"`
+	Currency      string          `json:"currency"`
+	Memo          string          `json:"memo"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Status        string          `json:"status"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Direction     string          `json:"direction"`
+}
+
+// transfers touching the accounts the user is an active member of, or only
+// account_id when set. Held transfers are only shown to the sending side.
+// The cursor is the sort key of the last row of the previous page.
+func (q *Queries) SearchTransferActivity(ctx context.Context, arg SearchTransferActivityParams) ([]SearchTransferActivityRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchTransferActivity,
+		arg.FromTime,
+		arg.ToTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CounterpartyAccountID,
+		arg.Direction,
+		arg.Status,
+		arg.Reference,
+		arg.Query,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorTime,
+		arg.CursorKind,
+		arg.CursorAmount,
+		arg.PageLimit,
+		arg.Username,
+		arg.AccountID,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchTransferActivityRow{}
+	for rows.Next() {
+		var i SearchTransferActivityRow
+		if err := rows.Scan(
+			&i.Kind,
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Fee,
+			&i.Currency,
+			&i.Memo,
+			&i.Reference,
+			&i.Metadata,
+			&i.Status,
+			&i.CreatedAt,
+			&i.Direction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
@@ -0,0 +1,230 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.16.0
+// source: transfer_limit.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const addTransferUsage = `-- name: AddTransferUsage :one
+INSERT INTO transfer_usage (
+  username,
+  currency,
+  day,
+  amount,
+  count
+) VALUES ($1, $2, $3, $4, 1)
+ON CONFLICT (username, currency, day)
+DO UPDATE SET amount = transfer_usage.amount + EXCLUDED.amount, count = transfer_usage.count + 1
+RETURNING username, currency, day, amount, count
+`
+
+type AddTransferUsageParams struct {
+	Username string    `json:"username"`
+	Currency string    `json:"currency"`
+	Day      time.Time `json:"day"`
+	Amount   int64     `json:"amount"`
+}
+
+func (q *Queries) AddTransferUsage(ctx context.Context, arg AddTransferUsageParams) (TransferUsage, error) {
+	row := q.db.QueryRowContext(ctx, addTransferUsage,
+		arg.Username,
+		arg.Currency,
+		arg.Day,
+		arg.Amount,
+	)
+	var i TransferUsage
+	err := row.Scan(
+		&i.Username,
+		&i.Currency,
+		&i.Day,
+		&i.Amount,
+		&i.Count,
+	)
+	return i, err
+}
+
+const getAccountTransferLimit = `-- name: GetAccountTransferLimit :one
+SELECT r.username, r.profile, r.currency, l.per_transaction, l.daily, l.monthly
+FROM (
+  SELECT a.owner AS username, a.currency, (CASE
+    -- users below tier 1, or whose email is not verified, get the tier0 limits
+    WHEN u.kyc_tier > 0 AND EXISTS (
+      SELECT 1 FROM user_aliases ua
+      WHERE ua.kind = 'email' AND ua.value = lower(u.email) AND ua.username = u.username AND ua.verified_at IS NOT NULL
+    ) THEN u.limit_profile
+    ELSE 'tier0'
+  END)::varchar AS profile
+  FROM accounts a
+  JOIN users u ON u.username = a.owner
+  WHERE a.id = $1
+) r
+LEFT JOIN transfer_limits l ON l.currency = r.currency AND l.profile = r.profile
+`
+
+type GetAccountTransferLimitRow struct {
+	Username       string        `json:"username"`
+	Profile        string        `json:"profile"`
+	Currency       string        `json:"currency"`
+	PerTransaction sql.NullInt64 `json:"per_transaction"`
+	Daily          sql.NullInt64 `json:"daily"`
+	Monthly        sql.NullInt64 `json:"monthly"`
+}
+
+// the limits are null when the profile has no row for the currency
+func (q *Queries) GetAccountTransferLimit(ctx context.Context, id int64) (GetAccountTransferLimitRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountTransferLimit, id)
+	var i GetAccountTransferLimitRow
+	err := row.Scan(
+		&i.Username,
+		&i.Profile,
+		&i.Currency,
+		&i.PerTransaction,
+		&i.Daily,
+		&i.Monthly,
+	)
+	return i, err
+}
+
+const getLimitProfile = `-- name: GetLimitProfile :one
+SELECT name, description, created_at FROM limit_profiles WHERE name = $1 LIMIT 1
+`
+
+func (q *Queries) GetLimitProfile(ctx context.Context, name string) (LimitProfile, error) {
+	row := q.db.QueryRowContext(ctx, getLimitProfile, name)
+	var i LimitProfile
+	err := row.Scan(&i.Name, &i.Description, &i.CreatedAt)
+	return i, err
+}
+
+const getTransferUsageBetween = `-- name: GetTransferUsageBetween :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS amount
+FROM transfer_usage
+WHERE username = $1 AND currency = $2 AND day >= $3 AND day <= $4
+`
+
+type GetTransferUsageBetweenParams struct {
+	Username string    `json:"username"`
+	Currency string    `json:"currency"`
+	FromDay  time.Time `json:"from_day"`
+	ToDay    time.Time `json:"to_day"`
+}
+
+func (q *Queries) GetTransferUsageBetween(ctx context.Context, arg GetTransferUsageBetweenParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getTransferUsageBetween,
+		arg.Username,
+		arg.Currency,
+		arg.FromDay,
+		arg.ToDay,
+	)
+	var amount int64
+	err := row.Scan(&amount)
+	return amount, err
+}
+
+const listTransferLimits = `-- name: ListTransferLimits :many
+SELECT profile, currency, per_transaction, daily, monthly FROM transfer_limits WHERE profile = $1 ORDER BY currency
+`
+
+func (q *Queries) ListTransferLimits(ctx context.Context, profile string) ([]TransferLimit, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferLimits, profile)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferLimit{}
+	for rows.Next() {
+		var i TransferLimit
+		if err := rows.Scan(
+			&i.Profile,
+			&i.Currency,
+			&i.PerTransaction,
+			&i.Daily,
+			&i.Monthly,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateUserLimitProfile = `-- name: UpdateUserLimitProfile :one
+UPDATE users SET limit_profile = $2 WHERE username = $1 RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, limit_profile, kyc_status, kyc_tier
+`
+
+type UpdateUserLimitProfileParams struct {
+	Username     string `json:"username"`
+	LimitProfile string `json:"limit_profile"`
+}
+
+func (q *Queries) UpdateUserLimitProfile(ctx context.Context, arg UpdateUserLimitProfileParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserLimitProfile, arg.Username, arg.LimitProfile)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.LimitProfile,
+		&i.KycStatus,
+		&i.KycTier,
+	)
+	return i, err
+}
+
+const upsertTransferLimit = `-- name: UpsertTransferLimit :one
+INSERT INTO transfer_limits (
+  profile,
+  currency,
+  per_transaction,
+  daily,
+  monthly
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (profile, currency) DO UPDATE SET
+  per_transaction = EXCLUDED.per_transaction,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly
+RETURNING profile, currency, per_transaction, daily, monthly
+`
+
+type UpsertTransferLimitParams struct {
+	Profile        string `json:"profile"`
+	Currency       string `json:"currency"`
+	PerTransaction int64  `json:"per_transaction"`
+	Daily          int64  `json:"daily"`
+	Monthly        int64  `json:"monthly"`
+}
+
+func (q *Queries) UpsertTransferLimit(ctx context.Context, arg UpsertTransferLimitParams) (TransferLimit, error) {
+	row := q.db.QueryRowContext(ctx, upsertTransferLimit,
+		arg.Profile,
+		arg.Currency,
+		arg.PerTransaction,
+		arg.Daily,
+		arg.Monthly,
+	)
+	var i TransferLimit
+	err := row.Scan(
+		&i.Profile,
+		&i.Currency,
+		&i.PerTransaction,
+		&i.Daily,
+		&i.Monthly,
+	)
+	return i, err
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Sort orders of a transfer search
+const (
+	TransferSortNewest   = "created_at_desc"
+	TransferSortOldest   = "created_at_asc"
+	TransferSortLargest  = "amount_desc"
+	TransferSortSmallest = "amount_asc"
+)
+
+// Directions of a transfer seen from the accounts it is searched from
+const (
+	TransferDirectionIncoming = "incoming"
+	TransferDirectionOutgoing = "outgoing"
+	TransferDirectionInternal = "internal"
+)
+
+// Statuses of transfer activity. Only completed transfers were executed; the
+// others are held back or stopped by risk screening or a second signer.
+const (
+	TransferStatusCompleted       = "completed"
+	TransferStatusPendingReview   = "pending_review"
+	TransferStatusPendingApproval = "pending_approval"
+	TransferStatusRejected        = "rejected"
+	TransferStatusBlocked         = "blocked"
+	// TransferStatusRefused is a transfer that needed a review it could not wait for
+	TransferStatusRefused = "refused"
+)
+
+// Kinds of transfer activity rows
+const (
+	TransferActivityTransfer = "transfer"
+	TransferActivityDecision = "decision"
+)
+
+// ErrInvalidTransferCursor is returned for a cursor that was not returned by
+// a search with the same sort order
+var ErrInvalidTransferCursor = errors.New("invalid transfer search cursor")
+
+func IsTransferSort(sort string) bool {
+	switch sort {
+	case TransferSortNewest, TransferSortOldest, TransferSortLargest, TransferSortSmallest:
+		return true
+	}
+	return false
+}
+
+func IsTransferDirection(direction string) bool {
+	switch direction {
+	case TransferDirectionIncoming, TransferDirectionOutgoing, TransferDirectionInternal:
+		return true
+	}
+	return false
+}
+
+func IsTransferStatus(status string) bool {
+	switch status {
+	case TransferStatusCompleted, TransferStatusPendingReview, TransferStatusPendingApproval,
+		TransferStatusRejected, TransferStatusBlocked, TransferStatusRefused:
+		return true
+	}
+	return false
+}
+
+// SearchTransfersParams filters the transfers of the accounts a user is a
+// member of. Zero values leave a filter out.
+type SearchTransfersParams struct {
+	Username string
+	// AccountID narrows the search down to one of the user's accounts
+	AccountID             int64
+	FromTime              time.Time
+	ToTime                time.Time
+	MinAmount             int64
+	MaxAmount             int64
+	CounterpartyAccountID int64
+	Direction             string
+	Status                string
+	Reference             string
+	// Query matches words of the memo
+	Query string
+	// Sort defaults to TransferSortNewest
+	Sort   string
+	Cursor string
+	Limit  int32
+}
+
+type SearchTransfersResult struct {
+	Transfers []SearchTransferActivityRow
+	// NextCursor is empty on the last page
+	NextCursor string
+}
+
+// transferCursor is the sort key of the last row of a page
+type transferCursor struct {
+	Sort   string    `json:"s"`
+	Time   time.Time `json:"t,omitempty"`
+	Amount int64     `json:"a,omitempty"`
+	Kind   string    `json:"k"`
+	ID     int64     `json:"i"`
+}
+
+func encodeTransferCursor(sort string, row SearchTransferActivityRow) (string, error) {
+	cursor := transferCursor{Sort: sort, Kind: row.Kind, ID: row.ID}
+	if sort == TransferSortLargest || sort == TransferSortSmallest {
+		cursor.Amount = row.Amount
+	} else {
+		cursor.Time = row.CreatedAt
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeTransferCursor(sort string, value string) (cursor transferCursor, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, ErrInvalidTransferCursor
+	}
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort || cursor.ID <= 0 {
+		return cursor, ErrInvalidTransferCursor
+	}
+	return cursor, nil
+}
+
+// SearchTransfers runs a transfer search and returns one page of it along
+// with the cursor of the next one
+func SearchTransfers(ctx context.Context, q Querier, arg SearchTransfersParams) (SearchTransfersResult, error) {
+
+	sort := arg.Sort
+	if sort == "" {
+		sort = TransferSortNewest
+	}
+
+	params := SearchTransferActivityParams{
+		Username:              arg.Username,
+		AccountID:             sql.NullInt64{Int64: arg.AccountID, Valid: arg.AccountID != 0},
+		FromTime:              sql.NullTime{Time: arg.FromTime, Valid: !arg.FromTime.IsZero()},
+		ToTime:                sql.NullTime{Time: arg.ToTime, Valid: !arg.ToTime.IsZero()},
+		MinAmount:             sql.NullInt64{Int64: arg.MinAmount, Valid: arg.MinAmount != 0},
+		MaxAmount:             sql.NullInt64{Int64: arg.MaxAmount, Valid: arg.MaxAmount != 0},
+		CounterpartyAccountID: sql.NullInt64{Int64: arg.CounterpartyAccountID, Valid: arg.CounterpartyAccountID != 0},
+		Direction:             sql.NullString{String: arg.Direction, Valid: arg.Direction != ""},
+		Status:                sql.NullString{String: arg.Status, Valid: arg.Status != ""},
+		Reference:             sql.NullString{String: arg.Reference, Valid: arg.Reference != ""},
+		Query:                 sql.NullString{String: arg.Query, Valid: arg.Query != ""},
+		Sort:                  sort,
+		// one more row than asked for tells whether there is a next page
+		PageLimit: arg.Limit + 1,
+	}
+
+	if arg.Cursor != "" {
+		cursor, err := decodeTransferCursor(sort, arg.Cursor)
+		if err != nil {
+			return SearchTransfersResult{}, err
+		}
+		params.CursorID = sql.NullInt64{Int64: cursor.ID, Valid: true}
+		params.CursorKind = sql.NullString{String: cursor.Kind, Valid: true}
+		params.CursorTime = sql.NullTime{Time: cursor.Time, Valid: true}
+		params.CursorAmount = sql.NullInt64{Int64: cursor.Amount, Valid: true}
+	}
+
+	rows, err := q.SearchTransferActivity(ctx, params)
+	if err != nil {
+		return SearchTransfersResult{}, err
+	}
+
+	result := SearchTransfersResult{Transfers: rows}
+	if len(rows) > int(arg.Limit) {
+		result.Transfers = rows[:arg.Limit]
+		result.NextCursor, err = encodeTransferCursor(sort, result.Transfers[arg.Limit-1])
+	}
+	return result, err
+}
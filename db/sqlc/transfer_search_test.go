@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTransfers(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+
+	rent, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+		Memo:          "Monthly rent payment",
+		Reference:     util.RandomString(12),
+	})
+	require.NoError(t, err)
+
+	coffee, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        20,
+		Memo:          "coffee",
+	})
+	require.NoError(t, err)
+
+	held, err := store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionReview))
+	require.NoError(t, err)
+
+	search := func(arg SearchTransfersParams) []SearchTransferActivityRow {
+		if arg.Limit == 0 {
+			arg.Limit = 10
+		}
+		result, err := SearchTransfers(context.Background(), store, arg)
+		require.NoError(t, err)
+		return result.Transfers
+	}
+
+	// the sender sees the held transfer, newest first
+	rows := search(SearchTransfersParams{Username: from.Owner, AccountID: from.ID})
+	require.Len(t, rows, 3)
+	require.Equal(t, TransferActivityDecision, rows[0].Kind)
+	require.Equal(t, held.Decision.ID, rows[0].ID)
+	require.Equal(t, TransferStatusPendingReview, rows[0].Status)
+	require.Equal(t, coffee.Transfer.ID, rows[1].ID)
+	require.Equal(t, rent.Transfer.ID, rows[2].ID)
+	for _, row := range rows {
+		require.Equal(t, TransferDirectionOutgoing, row.Direction)
+	}
+
+	// the recipient only sees what was executed
+	rows = search(SearchTransfersParams{Username: to.Owner, AccountID: to.ID})
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.Equal(t, TransferStatusCompleted, row.Status)
+		require.Equal(t, TransferDirectionIncoming, row.Direction)
+	}
+
+	rows = search(SearchTransfersParams{Username: from.Owner, Query: "RENT"})
+	require.Len(t, rows, 1)
+	require.Equal(t, rent.Transfer.ID, rows[0].ID)
+
+	rows = search(SearchTransfersParams{Username: to.Owner, Reference: rent.Transfer.Reference})
+	require.Len(t, rows, 1)
+	require.Equal(t, rent.Transfer.ID, rows[0].ID)
+
+	rows = search(SearchTransfersParams{Username: from.Owner, MinAmount: 15, Status: TransferStatusCompleted})
+	require.Len(t, rows, 1)
+	require.Equal(t, coffee.Transfer.ID, rows[0].ID)
+
+	rows = search(SearchTransfersParams{Username: from.Owner, Direction: TransferDirectionIncoming})
+	require.Empty(t, rows)
+
+	// someone else's account is never searched
+	rows = search(SearchTransfersParams{Username: to.Owner, AccountID: from.ID})
+	require.Empty(t, rows)
+
+	// pages of one row walk through everything in order
+	var amounts []int64
+	arg := SearchTransfersParams{Username: from.Owner, AccountID: from.ID, Sort: TransferSortSmallest, Limit: 1}
+	for {
+		result, err := SearchTransfers(context.Background(), store, arg)
+		require.NoError(t, err)
+		for _, row := range result.Transfers {
+			amounts = append(amounts, row.Amount)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		arg.Cursor = result.NextCursor
+	}
+	require.Equal(t, []int64{10, 10, 20}, amounts)
+
+	_, err = SearchTransfers(context.Background(), store, SearchTransfersParams{Username: from.Owner, Cursor: arg.Cursor, Limit: 1})
+	require.ErrorIs(t, err, ErrInvalidTransferCursor)
+}
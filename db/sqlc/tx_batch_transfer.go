@@ -0,0 +1,364 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/lib/pq"
+)
+
+// Batch transfer modes
+const (
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModeBestEffort   = "best_effort"
+)
+
+// Batch transfer statuses
+const (
+	BatchStatusPending            = "pending"
+	BatchStatusProcessing         = "processing"
+	BatchStatusCompleted          = "completed"
+	BatchStatusPartiallyCompleted = "partially_completed"
+	BatchStatusFailed             = "failed"
+)
+
+// Batch transfer item statuses
+const (
+	BatchItemPending   = "pending"
+	BatchItemSucceeded = "succeeded"
+	BatchItemFailed    = "failed"
+	BatchItemSkipped   = "skipped"
+)
+
+// ErrInsufficientFunds is returned when a batch row would overdraw the source account
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// Batch rows the risk screener did not allow are failed with these errors
+var (
+	ErrBatchRowBlocked = errors.New("blocked by risk screening")
+	// ErrBatchRowRefused is for rows that needed a review, which a batch cannot wait for
+	ErrBatchRowRefused = errors.New("refused by risk screening, send it as a transfer to have it reviewed")
+)
+
+// BatchTransferRow is one payout of a batch
+type BatchTransferRow struct {
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Reference   string `json:"reference"`
+}
+
+type CreateBatchTransferTxParams struct {
+	Owner          string
+	FromAccountID  int64
+	Mode           string
+	IdempotencyKey sql.NullString
+	Rows           []BatchTransferRow
+	// Screenings is the risk decision on each row, in the order of Rows. The
+	// owner, accounts, amount, currency and reference are taken from the batch.
+	Screenings []CreateRiskDecisionParams
+}
+
+type CreateBatchTransferTxResult struct {
+	Batch BatchTransfer
+	Items []BatchTransferItem
+}
+
+// CreateBatchTransferTx stores a validated batch with the risk decision on
+// each row and schedules its processing through the outbox. Rows the screener
+// did not allow are failed right away and, in an all or nothing batch, the
+// other rows are skipped.
+func (store *SQLStore) CreateBatchTransferTx(ctx context.Context, arg CreateBatchTransferTxParams) (CreateBatchTransferTxResult, error) {
+
+	var result CreateBatchTransferTxResult
+
+	if len(arg.Screenings) != len(arg.Rows) {
+		return result, fmt.Errorf("got %d risk decisions for %d batch rows", len(arg.Screenings), len(arg.Rows))
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+		if err != nil {
+			return err
+		}
+
+		var totalAmount int64
+		for _, row := range arg.Rows {
+			totalAmount += row.Amount
+		}
+
+		result.Batch, err = q.CreateBatchTransfer(ctx, CreateBatchTransferParams{
+			Owner:          arg.Owner,
+			FromAccountID:  arg.FromAccountID,
+			Mode:           arg.Mode,
+			TotalCount:     int32(len(arg.Rows)),
+			TotalAmount:    totalAmount,
+			IdempotencyKey: arg.IdempotencyKey,
+		})
+		if err != nil {
+			return err
+		}
+
+		var refusedRow int32
+		result.Items = make([]BatchTransferItem, 0, len(arg.Rows))
+		for i, row := range arg.Rows {
+			params := arg.Screenings[i]
+			params.Username = arg.Owner
+			params.FromAccountID = fromAccount.ID
+			params.ToAccountID = row.ToAccountID
+			params.Amount = row.Amount
+			params.Currency = fromAccount.Currency
+			params.Reference = row.Reference
+			params.Metadata = orEmptyMetadata(params.Metadata)
+			params.ReviewStatus = sql.NullString{}
+			if params.Decision == RiskDecisionReview {
+				params.ReviewStatus = sql.NullString{String: ReviewStatusRefused, Valid: true}
+			}
+
+			decision, err := q.CreateRiskDecision(ctx, params)
+			if err != nil {
+				return err
+			}
+
+			item, err := q.CreateBatchTransferItem(ctx, CreateBatchTransferItemParams{
+				BatchID:        result.Batch.ID,
+				RowNumber:      int32(i + 1),
+				ToAccountID:    row.ToAccountID,
+				Amount:         row.Amount,
+				Reference:      row.Reference,
+				RiskDecisionID: sql.NullInt64{Int64: decision.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			if decision.Decision != RiskDecisionAllow {
+				rowErr := ErrBatchRowRefused
+				if decision.Decision == RiskDecisionBlock {
+					rowErr = ErrBatchRowBlocked
+				}
+
+				item, err = q.MarkBatchTransferItemFailed(ctx, MarkBatchTransferItemFailedParams{
+					ID:    item.ID,
+					Error: rowErr.Error(),
+				})
+				if err != nil {
+					return err
+				}
+				if refusedRow == 0 {
+					refusedRow = item.RowNumber
+				}
+			}
+			result.Items = append(result.Items, item)
+		}
+
+		if arg.Mode == BatchModeAllOrNothing && refusedRow > 0 {
+			err = q.SkipPendingBatchTransferItems(ctx, SkipPendingBatchTransferItemsParams{
+				BatchID: result.Batch.ID,
+				Error:   fmt.Sprintf("not paid because row %d failed", refusedRow),
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Items, err = q.ListBatchTransferItems(ctx, ListBatchTransferItemsParams{
+				BatchID: result.Batch.ID,
+				Limit:   int32(len(arg.Rows)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return addOutboxEvent(ctx, q, EventBatchTransferCreated, fmt.Sprintf("%s:%d", EventBatchTransferCreated, result.Batch.ID), BatchTransferCreatedEvent{
+			BatchID: result.Batch.ID,
+		})
+	})
+
+	return result, err
+}
+
+// BatchRowError reports the row that stopped a batch transfer
+type BatchRowError struct {
+	Item BatchTransferItem
+	Err  error
+}
+
+func (e *BatchRowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Item.RowNumber, e.Err)
+}
+
+func (e *BatchRowError) Unwrap() error {
+	return e.Err
+}
+
+type BatchTransferItemTxParams struct {
+	Batch  BatchTransfer
+	ItemID int64
+}
+
+type BatchTransferItemTxResult struct {
+	Item BatchTransferItem
+}
+
+// BatchTransferItemTx pays a single row in its own transaction. A row that was
+// already processed is returned unchanged, so redelivered tasks never pay twice.
+// A failure that a retry would run into again is returned as a *BatchRowError
+// and recorded on the row; a transient error leaves the row pending for a retry.
+func (store *SQLStore) BatchTransferItemTx(ctx context.Context, arg BatchTransferItemTxParams) (BatchTransferItemTxResult, error) {
+
+	var result BatchTransferItemTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Item, err = processBatchItem(ctx, q, arg.Batch, arg.ItemID)
+		return err
+	})
+
+	var rowErr *BatchRowError
+	if errors.As(err, &rowErr) {
+		result.Item, err = store.MarkBatchTransferItemFailed(ctx, MarkBatchTransferItemFailedParams{
+			ID:    arg.ItemID,
+			Error: rowErr.Err.Error(),
+		})
+		if err != nil {
+			return result, err
+		}
+		return result, rowErr
+	}
+
+	return result, err
+}
+
+type BatchTransferAllTxParams struct {
+	Batch BatchTransfer
+}
+
+type BatchTransferAllTxResult struct {
+	Items []BatchTransferItem
+}
+
+// BatchTransferAllTx pays every pending row of the batch in one transaction.
+// If a row fails nothing is paid: the row is marked failed and the others skipped.
+func (store *SQLStore) BatchTransferAllTx(ctx context.Context, arg BatchTransferAllTxParams) (BatchTransferAllTxResult, error) {
+
+	var result BatchTransferAllTxResult
+	batch := arg.Batch
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		items, err := q.ListPendingBatchTransferItems(ctx, batch.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]BatchTransferItem, 0, len(items))
+		for _, item := range items {
+			item, err = processBatchItem(ctx, q, batch, item.ID)
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, item)
+		}
+		return nil
+	})
+
+	var rowErr *BatchRowError
+	if !errors.As(err, &rowErr) {
+		return result, err
+	}
+	result.Items = nil
+
+	err = store.execTx(ctx, func(q *Queries) error {
+		_, err := q.MarkBatchTransferItemFailed(ctx, MarkBatchTransferItemFailedParams{
+			ID:    rowErr.Item.ID,
+			Error: rowErr.Err.Error(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.SkipPendingBatchTransferItems(ctx, SkipPendingBatchTransferItemsParams{
+			BatchID: batch.ID,
+			Error:   fmt.Sprintf("not paid because row %d failed", rowErr.Item.RowNumber),
+		})
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, rowErr
+}
+
+// processBatchItem pays one row using the caller's transaction. The row lock
+// and the status check make it safe to run more than once.
+func processBatchItem(ctx context.Context, q *Queries, batch BatchTransfer, itemID int64) (BatchTransferItem, error) {
+
+	item, err := q.GetBatchTransferItemForUpdate(ctx, itemID)
+	if err != nil {
+		return item, err
+	}
+
+	if item.Status != BatchItemPending {
+		return item, nil
+	}
+
+	result, err := transfer(ctx, q, TransferTxParams{
+		FromAccountID: batch.FromAccountID,
+		ToAccountID:   item.ToAccountID,
+		Amount:        item.Amount,
+		Reference:     item.Reference,
+	})
+	if err != nil {
+		// retrying cannot fix what is wrong with the row itself, so only
+		// transient errors leave it pending
+		if isTransientError(err) {
+			return item, err
+		}
+		return item, &BatchRowError{Item: item, Err: err}
+	}
+
+	if result.FromAccount.Balance < 0 {
+		return item, &BatchRowError{Item: item, Err: ErrInsufficientFunds}
+	}
+
+	if item.RiskDecisionID.Valid {
+		_, err = q.SetRiskDecisionTransfer(ctx, SetRiskDecisionTransferParams{
+			ID:         item.RiskDecisionID.Int64,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return item, err
+		}
+	}
+
+	return q.MarkBatchTransferItemSucceeded(ctx, MarkBatchTransferItemSucceededParams{
+		ID:         item.ID,
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+	})
+}
+
+// isTransientError reports whether err may go away when the transaction is
+// run again: a lost connection, a cancelled context, a serialization failure
+// or deadlock, or a database that is shutting down or out of resources
+func isTransientError(err error) bool {
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Class() {
+		case "08", "40", "53", "57", "58":
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,261 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// batchScreenings screens every row with decision
+func batchScreenings(rows []BatchTransferRow, decision string) []CreateRiskDecisionParams {
+
+	screenings := make([]CreateRiskDecisionParams, len(rows))
+	for i := range screenings {
+		screenings[i] = CreateRiskDecisionParams{
+			UserAgent: "test",
+			ClientIp:  "127.0.0.1",
+			Decision:  decision,
+			Hits:      json.RawMessage(`[]`),
+		}
+	}
+	return screenings
+}
+
+func createRandomBatchTransfer(t *testing.T, store Store, mode string, from Account, rows []BatchTransferRow) BatchTransfer {
+
+	result, err := store.CreateBatchTransferTx(context.Background(), CreateBatchTransferTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Mode:          mode,
+		Rows:          rows,
+		Screenings:    batchScreenings(rows, RiskDecisionAllow),
+	})
+	require.NoError(t, err)
+	require.Equal(t, BatchStatusPending, result.Batch.Status)
+	require.Equal(t, int32(len(rows)), result.Batch.TotalCount)
+	require.Len(t, result.Items, len(rows))
+
+	return result.Batch
+}
+
+func TestBatchTransferItemTx(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+	cash, err := store.GetInternalAccount(context.Background(), GetInternalAccountParams{
+		Name:     CashAccount,
+		Currency: from.Currency,
+	})
+	require.NoError(t, err)
+
+	batch := createRandomBatchTransfer(t, store, BatchModeBestEffort, from, []BatchTransferRow{
+		{ToAccountID: to.ID, Amount: 1, Reference: "first"},
+		{ToAccountID: to.ID, Amount: from.Balance + 1, Reference: "too much"},
+		{ToAccountID: cash.ID, Amount: 1, Reference: "general ledger account"},
+	})
+
+	items, err := store.ListPendingBatchTransferItems(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	result, err := store.BatchTransferItemTx(context.Background(), BatchTransferItemTxParams{Batch: batch, ItemID: items[0].ID})
+	require.NoError(t, err)
+	require.Equal(t, BatchItemSucceeded, result.Item.Status)
+	require.True(t, result.Item.TransferID.Valid)
+
+	// a redelivered row is not paid twice
+	again, err := store.BatchTransferItemTx(context.Background(), BatchTransferItemTxParams{Batch: batch, ItemID: items[0].ID})
+	require.NoError(t, err)
+	require.Equal(t, result.Item.TransferID, again.Item.TransferID)
+
+	_, err = store.BatchTransferItemTx(context.Background(), BatchTransferItemTxParams{Batch: batch, ItemID: items[1].ID})
+	var rowErr *BatchRowError
+	require.True(t, errors.As(err, &rowErr))
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// an error that is not from the database still fails the row, so the
+	// batch is not retried forever
+	result, err = store.BatchTransferItemTx(context.Background(), BatchTransferItemTxParams{Batch: batch, ItemID: items[2].ID})
+	require.True(t, errors.As(err, &rowErr))
+	require.ErrorIs(t, err, ErrGLAccount)
+	require.Equal(t, BatchItemFailed, result.Item.Status)
+
+	counts, err := store.CountBatchTransferItems(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), counts.Succeeded)
+	require.Equal(t, int32(2), counts.Failed)
+	require.Zero(t, counts.Pending)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-1, account.Balance)
+}
+
+func TestBatchTransferAllTx(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+
+	batch := createRandomBatchTransfer(t, store, BatchModeAllOrNothing, from, []BatchTransferRow{
+		{ToAccountID: to.ID, Amount: 1, Reference: "first"},
+		{ToAccountID: to.ID, Amount: from.Balance + 1, Reference: "too much"},
+		{ToAccountID: to.ID, Amount: 1, Reference: "last"},
+	})
+
+	_, err := store.BatchTransferAllTx(context.Background(), BatchTransferAllTxParams{Batch: batch})
+	var rowErr *BatchRowError
+	require.True(t, errors.As(err, &rowErr))
+	require.Equal(t, int32(2), rowErr.Item.RowNumber)
+
+	items, err := store.ListBatchTransferItems(context.Background(), ListBatchTransferItemsParams{BatchID: batch.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.Equal(t, BatchItemSkipped, items[0].Status)
+	require.Equal(t, BatchItemFailed, items[1].Status)
+	require.Equal(t, BatchItemSkipped, items[2].Status)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, account.Balance)
+}
+
+func TestCreateBatchTransferTxScreening(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+
+	rows := []BatchTransferRow{
+		{ToAccountID: to.ID, Amount: 1, Reference: "allowed"},
+		{ToAccountID: to.ID, Amount: 2, Reference: "held"},
+		{ToAccountID: to.ID, Amount: 3, Reference: "blocked"},
+	}
+	screenings := batchScreenings(rows, RiskDecisionAllow)
+	screenings[1].Decision = RiskDecisionReview
+	screenings[2].Decision = RiskDecisionBlock
+
+	result, err := store.CreateBatchTransferTx(context.Background(), CreateBatchTransferTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Mode:          BatchModeBestEffort,
+		Rows:          rows,
+		Screenings:    screenings,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 3)
+
+	require.Equal(t, BatchItemPending, result.Items[0].Status)
+	require.Equal(t, BatchItemFailed, result.Items[1].Status)
+	require.Equal(t, ErrBatchRowRefused.Error(), result.Items[1].Error)
+	require.Equal(t, BatchItemFailed, result.Items[2].Status)
+	require.Equal(t, ErrBatchRowBlocked.Error(), result.Items[2].Error)
+
+	// the held row cannot wait for a review, so it is refused
+	held, err := store.GetRiskDecision(context.Background(), result.Items[1].RiskDecisionID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, RiskDecisionReview, held.Decision)
+	require.Equal(t, sql.NullString{String: ReviewStatusRefused, Valid: true}, held.ReviewStatus)
+	require.Equal(t, from.Owner, held.Username)
+	require.Equal(t, from.Currency, held.Currency)
+	require.Equal(t, "held", held.Reference)
+	require.Equal(t, "test", held.UserAgent)
+
+	blocked, err := store.GetRiskDecision(context.Background(), result.Items[2].RiskDecisionID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, RiskDecisionBlock, blocked.Decision)
+	require.False(t, blocked.ReviewStatus.Valid)
+
+	paid, err := store.BatchTransferItemTx(context.Background(), BatchTransferItemTxParams{Batch: result.Batch, ItemID: result.Items[0].ID})
+	require.NoError(t, err)
+	require.Equal(t, BatchItemSucceeded, paid.Item.Status)
+
+	allowed, err := store.GetRiskDecision(context.Background(), result.Items[0].RiskDecisionID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, paid.Item.TransferID, allowed.TransferID)
+
+	for _, status := range []string{TransferStatusRefused, TransferStatusBlocked, TransferStatusCompleted} {
+		activity, err := SearchTransfers(context.Background(), store, SearchTransfersParams{
+			Username:  from.Owner,
+			AccountID: from.ID,
+			Status:    status,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+		require.Len(t, activity.Transfers, 1, status)
+	}
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-1, account.Balance)
+}
+
+func TestCreateBatchTransferTxScreeningAllOrNothing(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+
+	rows := []BatchTransferRow{
+		{ToAccountID: to.ID, Amount: 1, Reference: "first"},
+		{ToAccountID: to.ID, Amount: 2, Reference: "held"},
+		{ToAccountID: to.ID, Amount: 3, Reference: "last"},
+	}
+	screenings := batchScreenings(rows, RiskDecisionAllow)
+	screenings[1].Decision = RiskDecisionReview
+
+	result, err := store.CreateBatchTransferTx(context.Background(), CreateBatchTransferTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Mode:          BatchModeAllOrNothing,
+		Rows:          rows,
+		Screenings:    screenings,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 3)
+	require.Equal(t, BatchItemSkipped, result.Items[0].Status)
+	require.Equal(t, BatchItemFailed, result.Items[1].Status)
+	require.Equal(t, BatchItemSkipped, result.Items[2].Status)
+
+	// nothing is left to pay
+	_, err = store.BatchTransferAllTx(context.Background(), BatchTransferAllTxParams{Batch: result.Batch})
+	require.NoError(t, err)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, account.Balance)
+
+	_, err = store.CreateBatchTransferTx(context.Background(), CreateBatchTransferTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Mode:          BatchModeAllOrNothing,
+		Rows:          rows,
+	})
+	require.Error(t, err)
+}
+
+func TestIsTransientError(t *testing.T) {
+
+	require.True(t, isTransientError(context.DeadlineExceeded))
+	require.True(t, isTransientError(fmt.Errorf("wrapped: %w", driver.ErrBadConn)))
+	require.True(t, isTransientError(&pq.Error{Code: "40001"}))
+	require.True(t, isTransientError(&pq.Error{Code: "40P01"}))
+	require.True(t, isTransientError(&pq.Error{Code: "57P01"}))
+
+	require.False(t, isTransientError(&pq.Error{Code: "23514"}))
+	require.False(t, isTransientError(ErrGLAccount))
+	require.False(t, isTransientError(&UnbalancedJournalError{Currency: "USD", Sum: 1}))
+	require.False(t, isTransientError(&LimitExceededError{}))
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+type CreateSessionTxParams struct {
+	CreateSessionParams
+}
+
+type CreateSessionTxResult struct {
+	Session Session
+	// NewDevice is set when the user logged in before, but never with this user agent
+	NewDevice bool
+}
+
+// CreateSessionTx stores the session of a login. A login from a device the
+// user never used is written to the outbox so the user can be told about it.
+func (store *SQLStore) CreateSessionTx(ctx context.Context, arg CreateSessionTxParams) (CreateSessionTxResult, error) {
+
+	var result CreateSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		counts, err := q.CountUserDeviceSessions(ctx, CountUserDeviceSessionsParams{
+			UserAgent: arg.UserAgent,
+			Username:  arg.Username,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Session, err = q.CreateSession(ctx, arg.CreateSessionParams)
+		if err != nil {
+			return err
+		}
+
+		result.NewDevice = counts.Sessions > 0 && counts.DeviceSessions == 0
+		if !result.NewDevice {
+			return nil
+		}
+
+		return addOutboxEvent(ctx, q, EventNewDeviceLogin, fmt.Sprintf("%s:%s", EventNewDeviceLogin, result.Session.ID), NewDeviceLoginEvent{
+			Username:  result.Session.Username,
+			SessionID: result.Session.ID.String(),
+			UserAgent: result.Session.UserAgent,
+			ClientIP:  result.Session.ClientIp,
+		})
+	})
+
+	return result, err
+}
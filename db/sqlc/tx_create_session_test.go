@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createDeviceSession(t *testing.T, store Store, username string, userAgent string) CreateSessionTxResult {
+
+	result, err := store.CreateSessionTx(context.Background(), CreateSessionTxParams{
+		CreateSessionParams: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     username,
+			RefreshToken: "refresh",
+			UserAgent:    userAgent,
+			ClientIp:     "127.0.0.1",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, username, result.Session.Username)
+
+	return result
+}
+
+func TestCreateSessionTx(t *testing.T) {
+
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	// the first login is not from a new device, there is nothing to compare with
+	require.False(t, createDeviceSession(t, store, user.Username, "laptop").NewDevice)
+	require.False(t, createDeviceSession(t, store, user.Username, "laptop").NewDevice)
+
+	result := createDeviceSession(t, store, user.Username, "phone")
+	require.True(t, result.NewDevice)
+
+	require.False(t, createDeviceSession(t, store, user.Username, "phone").NewDevice)
+}
+
+func TestNotificationEnabled(t *testing.T) {
+
+	preferences := []NotificationPreference{
+		{EventType: NotificationTransferReceived, Channel: ChannelEmail, Enabled: false},
+		{EventType: NotificationNewDeviceLogin, Channel: ChannelSMS, Enabled: true},
+	}
+
+	require.True(t, NotificationEnabled(preferences, NotificationTransferReceived, ChannelInApp))
+	require.False(t, NotificationEnabled(preferences, NotificationTransferReceived, ChannelEmail))
+	require.False(t, NotificationEnabled(preferences, NotificationTransferReceived, ChannelSMS))
+	require.True(t, NotificationEnabled(preferences, NotificationNewDeviceLogin, ChannelSMS))
+	require.True(t, NotificationEnabled(nil, NotificationPaymentRequestSettled, ChannelEmail))
+}
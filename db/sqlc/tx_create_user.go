@@ -1,10 +1,14 @@
 package db
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type CreateUserTxParams struct {
 	CreateUserParams
-	AfterCreate func(user User) error
+	// Role overrides the default role of the new user when set
+	Role string
 }
 
 type CreateUserTxResult struct {
@@ -22,7 +26,21 @@ func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams)
 		if err != nil {
 			return err
 		}
-		return arg.AfterCreate(result.User)
+
+		if arg.Role != "" {
+			result.User, err = q.UpdateUserRole(ctx, UpdateUserRoleParams{
+				Username: result.User.Username,
+				Role:     arg.Role,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return addOutboxEvent(ctx, q, EventUserCreated, fmt.Sprintf("%s:%s", EventUserCreated, result.User.Username), UserCreatedEvent{
+			Username: result.User.Username,
+			Email:    result.User.Email,
+		})
 	})
 
 	return result, err
@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// KYC statuses of users
+const (
+	KycStatusUnverified = "unverified"
+	KycStatusPending    = "pending"
+	KycStatusVerified   = "verified"
+	KycStatusRejected   = "rejected"
+)
+
+// KYC tiers. Tier 0 users can hold one account and send up to the tier0 limits.
+const (
+	KycTierNone  int32 = 0
+	KycTierBasic int32 = 1
+	KycTierFull  int32 = 2
+)
+
+// KycTierNoneMaxAccounts is the number of accounts a tier 0 user can hold
+const KycTierNoneMaxAccounts = 1
+
+// Kinds of KYC documents
+const (
+	KycDocumentPassport       = "passport"
+	KycDocumentNationalID     = "national_id"
+	KycDocumentDriversLicense = "drivers_license"
+	KycDocumentProofOfAddress = "proof_of_address"
+)
+
+// Review statuses of KYC documents
+const (
+	KycDocumentStatusPending  = "pending"
+	KycDocumentStatusApproved = "approved"
+	KycDocumentStatusRejected = "rejected"
+)
+
+var (
+	// ErrKycAccountLimit is returned when a tier 0 user opens another account
+	ErrKycAccountLimit = errors.New("complete identity verification to open more than one account")
+	// ErrKycDocumentNotPending is returned when a document was already reviewed
+	ErrKycDocumentNotPending = errors.New("document was already reviewed")
+)
+
+// IsKycDocumentKind reports whether kind is a document users can submit
+func IsKycDocumentKind(kind string) bool {
+	switch kind {
+	case KycDocumentPassport, KycDocumentNationalID, KycDocumentDriversLicense, KycDocumentProofOfAddress:
+		return true
+	}
+	return false
+}
+
+// EffectiveKycTier is the tier a user is treated as. Until the email the user
+// signed up with is verified that is tier 0, whatever the review granted.
+func EffectiveKycTier(tier int32, emailVerified bool) int32 {
+	if !emailVerified {
+		return KycTierNone
+	}
+	return tier
+}
+
+type CreateAccountTxParams struct {
+	CreateAccountParams
+	// OpeningBalance is credited to the new account in a journal against the
+	// cash account of the general ledger
+	OpeningBalance int64
+}
+
+// CreateAccountTx opens an account, unless the owner is at tier 0 and already
+// has one. The owner row is locked so concurrent requests are counted in turn.
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error) {
+
+	var account Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		kyc, err := q.GetUserKycForUpdate(ctx, arg.Owner)
+		if err != nil {
+			return err
+		}
+
+		if EffectiveKycTier(kyc.KycTier, kyc.EmailVerified) == KycTierNone {
+			count, err := q.CountAccountsByOwner(ctx, arg.Owner)
+			if err != nil {
+				return err
+			}
+			if count >= KycTierNoneMaxAccounts {
+				return ErrKycAccountLimit
+			}
+		}
+
+		account, err = q.CreateAccount(ctx, arg.CreateAccountParams)
+		if err != nil || arg.OpeningBalance == 0 {
+			return err
+		}
+
+		cash, err := glAccount(ctx, q, CashAccount, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			JournalType: EntryTypeAdjustment,
+			Description: "opening balance",
+			Legs: []JournalLeg{
+				{AccountID: cash.ID, Amount: -arg.OpeningBalance},
+				{AccountID: account.ID, Amount: arg.OpeningBalance},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		account = journal.Accounts[account.ID]
+		return nil
+	})
+
+	return account, err
+}
+
+// SubmitKycDocumentTx records a document the user uploaded and queues the
+// user for review
+func (store *SQLStore) SubmitKycDocumentTx(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error) {
+
+	var document KycDocument
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		kyc, err := q.GetUserKycForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		document, err = q.CreateKycDocument(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if kyc.KycStatus == KycStatusVerified || kyc.KycStatus == KycStatusPending {
+			return nil
+		}
+
+		_, err = q.UpdateUserKyc(ctx, UpdateUserKycParams{
+			Username:  arg.Username,
+			KycStatus: KycStatusPending,
+			KycTier:   kyc.KycTier,
+		})
+		return err
+	})
+
+	return document, err
+}
+
+type ReviewKycDocumentTxParams struct {
+	DocumentID int64
+	Reviewer   string
+	Approve    bool
+	// Tier is granted on approval. A user never drops to a lower tier.
+	Tier int32
+	Note string
+}
+
+type ReviewKycDocumentTxResult struct {
+	Document KycDocument
+	User     User
+}
+
+// ReviewKycDocumentTx approves or rejects a pending document. Approving sets
+// the user verified at the given tier; rejecting leaves an already verified
+// user as they are.
+func (store *SQLStore) ReviewKycDocumentTx(ctx context.Context, arg ReviewKycDocumentTxParams) (ReviewKycDocumentTxResult, error) {
+
+	var result ReviewKycDocumentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		document, err := q.GetKycDocumentForUpdate(ctx, arg.DocumentID)
+		if err != nil {
+			return err
+		}
+
+		if document.Status != KycDocumentStatusPending {
+			return ErrKycDocumentNotPending
+		}
+
+		status := KycDocumentStatusRejected
+		if arg.Approve {
+			status = KycDocumentStatusApproved
+		}
+
+		result.Document, err = q.ReviewKycDocument(ctx, ReviewKycDocumentParams{
+			ID:         document.ID,
+			Status:     status,
+			ReviewedBy: sql.NullString{String: arg.Reviewer, Valid: true},
+			ReviewNote: arg.Note,
+		})
+		if err != nil {
+			return err
+		}
+
+		kyc, err := q.GetUserKycForUpdate(ctx, document.Username)
+		if err != nil {
+			return err
+		}
+
+		update := UpdateUserKycParams{
+			Username:  document.Username,
+			KycStatus: kyc.KycStatus,
+			KycTier:   kyc.KycTier,
+		}
+		switch {
+		case arg.Approve:
+			update.KycStatus = KycStatusVerified
+			if arg.Tier > update.KycTier {
+				update.KycTier = arg.Tier
+			}
+		case kyc.KycStatus != KycStatusVerified:
+			update.KycStatus = KycStatusRejected
+		}
+
+		result.User, err = q.UpdateUserKyc(ctx, update)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyKyc verifies the email of username and raises the user to tier
+func verifyKyc(t *testing.T, username string, tier int32) {
+
+	user, err := testQueries.GetUser(context.Background(), username)
+	require.NoError(t, err)
+
+	_, err = testQueries.UpsertUserAlias(context.Background(), UpsertUserAliasParams{
+		Kind:       AliasKindEmail,
+		Value:      strings.ToLower(user.Email),
+		Username:   username,
+		VerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateUserKyc(context.Background(), UpdateUserKycParams{
+		Username:  username,
+		KycStatus: KycStatusVerified,
+		KycTier:   tier,
+	})
+	require.NoError(t, err)
+}
+
+func submitKycDocument(t *testing.T, store Store, username string) KycDocument {
+
+	document, err := store.SubmitKycDocumentTx(context.Background(), CreateKycDocumentParams{
+		Username:    username,
+		Kind:        KycDocumentPassport,
+		FileName:    "passport.png",
+		ContentType: "image/png",
+		SizeBytes:   10,
+		Sha256:      util.RandomString(64),
+		StorageKey:  username + "/" + util.RandomString(32),
+	})
+	require.NoError(t, err)
+	require.Equal(t, KycDocumentStatusPending, document.Status)
+
+	return document
+}
+
+func TestCreateAccountTxKycTier(t *testing.T) {
+
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	arg := CreateAccountTxParams{CreateAccountParams: CreateAccountParams{Owner: user.Username, Currency: util.USD}}
+	_, err := store.CreateAccountTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Currency = util.EUR
+	_, err = store.CreateAccountTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrKycAccountLimit)
+
+	// an approved review does not count until the email is verified
+	_, err = testQueries.UpdateUserKyc(context.Background(), UpdateUserKycParams{
+		Username:  user.Username,
+		KycStatus: KycStatusVerified,
+		KycTier:   KycTierBasic,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateAccountTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrKycAccountLimit)
+
+	verifyKyc(t, user.Username, KycTierBasic)
+
+	_, err = store.CreateAccountTx(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func TestReviewKycDocumentTx(t *testing.T) {
+
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	admin := createRandomUser(t)
+
+	rejected := submitKycDocument(t, store, user.Username)
+
+	kyc, err := testQueries.GetUserKyc(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, KycStatusPending, kyc.KycStatus)
+	require.Equal(t, KycTierNone, kyc.KycTier)
+
+	result, err := store.ReviewKycDocumentTx(context.Background(), ReviewKycDocumentTxParams{
+		DocumentID: rejected.ID,
+		Reviewer:   admin.Username,
+		Note:       "blurry",
+	})
+	require.NoError(t, err)
+	require.Equal(t, KycDocumentStatusRejected, result.Document.Status)
+	require.Equal(t, KycStatusRejected, result.User.KycStatus)
+	require.Equal(t, KycTierNone, result.User.KycTier)
+
+	_, err = store.ReviewKycDocumentTx(context.Background(), ReviewKycDocumentTxParams{DocumentID: rejected.ID, Reviewer: admin.Username})
+	require.ErrorIs(t, err, ErrKycDocumentNotPending)
+
+	approved := submitKycDocument(t, store, user.Username)
+	result, err = store.ReviewKycDocumentTx(context.Background(), ReviewKycDocumentTxParams{
+		DocumentID: approved.ID,
+		Reviewer:   admin.Username,
+		Approve:    true,
+		Tier:       KycTierFull,
+	})
+	require.NoError(t, err)
+	require.Equal(t, KycDocumentStatusApproved, result.Document.Status)
+	require.Equal(t, KycStatusVerified, result.User.KycStatus)
+	require.Equal(t, KycTierFull, result.User.KycTier)
+
+	// a later rejection or lower approval keeps the user verified at the higher tier
+	result, err = store.ReviewKycDocumentTx(context.Background(), ReviewKycDocumentTxParams{
+		DocumentID: submitKycDocument(t, store, user.Username).ID,
+		Reviewer:   admin.Username,
+		Approve:    true,
+		Tier:       KycTierBasic,
+	})
+	require.NoError(t, err)
+	require.Equal(t, KycStatusVerified, result.User.KycStatus)
+	require.Equal(t, KycTierFull, result.User.KycTier)
+}
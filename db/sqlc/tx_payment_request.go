@@ -0,0 +1,207 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Statuses of payment requests
+const (
+	PaymentRequestStatusPending  = "pending"
+	PaymentRequestStatusPaid     = "paid"
+	PaymentRequestStatusDeclined = "declined"
+)
+
+var (
+	// ErrPaymentRequestNotPending is returned when a request was already paid or declined
+	ErrPaymentRequestNotPending = errors.New("payment request is not pending")
+	// ErrPaymentRequestExpired is returned when a request is paid after it expired
+	ErrPaymentRequestExpired = errors.New("payment request has expired")
+	// ErrPaymentAccount is returned when a request is paid from an account in
+	// another currency, or from the account it pays into
+	ErrPaymentAccount = errors.New("account cannot pay the payment request")
+	// ErrPaymentNeedsApproval is returned when a request is paid from an account
+	// that needs a second signer for the amount
+	ErrPaymentNeedsApproval = errors.New("payment needs a second signer of the account")
+)
+
+type PayPaymentRequestTxParams struct {
+	ID            int64
+	Payer         string
+	FromAccountID int64
+	// Screening is the risk decision on the payment. The payer, accounts,
+	// amount, currency and memo are taken from the request.
+	Screening CreateRiskDecisionParams
+}
+
+type PayPaymentRequestTxResult struct {
+	Request  PaymentRequest
+	Decision RiskDecision
+	// Transfer is only set when the payment was allowed and the request paid
+	Transfer TransferTxResult
+}
+
+// PayPaymentRequestTx pays a pending payment request from an account the
+// payer can sign for. The risk decision, the transfer and the request are
+// settled in the same transaction, and the requester is notified through the
+// outbox. A request cannot wait for a review or a second signer, so a payment
+// that screening does not allow only records its decision, as refused when it
+// needed a review, and leaves the request pending. One that needs a second
+// signer fails with ErrPaymentNeedsApproval.
+func (store *SQLStore) PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error) {
+
+	var result PayPaymentRequestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		request, err := pendingPaymentRequest(ctx, q, arg.ID, arg.Payer)
+		if err != nil {
+			return err
+		}
+
+		if request.ExpiresAt.Before(time.Now()) {
+			return ErrPaymentRequestExpired
+		}
+
+		account, err := q.GetAccount(ctx, arg.FromAccountID)
+		if err != nil {
+			return err
+		}
+
+		role, err := MemberRole(ctx, q, account, arg.Payer)
+		if err != nil {
+			return err
+		}
+		if !CanSign(role) {
+			return ErrNotSigner
+		}
+
+		if account.Currency != request.Currency || account.ID == request.ToAccountID {
+			return ErrPaymentAccount
+		}
+
+		needed, err := needsApproval(ctx, q, account, request.Amount)
+		if err != nil {
+			return err
+		}
+		if needed {
+			return ErrPaymentNeedsApproval
+		}
+
+		params := arg.Screening
+		params.Username = arg.Payer
+		params.FromAccountID = account.ID
+		params.ToAccountID = request.ToAccountID
+		params.Amount = request.Amount
+		params.Currency = request.Currency
+		params.Memo = request.Memo
+		params.Metadata = orEmptyMetadata(params.Metadata)
+		params.ReviewStatus = sql.NullString{}
+		if params.Decision == RiskDecisionReview {
+			params.ReviewStatus = sql.NullString{String: ReviewStatusRefused, Valid: true}
+		}
+
+		result.Decision, err = q.CreateRiskDecision(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if result.Decision.Decision != RiskDecisionAllow {
+			return nil
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: account.ID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+			Memo:          request.Memo,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Decision, err = q.SetRiskDecisionTransfer(ctx, SetRiskDecisionTransferParams{
+			ID:         result.Decision.ID,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Request, err = closePaymentRequest(ctx, q, SettlePaymentRequestParams{
+			ID:         request.ID,
+			Status:     PaymentRequestStatusPaid,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type DeclinePaymentRequestTxParams struct {
+	ID    int64
+	Payer string
+}
+
+// DeclinePaymentRequestTx declines a pending payment request and notifies the
+// requester through the outbox. Expired requests can still be declined.
+func (store *SQLStore) DeclinePaymentRequestTx(ctx context.Context, arg DeclinePaymentRequestTxParams) (PaymentRequest, error) {
+
+	var result PaymentRequest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := pendingPaymentRequest(ctx, q, arg.ID, arg.Payer)
+		if err != nil {
+			return err
+		}
+
+		result, err = closePaymentRequest(ctx, q, SettlePaymentRequestParams{
+			ID:     arg.ID,
+			Status: PaymentRequestStatusDeclined,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// pendingPaymentRequest locks a pending request sent to payer. Requests sent
+// to someone else are reported as not found.
+func pendingPaymentRequest(ctx context.Context, q *Queries, id int64, payer string) (PaymentRequest, error) {
+
+	request, err := q.GetPaymentRequestForUpdate(ctx, id)
+	if err != nil {
+		return request, err
+	}
+
+	if request.Payer != payer {
+		return request, sql.ErrNoRows
+	}
+
+	if request.Status != PaymentRequestStatusPending {
+		return request, ErrPaymentRequestNotPending
+	}
+
+	return request, nil
+}
+
+// closePaymentRequest records the outcome of a request and the event that
+// notifies the requester
+func closePaymentRequest(ctx context.Context, q *Queries, arg SettlePaymentRequestParams) (PaymentRequest, error) {
+
+	request, err := q.SettlePaymentRequest(ctx, arg)
+	if err != nil {
+		return request, err
+	}
+
+	err = addOutboxEvent(ctx, q, EventPaymentRequestSettled, fmt.Sprintf("%s:%d", EventPaymentRequestSettled, request.ID), PaymentRequestSettledEvent{
+		RequestID:  request.ID,
+		Requester:  request.Requester,
+		Status:     request.Status,
+		TransferID: request.TransferID.Int64,
+	})
+	return request, err
+}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPaymentRequest(t *testing.T, to Account, payer string, expiresAt time.Time) PaymentRequest {
+
+	request, err := testQueries.CreatePaymentRequest(context.Background(), CreatePaymentRequestParams{
+		Requester:   to.Owner,
+		Payer:       payer,
+		ToAccountID: to.ID,
+		Amount:      10,
+		Currency:    to.Currency,
+		Memo:        "lunch",
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPending, request.Status)
+
+	return request
+}
+
+// allowPayment is the risk decision of a payment screening found nothing wrong with
+var allowPayment = CreateRiskDecisionParams{Decision: RiskDecisionAllow, Hits: json.RawMessage(`[]`)}
+
+func TestPayPaymentRequestTx(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	to := createRandomAccount(t)
+	payer := createRandomAccountLike(t, to)
+
+	// pay from an account of the payer in the currency of the request
+	verifyKyc(t, payer.Owner, KycTierBasic)
+	from, err := store.CreateAccountTx(context.Background(), CreateAccountTxParams{
+		CreateAccountParams: CreateAccountParams{
+			Owner:    payer.Owner,
+			Currency: to.Currency,
+			Product:  "savings",
+		},
+		OpeningBalance: 1000,
+	})
+	require.NoError(t, err)
+
+	request := createRandomPaymentRequest(t, to, from.Owner, time.Now().Add(time.Hour))
+
+	// only the payer can pay
+	_, err = store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{ID: request.ID, Payer: to.Owner, FromAccountID: to.ID, Screening: allowPayment})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	result, err := store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{ID: request.ID, Payer: from.Owner, FromAccountID: from.ID, Screening: allowPayment})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPaid, result.Request.Status)
+	require.Equal(t, sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}, result.Request.TransferID)
+	require.True(t, result.Request.SettledAt.Valid)
+	require.Equal(t, to.Balance+10, result.Transfer.ToAccount.Balance)
+	require.Equal(t, from.Owner, result.Decision.Username)
+	require.Equal(t, sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}, result.Decision.TransferID)
+
+	_, err = store.DeclinePaymentRequestTx(context.Background(), DeclinePaymentRequestTxParams{ID: request.ID, Payer: from.Owner})
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+
+	expired := createRandomPaymentRequest(t, to, from.Owner, time.Now().Add(-time.Minute))
+	_, err = store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{ID: expired.ID, Payer: from.Owner, FromAccountID: from.ID, Screening: allowPayment})
+	require.ErrorIs(t, err, ErrPaymentRequestExpired)
+
+	declined, err := store.DeclinePaymentRequestTx(context.Background(), DeclinePaymentRequestTxParams{ID: expired.ID, Payer: from.Owner})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusDeclined, declined.Status)
+	require.False(t, declined.TransferID.Valid)
+}
+
+func TestPayPaymentRequestTxScreened(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	to := createRandomAccount(t)
+	from := createRandomAccountLike(t, to)
+	request := createRandomPaymentRequest(t, to, from.Owner, time.Now().Add(time.Hour))
+
+	// a payment screening does not allow is recorded and the request stays pending
+	result, err := store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		ID:            request.ID,
+		Payer:         from.Owner,
+		FromAccountID: from.ID,
+		Screening:     CreateRiskDecisionParams{Decision: RiskDecisionBlock, Hits: json.RawMessage(`[]`)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, RiskDecisionBlock, result.Decision.Decision)
+	require.Equal(t, request.Amount, result.Decision.Amount)
+	require.Equal(t, request.ToAccountID, result.Decision.ToAccountID)
+	require.False(t, result.Decision.TransferID.Valid)
+	require.Zero(t, result.Transfer.Transfer.ID)
+
+	pending, err := testQueries.GetPaymentRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPending, pending.Status)
+
+	// a payment that needed a review is refused, not left waiting for one
+	result, err = store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		ID:            request.ID,
+		Payer:         from.Owner,
+		FromAccountID: from.ID,
+		Screening:     CreateRiskDecisionParams{Decision: RiskDecisionReview, Hits: json.RawMessage(`[]`)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullString{String: ReviewStatusRefused, Valid: true}, result.Decision.ReviewStatus)
+
+	activity, err := SearchTransfers(context.Background(), store, SearchTransfersParams{
+		Username:  from.Owner,
+		AccountID: from.ID,
+		Status:    TransferStatusRefused,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, activity.Transfers, 1)
+	require.Equal(t, result.Decision.ID, activity.Transfers[0].ID)
+}
+
+func TestPayPaymentRequestTxJointAccount(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	to := createRandomAccount(t)
+	joint := createRandomAccountLike(t, to)
+	signer := addActiveMember(t, joint, MemberRoleSigner)
+
+	_, err := testQueries.SetAccountApprovalThreshold(context.Background(), SetAccountApprovalThresholdParams{
+		ID:                joint.ID,
+		ApprovalThreshold: 10,
+	})
+	require.NoError(t, err)
+
+	// the request is at the threshold, so paying it needs the other signer
+	request := createRandomPaymentRequest(t, to, signer.Username, time.Now().Add(time.Hour))
+	_, err = store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		ID:            request.ID,
+		Payer:         signer.Username,
+		FromAccountID: joint.ID,
+		Screening:     allowPayment,
+	})
+	require.ErrorIs(t, err, ErrPaymentNeedsApproval)
+
+	account, err := testQueries.GetAccount(context.Background(), joint.ID)
+	require.NoError(t, err)
+	require.Equal(t, joint.Balance, account.Balance)
+
+	pending, err := testQueries.GetPaymentRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPending, pending.Status)
+
+	// under the threshold the signer pays alone
+	_, err = testQueries.SetAccountApprovalThreshold(context.Background(), SetAccountApprovalThresholdParams{
+		ID:                joint.ID,
+		ApprovalThreshold: 11,
+	})
+	require.NoError(t, err)
+
+	result, err := store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		ID:            request.ID,
+		Payer:         signer.Username,
+		FromAccountID: joint.ID,
+		Screening:     allowPayment,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPaid, result.Request.Status)
+}
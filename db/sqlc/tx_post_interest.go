@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MicrosPerMinorUnit is the precision interest is accrued with
+const MicrosPerMinorUnit = 1_000_000
+
+// InterestExpenseAccount names the internal account interest is paid from
+const InterestExpenseAccount = "interest_expense"
+
+type PostInterestTxParams struct {
+	AccountID int64
+	// Month is the first day of the month to post
+	Month time.Time
+}
+
+type PostInterestTxResult struct {
+	Posting InterestPosting
+	// AlreadyPosted is true when the month had been posted before
+	AlreadyPosted bool
+	FromEntry     Entry
+	ToEntry       Entry
+}
+
+// PostInterestTx pays the interest an account accrued during a month from the
+// interest expense account of its currency. Whole minor units are paid and
+// the remainder is carried to the next month. A month is only ever posted once.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		accrued, err := q.SumInterestAccruals(ctx, SumInterestAccrualsParams{
+			AccountID: account.ID,
+			FromDay:   arg.Month,
+			ToDay:     arg.Month.AddDate(0, 1, -1),
+		})
+		if err != nil {
+			return err
+		}
+
+		carriedIn, err := q.GetLastInterestCarry(ctx, GetLastInterestCarryParams{
+			AccountID: account.ID,
+			Month:     arg.Month,
+		})
+		if err != nil {
+			return err
+		}
+
+		total := accrued + carriedIn
+		result.Posting, err = q.CreateInterestPosting(ctx, CreateInterestPostingParams{
+			AccountID:        account.ID,
+			Month:            arg.Month,
+			AccruedMicros:    accrued,
+			CarriedInMicros:  carriedIn,
+			Amount:           total / MicrosPerMinorUnit,
+			CarriedOutMicros: total % MicrosPerMinorUnit,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			result.AlreadyPosted = true
+			result.Posting, err = q.GetInterestPosting(ctx, GetInterestPostingParams{
+				AccountID: account.ID,
+				Month:     arg.Month,
+			})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		amount := result.Posting.Amount
+		if amount == 0 {
+			return nil
+		}
+
+		expense, err := q.GetInternalAccount(ctx, GetInternalAccountParams{
+			Name:     InterestExpenseAccount,
+			Currency: account.Currency,
+		})
+		if err != nil {
+			return err
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			JournalType: EntryTypeInterest,
+			Description: fmt.Sprintf("interest for %s", arg.Month.Format("2006-01")),
+			Legs: []JournalLeg{
+				{AccountID: expense.ID, Amount: -amount},
+				{AccountID: account.ID, Amount: amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		result.FromEntry = journal.Entries[0]
+		result.ToEntry = journal.Entries[1]
+
+		result.Posting, err = q.SetInterestPostingEntries(ctx, SetInterestPostingEntriesParams{
+			ID:          result.Posting.ID,
+			FromEntryID: sql.NullInt64{Int64: result.FromEntry.ID, Valid: true},
+			ToEntryID:   sql.NullInt64{Int64: result.ToEntry.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func accrueInterest(t *testing.T, account Account, day time.Time, micros int64) int64 {
+
+	rows, err := testQueries.CreateInterestAccrual(context.Background(), CreateInterestAccrualParams{
+		AccountID:     account.ID,
+		Day:           day,
+		Balance:       account.Balance,
+		AnnualRateBps: 250,
+		DayCount:      "actual/365",
+		AmountMicros:  micros,
+	})
+	require.NoError(t, err)
+	return rows
+}
+
+func TestPostInterestTx(t *testing.T) {
+
+	store := NewStore(testDB)
+	account := createRandomAccount(t)
+
+	january := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february := january.AddDate(0, 1, 0)
+
+	require.Equal(t, int64(1), accrueInterest(t, account, january, 700_000))
+	require.Equal(t, int64(1), accrueInterest(t, account, january.AddDate(0, 0, 1), 700_000))
+	// accruing the same day twice changes nothing
+	require.Zero(t, accrueInterest(t, account, january, 700_000))
+
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{AccountID: account.ID, Month: january})
+	require.NoError(t, err)
+	require.False(t, result.AlreadyPosted)
+	require.Equal(t, int64(1_400_000), result.Posting.AccruedMicros)
+	require.Equal(t, int64(1), result.Posting.Amount)
+	require.Equal(t, int64(400_000), result.Posting.CarriedOutMicros)
+	require.Equal(t, int64(1), result.ToEntry.Amount)
+	require.Equal(t, int64(-1), result.FromEntry.Amount)
+	require.Equal(t, EntryTypeInterest, result.ToEntry.EntryType)
+	require.Equal(t, EntryTypeInterest, result.FromEntry.EntryType)
+	require.Equal(t, result.ToEntry.ID, result.Posting.ToEntryID.Int64)
+
+	// a posted month is neither accrued nor posted again
+	require.Zero(t, accrueInterest(t, account, january.AddDate(0, 0, 2), 700_000))
+
+	again, err := store.PostInterestTx(context.Background(), PostInterestTxParams{AccountID: account.ID, Month: january})
+	require.NoError(t, err)
+	require.True(t, again.AlreadyPosted)
+	require.Equal(t, result.Posting.ID, again.Posting.ID)
+
+	// the remainder of january is carried into february
+	require.Equal(t, int64(1), accrueInterest(t, account, february, 600_000))
+
+	result, err = store.PostInterestTx(context.Background(), PostInterestTxParams{AccountID: account.ID, Month: february})
+	require.NoError(t, err)
+	require.Equal(t, int64(400_000), result.Posting.CarriedInMicros)
+	require.Equal(t, int64(1), result.Posting.Amount)
+	require.Zero(t, result.Posting.CarriedOutMicros)
+
+	updated, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+2, updated.Balance)
+}
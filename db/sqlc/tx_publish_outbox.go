@@ -0,0 +1,56 @@
+package db
+
+import "context"
+
+// PublishOutboxTxParams contains the input parameters of the publish outbox transaction
+type PublishOutboxTxParams struct {
+	Limit   int32
+	Publish func(event Outbox) error
+}
+
+// PublishOutboxTxResult is the result of the publish outbox transaction
+type PublishOutboxTxResult struct {
+	Published []Outbox
+	Failed    []Outbox
+}
+
+// PublishOutboxTx locks a batch of pending outbox events and hands each one to Publish.
+// Rows are locked with SKIP LOCKED so several relays can run side by side. An event
+// whose publish fails is rescheduled with backoff instead of failing the whole batch.
+func (store *SQLStore) PublishOutboxTx(ctx context.Context, arg PublishOutboxTxParams) (PublishOutboxTxResult, error) {
+
+	var result PublishOutboxTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		events, err := q.ListPendingOutboxEvents(ctx, arg.Limit)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+
+			if publishErr := arg.Publish(event); publishErr != nil {
+				event, err = q.MarkOutboxEventFailed(ctx, MarkOutboxEventFailedParams{
+					ID:        event.ID,
+					LastError: publishErr.Error(),
+				})
+				if err != nil {
+					return err
+				}
+				result.Failed = append(result.Failed, event)
+				continue
+			}
+
+			event, err = q.MarkOutboxEventPublished(ctx, event.ID)
+			if err != nil {
+				return err
+			}
+			result.Published = append(result.Published, event)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
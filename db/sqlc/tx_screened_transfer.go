@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Risk screening decisions
+const (
+	RiskDecisionAllow  = "allow"
+	RiskDecisionReview = "review"
+	RiskDecisionBlock  = "block"
+)
+
+// Review statuses of transfers held by risk screening
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+	// ReviewStatusRefused is set instead of pending when the transfer cannot
+	// wait for a review, like the payment of a payment request
+	ReviewStatusRefused = "refused"
+)
+
+// ErrReviewNotPending is returned when a held transfer was already approved or rejected
+var ErrReviewNotPending = errors.New("transfer is not pending review")
+
+type ScreenedTransferTxParams struct {
+	CreateRiskDecisionParams
+}
+
+type ScreenedTransferTxResult struct {
+	Decision RiskDecision
+	// Approval is only set when the allowed transfer waits for a second signer
+	Approval TransferApproval
+	// Transfer is only set when the transfer was allowed and executed
+	Transfer TransferTxResult
+}
+
+// ScreenedTransferTx stores a risk decision and acts on it: an allowed
+// transfer is executed in the same transaction, unless its account needs a
+// second signer for it, a transfer under review is held as pending and a
+// blocked one is only recorded.
+func (store *SQLStore) ScreenedTransferTx(ctx context.Context, arg ScreenedTransferTxParams) (ScreenedTransferTxResult, error) {
+
+	var result ScreenedTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		params := arg.CreateRiskDecisionParams
+		params.ReviewStatus = sql.NullString{}
+		params.Metadata = orEmptyMetadata(params.Metadata)
+		if params.Decision == RiskDecisionReview {
+			params.ReviewStatus = sql.NullString{String: ReviewStatusPending, Valid: true}
+		}
+
+		result.Decision, err = q.CreateRiskDecision(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if result.Decision.Decision != RiskDecisionAllow {
+			return nil
+		}
+
+		var held bool
+		result.Approval, held, err = holdForApproval(ctx, q, result.Decision)
+		if err != nil || held {
+			return err
+		}
+
+		result.Transfer, err = transfer(ctx, q, TransferTxParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+			Memo:          arg.Memo,
+			Reference:     arg.Reference,
+			Metadata:      params.Metadata,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Decision, err = q.SetRiskDecisionTransfer(ctx, SetRiskDecisionTransferParams{
+			ID:         result.Decision.ID,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type ReviewTransferTxParams struct {
+	ID       int64
+	Reviewer string
+	Approve  bool
+	Note     string
+}
+
+type ReviewTransferTxResult struct {
+	Decision RiskDecision
+	// Approval is only set when the approved transfer waits for a second signer
+	Approval TransferApproval
+	// Transfer is only set when the transfer was approved and executed
+	Transfer TransferTxResult
+}
+
+// ReviewTransferTx approves or rejects a transfer held for review. An approved
+// transfer is executed in the same transaction, with the limits that apply
+// now, or waits for a second signer when its account needs one.
+func (store *SQLStore) ReviewTransferTx(ctx context.Context, arg ReviewTransferTxParams) (ReviewTransferTxResult, error) {
+
+	var result ReviewTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Decision, err = q.GetRiskDecisionForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		decision := result.Decision
+
+		if decision.ReviewStatus.String != ReviewStatusPending {
+			return ErrReviewNotPending
+		}
+
+		params := ReviewRiskDecisionParams{
+			ID:           decision.ID,
+			ReviewStatus: sql.NullString{String: ReviewStatusRejected, Valid: true},
+			ReviewedBy:   sql.NullString{String: arg.Reviewer, Valid: true},
+			ReviewNote:   arg.Note,
+		}
+
+		if arg.Approve {
+			params.ReviewStatus.String = ReviewStatusApproved
+
+			var held bool
+			result.Approval, held, err = holdForApproval(ctx, q, decision)
+			if err != nil {
+				return err
+			}
+
+			if !held {
+				result.Transfer, err = transfer(ctx, q, TransferTxParams{
+					FromAccountID: decision.FromAccountID,
+					ToAccountID:   decision.ToAccountID,
+					Amount:        decision.Amount,
+					Memo:          decision.Memo,
+					Reference:     decision.Reference,
+					Metadata:      decision.Metadata,
+				})
+				if err != nil {
+					return err
+				}
+
+				params.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+			}
+		}
+
+		result.Decision, err = q.ReviewRiskDecision(ctx, params)
+		return err
+	})
+
+	return result, err
+}
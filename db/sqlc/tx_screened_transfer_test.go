@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func screenedTransferParams(from Account, to Account, decision string) ScreenedTransferTxParams {
+	return ScreenedTransferTxParams{
+		CreateRiskDecisionParams: CreateRiskDecisionParams{
+			Username:      from.Owner,
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        10,
+			Currency:      from.Currency,
+			UserAgent:     "test",
+			ClientIp:      "127.0.0.1",
+			Decision:      decision,
+			Hits:          json.RawMessage(`[]`),
+			Memo:          "screened",
+			Reference:     util.RandomString(10),
+			Metadata:      json.RawMessage(`{"channel":"test"}`),
+		},
+	}
+}
+
+func TestScreenedTransferTx(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+
+	allowed, err := store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionAllow))
+	require.NoError(t, err)
+	require.False(t, allowed.Decision.ReviewStatus.Valid)
+	require.Equal(t, sql.NullInt64{Int64: allowed.Transfer.Transfer.ID, Valid: true}, allowed.Decision.TransferID)
+
+	held, err := store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionReview))
+	require.NoError(t, err)
+	require.Equal(t, ReviewStatusPending, held.Decision.ReviewStatus.String)
+	require.False(t, held.Decision.TransferID.Valid)
+	require.Zero(t, held.Transfer.Transfer.ID)
+
+	blocked, err := store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionBlock))
+	require.NoError(t, err)
+	require.False(t, blocked.Decision.ReviewStatus.Valid)
+	require.False(t, blocked.Decision.TransferID.Valid)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-10, account.Balance)
+
+	signals, err := store.GetTransferRiskSignals(context.Background(), GetTransferRiskSignalsParams{
+		Username:      from.Owner,
+		VelocitySince: time.Now().Add(-time.Hour),
+		ToAccountID:   to.ID,
+		KnownBefore:   time.Now(),
+		Currency:      from.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), signals.RecentTransfers)
+	require.Equal(t, int64(1), signals.PayeeTransfers)
+	require.Zero(t, signals.PreviousSessions)
+
+	currency, err := store.GetCurrency(context.Background(), from.Currency)
+	require.NoError(t, err)
+	require.Positive(t, currency.RiskLargeAmount)
+	require.Equal(t, currency.RiskLargeAmount, signals.LargeAmount)
+}
+
+func TestReviewTransferTx(t *testing.T) {
+
+	store := NewStore(testDB)
+	reviewer := createRandomUser(t)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+
+	held, err := store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionReview))
+	require.NoError(t, err)
+
+	result, err := store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{
+		ID:       held.Decision.ID,
+		Reviewer: reviewer.Username,
+		Approve:  true,
+		Note:     "known customer",
+	})
+	require.NoError(t, err)
+	require.Equal(t, ReviewStatusApproved, result.Decision.ReviewStatus.String)
+	require.Equal(t, reviewer.Username, result.Decision.ReviewedBy.String)
+	require.Equal(t, "known customer", result.Decision.ReviewNote)
+	require.True(t, result.Decision.ReviewedAt.Valid)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Decision.TransferID.Int64)
+
+	// the details of the held transfer end up on the executed one
+	require.Equal(t, held.Decision.Memo, result.Transfer.Transfer.Memo)
+	require.Equal(t, held.Decision.Reference, result.Transfer.Transfer.Reference)
+	require.JSONEq(t, `{"channel":"test"}`, string(result.Transfer.Transfer.Metadata))
+
+	_, err = store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{ID: held.Decision.ID, Reviewer: reviewer.Username})
+	require.ErrorIs(t, err, ErrReviewNotPending)
+
+	held, err = store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionReview))
+	require.NoError(t, err)
+
+	result, err = store.ReviewTransferTx(context.Background(), ReviewTransferTxParams{ID: held.Decision.ID, Reviewer: reviewer.Username})
+	require.NoError(t, err)
+	require.Equal(t, ReviewStatusRejected, result.Decision.ReviewStatus.String)
+	require.False(t, result.Decision.TransferID.Valid)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-10, account.Balance)
+}
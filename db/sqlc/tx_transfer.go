@@ -1,12 +1,41 @@
 package db
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // TransferTxParams contains the input paramters of the transfer transaction
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Memo          string `json:"memo"`
+	Reference     string `json:"reference"`
+	// Metadata is a JSON object of strings, see TransferMetadata
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+var emptyTransferMetadata = json.RawMessage(`{}`)
+
+// TransferMetadata encodes the client metadata of a transfer. Transfers
+// without metadata store an empty object.
+func TransferMetadata(metadata map[string]string) (json.RawMessage, error) {
+	if len(metadata) == 0 {
+		return emptyTransferMetadata, nil
+	}
+	return json.Marshal(metadata)
+}
+
+// orEmptyMetadata keeps callers that have no metadata from storing a null
+func orEmptyMetadata(metadata json.RawMessage) json.RawMessage {
+	if len(metadata) == 0 {
+		return emptyTransferMetadata
+	}
+	return metadata
 }
 
 // TransferTxResult is the result of the transfer transaction
@@ -16,6 +45,9 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	Fee         Fee      `json:"fee"`
+	// FeeEntry debits the fee from the sender; it is empty when there is no fee
+	FeeEntry Entry `json:"fee_entry"`
 }
 
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
@@ -24,72 +56,100 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = transfer(ctx, q, arg)
+		return err
+	})
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-
-		if err != nil {
-			return err
-		}
-
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-
-		if err != nil {
-			return err
-		}
+	return result, err
+}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+// transfer moves money between two customer accounts using the caller's
+// transaction. The sender's transfer limits are enforced first, then the fee
+// of the sender's schedule is charged on top of the amount, in the same journal.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (result TransferTxResult, err error) {
 
-		if err != nil {
-			return err
-		}
+	err = checkCustomerAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+	if err != nil {
+		return
+	}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
+	err = checkTransferLimits(ctx, q, arg.FromAccountID, arg.Amount, time.Now())
+	if err != nil {
+		return
+	}
 
-		} else {
+	result.Fee, err = transferFee(ctx, q, arg.FromAccountID, arg.Amount)
+	if err != nil {
+		return
+	}
+	fee := result.Fee.Total
+
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Fee:           fee,
+		Memo:          arg.Memo,
+		Reference:     arg.Reference,
+		Metadata:      orEmptyMetadata(arg.Metadata),
+	})
 
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	if err != nil {
+		return
+	}
 
+	legs := []JournalLeg{
+		{AccountID: arg.FromAccountID, Amount: -arg.Amount},
+		{AccountID: arg.ToAccountID, Amount: arg.Amount},
+	}
+	if fee > 0 {
+		var feeIncome Account
+		feeIncome, err = feeIncomeAccount(ctx, q, arg.FromAccountID)
+		if err != nil {
+			return
 		}
 
-		return err
-	})
-
-	return result, err
-}
+		legs = append(legs,
+			JournalLeg{AccountID: arg.FromAccountID, Amount: -fee, EntryType: EntryTypeFee},
+			JournalLeg{AccountID: feeIncome.ID, Amount: fee, EntryType: EntryTypeFee},
+		)
+	}
 
-func addMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		Amount: amount1,
-		ID:     accountID1,
+	journal, err := postJournal(ctx, q, PostJournalParams{
+		JournalType: EntryTypeTransfer,
+		TransferID:  sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		Legs:        legs,
 	})
 	if err != nil {
 		return
 	}
 
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		Amount: amount2,
-		ID:     accountID2,
+	result.FromEntry = journal.Entries[0]
+	result.ToEntry = journal.Entries[1]
+	if fee > 0 {
+		result.FeeEntry = journal.Entries[2]
+	}
+	result.FromAccount = journal.Accounts[arg.FromAccountID]
+	result.ToAccount = journal.Accounts[arg.ToAccountID]
+
+	err = addOutboxEvent(ctx, q, EventTransferCompleted, fmt.Sprintf("%s:%d", EventTransferCompleted, result.Transfer.ID), TransferCompletedEvent{
+		TransferID:    result.Transfer.ID,
+		FromAccountID: result.Transfer.FromAccountID,
+		ToAccountID:   result.Transfer.ToAccountID,
+		Amount:        result.Transfer.Amount,
+		Fee:           result.Transfer.Fee,
 	})
-
 	return
+}
+
+// feeIncomeAccount is the internal account collecting the fees charged in
+// the currency of the sending account
+func feeIncomeAccount(ctx context.Context, q *Queries, fromAccountID int64) (Account, error) {
+
+	from, err := q.GetAccount(ctx, fromAccountID)
+	if err != nil {
+		return Account{}, err
+	}
 
+	return glAccount(ctx, q, FeeIncomeAccount, from.Currency)
 }
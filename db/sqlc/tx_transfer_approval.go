@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Statuses of transfers waiting for a second signer
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+)
+
+var (
+	// ErrApprovalNotPending is returned when a transfer was already approved or rejected
+	ErrApprovalNotPending = errors.New("transfer is not pending approval")
+	// ErrSelfApproval is returned when the requester of a transfer approves it
+	ErrSelfApproval = errors.New("a transfer must be approved by another signer")
+)
+
+// needsApproval reports whether a transfer of amount out of account must wait
+// for a second signer: it reaches the approval threshold of the account and
+// the account has more than one signer
+func needsApproval(ctx context.Context, q *Queries, account Account, amount int64) (bool, error) {
+
+	if account.ApprovalThreshold == 0 || amount < account.ApprovalThreshold {
+		return false, nil
+	}
+
+	signers, err := q.CountAccountSigners(ctx, account.ID)
+	if err != nil {
+		return false, err
+	}
+	return signers >= 2, nil
+}
+
+// holdForApproval stores an allowed transfer as waiting for a second signer
+// when its account needs one for it. It reports whether the transfer was held.
+func holdForApproval(ctx context.Context, q *Queries, decision RiskDecision) (TransferApproval, bool, error) {
+
+	account, err := q.GetAccount(ctx, decision.FromAccountID)
+	if err != nil {
+		return TransferApproval{}, false, err
+	}
+
+	needed, err := needsApproval(ctx, q, account, decision.Amount)
+	if err != nil || !needed {
+		return TransferApproval{}, false, err
+	}
+
+	approval, err := q.CreateTransferApproval(ctx, CreateTransferApprovalParams{
+		FromAccountID:  decision.FromAccountID,
+		ToAccountID:    decision.ToAccountID,
+		Amount:         decision.Amount,
+		Currency:       decision.Currency,
+		RequestedBy:    decision.Username,
+		RiskDecisionID: decision.ID,
+	})
+	return approval, err == nil, err
+}
+
+type ApproveTransferTxParams struct {
+	ID       int64
+	Approver string
+	Approve  bool
+}
+
+type ApproveTransferTxResult struct {
+	Approval TransferApproval
+	// Transfer is only set when the transfer was approved
+	Transfer TransferTxResult
+}
+
+// ApproveTransferTx lets a signer of the sending account approve or reject a
+// transfer waiting for a second signer. The requester can reject, which
+// cancels the transfer, but not approve it. An approved transfer is executed
+// in the same transaction, with the limits that apply now.
+func (store *SQLStore) ApproveTransferTx(ctx context.Context, arg ApproveTransferTxParams) (ApproveTransferTxResult, error) {
+
+	var result ApproveTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Approval, err = q.GetTransferApprovalForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		approval := result.Approval
+
+		if approval.Status != ApprovalStatusPending {
+			return ErrApprovalNotPending
+		}
+
+		role, err := memberRole(ctx, q, approval.FromAccountID, arg.Approver)
+		if err != nil {
+			return err
+		}
+		if !CanSign(role) {
+			return ErrNotSigner
+		}
+
+		params := DecideTransferApprovalParams{
+			ID:        approval.ID,
+			Status:    ApprovalStatusRejected,
+			DecidedBy: sql.NullString{String: arg.Approver, Valid: true},
+		}
+
+		if arg.Approve {
+			if arg.Approver == approval.RequestedBy {
+				return ErrSelfApproval
+			}
+
+			// the details of the transfer are kept on the decision that held it
+			decision, err := q.GetRiskDecision(ctx, approval.RiskDecisionID)
+			if err != nil {
+				return err
+			}
+
+			result.Transfer, err = transfer(ctx, q, TransferTxParams{
+				FromAccountID: approval.FromAccountID,
+				ToAccountID:   approval.ToAccountID,
+				Amount:        approval.Amount,
+				Memo:          decision.Memo,
+				Reference:     decision.Reference,
+				Metadata:      decision.Metadata,
+			})
+			if err != nil {
+				return err
+			}
+
+			params.Status = ApprovalStatusApproved
+			params.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+
+			_, err = q.SetRiskDecisionTransfer(ctx, SetRiskDecisionTransferParams{
+				ID:         approval.RiskDecisionID,
+				TransferID: params.TransferID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Approval, err = q.DecideTransferApproval(ctx, params)
+		return err
+	})
+
+	return result, err
+}
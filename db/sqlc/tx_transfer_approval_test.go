@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func addActiveMember(t *testing.T, account Account, role string) User {
+
+	user := createRandomUser(t)
+
+	_, err := testQueries.InviteAccountMember(context.Background(), InviteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+		Role:      role,
+		InvitedBy: sql.NullString{String: account.Owner, Valid: true},
+	})
+	require.NoError(t, err)
+
+	member, err := testQueries.AcceptAccountInvitation(context.Background(), AcceptAccountInvitationParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberStatusActive, member.Status)
+
+	return user
+}
+
+func TestApproveTransferTx(t *testing.T) {
+
+	store := NewStore(testDB)
+
+	from := createRandomAccount(t)
+	to := createRandomAccountLike(t, from)
+
+	_, err := store.SetAccountApprovalThreshold(context.Background(), SetAccountApprovalThresholdParams{
+		ID:                from.ID,
+		ApprovalThreshold: 10,
+	})
+	require.NoError(t, err)
+
+	// a single signer is never held
+	alone, err := store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionAllow))
+	require.NoError(t, err)
+	require.Zero(t, alone.Approval.ID)
+	require.NotZero(t, alone.Transfer.Transfer.ID)
+
+	signer := addActiveMember(t, from, MemberRoleSigner)
+	viewer := addActiveMember(t, from, MemberRoleViewer)
+
+	held, err := store.ScreenedTransferTx(context.Background(), screenedTransferParams(from, to, RiskDecisionAllow))
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusPending, held.Approval.Status)
+	require.Zero(t, held.Transfer.Transfer.ID)
+	require.False(t, held.Decision.TransferID.Valid)
+
+	_, err = store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{ID: held.Approval.ID, Approver: viewer.Username, Approve: true})
+	require.ErrorIs(t, err, ErrNotSigner)
+
+	_, err = store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{ID: held.Approval.ID, Approver: from.Owner, Approve: true})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{ID: held.Approval.ID, Approver: signer.Username, Approve: true})
+	require.NoError(t, err)
+	require.Equal(t, ApprovalStatusApproved, result.Approval.Status)
+	require.Equal(t, sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}, result.Approval.TransferID)
+	require.Equal(t, held.Decision.Reference, result.Transfer.Transfer.Reference)
+	require.JSONEq(t, `{"channel":"test"}`, string(result.Transfer.Transfer.Metadata))
+
+	_, err = store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{ID: held.Approval.ID, Approver: signer.Username, Approve: false})
+	require.ErrorIs(t, err, ErrApprovalNotPending)
+
+	account, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-20, account.Balance)
+}
@@ -16,7 +16,7 @@ INSERT INTO users (
   hashed_password,
   full_name,
   email
-) VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at
+) VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, limit_profile, kyc_status, kyc_tier
 `
 
 type CreateUserParams struct {
@@ -41,12 +41,16 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.LimitProfile,
+		&i.KycStatus,
+		&i.KycTier,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at FROM users WHERE username = $1 LIMIT  1
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, role, limit_profile, kyc_status, kyc_tier FROM users WHERE username = $1 LIMIT  1
 `
 
 func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
@@ -59,6 +63,10 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.LimitProfile,
+		&i.KycStatus,
+		&i.KycTier,
 	)
 	return i, err
 }
@@ -69,7 +77,7 @@ UPDATE users
         password_changed_at = COALESCE($2, password_changed_at),
         full_name = COALESCE($3, full_name),
         email = COALESCE($4, email)
-    WHERE username = $5 RETURNING username, hashed_password, full_name, email, password_changed_at, created_at
+    WHERE username = $5 RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, limit_profile, kyc_status, kyc_tier
 `
 
 type UpdateUserParams struct {
@@ -96,6 +104,37 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.LimitProfile,
+		&i.KycStatus,
+		&i.KycTier,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users SET role = $2 WHERE username = $1 RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, limit_profile, kyc_status, kyc_tier
+`
+
+type UpdateUserRoleParams struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserRole, arg.Username, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.LimitProfile,
+		&i.KycStatus,
+		&i.KycTier,
 	)
 	return i, err
 }
@@ -29,6 +29,7 @@ func createRandomUser(t *testing.T) User {
 	require.Equal(t, arg.FullName, user.FullName)
 	require.Equal(t, arg.Email, user.Email)
 
+	require.Equal(t, util.DepositorRole, user.Role)
 	require.NotZero(t, user.CreatedAt)
 	require.True(t, user.PasswordChangedAt.IsZero())
 
@@ -142,3 +143,25 @@ func TestUpdateAllFields(t *testing.T) {
 	require.NotEqual(t, oldUser.Email, email)
 
 }
+
+func TestCreateUserTxWithRole(t *testing.T) {
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	store := NewStore(testDB)
+	result, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: hashedPassword,
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+		Role: util.AdminRole,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.AdminRole, result.User.Role)
+
+	user, err := testQueries.GetUser(context.Background(), result.User.Username)
+	require.NoError(t, err)
+	require.Equal(t, util.AdminRole, user.Role)
+}
@@ -2,9 +2,14 @@ package gapi
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	db "github.com/joefazee/simplebank/db/sqlc"
 	"github.com/joefazee/simplebank/token"
+	"github.com/joefazee/simplebank/util"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"strings"
 )
 
@@ -37,7 +42,7 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 		return nil, errors.New("invalid authorization type")
 	}
 
-	accessToken := fields[0]
+	accessToken := fields[1]
 
 	payload, err := server.tokenMaker.VerifyToken(accessToken)
 	if err != nil {
@@ -47,3 +52,43 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 	return payload, nil
 
 }
+
+// authorizeAdmin authorizes the request and checks that the user has the admin
+// role. The role is read from the database so a demoted admin loses access at once.
+func (server *Server) authorizeAdmin(ctx context.Context) (*token.Payload, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.PermissionDenied, "admin role required")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+
+	if user.Role != util.AdminRole {
+		return nil, status.Error(codes.PermissionDenied, "admin role required")
+	}
+
+	return authPayload, nil
+}
+
+// memberRole returns the role of username on account, or PermissionDenied when
+// the user is not an active member of the account
+func (server *Server) memberRole(ctx context.Context, account db.Account, username string) (string, error) {
+
+	role, err := db.MemberRole(ctx, server.store, account, username)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to get account member: %s", err)
+	}
+
+	if role == "" {
+		return "", status.Error(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+	}
+
+	return role, nil
+}
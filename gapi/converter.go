@@ -1,8 +1,12 @@
 package gapi
 
 import (
+	"encoding/json"
+	"github.com/joefazee/simplebank/currency"
 	db "github.com/joefazee/simplebank/db/sqlc"
 	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/risk"
+	"github.com/joefazee/simplebank/util"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -15,3 +19,266 @@ func convertUser(user *db.User) *pb.User {
 		CreatedAt:        timestamppb.New(user.CreatedAt),
 	}
 }
+
+func convertEntry(entry *db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:              entry.ID,
+		AccountId:       entry.AccountID,
+		Amount:          entry.Amount,
+		CreatedAt:       timestamppb.New(entry.CreatedAt),
+		EntryType:       entry.EntryType,
+		TransferId:      entry.TransferID.Int64,
+		CorrectsEntryId: entry.CorrectsEntryID.Int64,
+	}
+}
+
+func convertAccountActivity(row *db.ListAccountActivityAfterRow) *pb.WatchAccountResponse {
+	return &pb.WatchAccountResponse{
+		Entry: &pb.Entry{
+			Id:              row.ID,
+			AccountId:       row.AccountID,
+			Amount:          row.Amount,
+			CreatedAt:       timestamppb.New(row.CreatedAt),
+			EntryType:       row.EntryType,
+			TransferId:      row.TransferID.Int64,
+			CorrectsEntryId: row.CorrectsEntryID.Int64,
+		},
+		Balance: row.Balance,
+	}
+}
+
+func convertStatementEntry(row *db.ListAccountStatementRow, currencyCode string, currencies *currency.Registry) *pb.StatementEntry {
+	return &pb.StatementEntry{
+		Entry: &pb.Entry{
+			Id:              row.ID,
+			AccountId:       row.AccountID,
+			Amount:          row.Amount,
+			CreatedAt:       timestamppb.New(row.CreatedAt),
+			EntryType:       row.EntryType,
+			TransferId:      row.TransferID.Int64,
+			CorrectsEntryId: row.CorrectsEntryID.Int64,
+		},
+		FormattedAmount:       currencies.Format(currencyCode, row.Amount),
+		CounterpartyAccountId: row.CounterpartyAccountID.Int64,
+		CounterpartyName:      util.MaskName(row.CounterpartyName.String),
+	}
+}
+
+func convertTransfer(transfer *db.Transfer, currencyCode string, currencies *currency.Registry) *pb.Transfer {
+	return &pb.Transfer{
+		Id:              transfer.ID,
+		FromAccountId:   transfer.FromAccountID,
+		ToAccountId:     transfer.ToAccountID,
+		Amount:          transfer.Amount,
+		CreatedAt:       timestamppb.New(transfer.CreatedAt),
+		Fee:             transfer.Fee,
+		Currency:        currencyCode,
+		FormattedAmount: currencies.Format(currencyCode, transfer.Amount),
+		FormattedFee:    currencies.Format(currencyCode, transfer.Fee),
+		Memo:            transfer.Memo,
+		Reference:       transfer.Reference,
+		Metadata:        convertTransferMetadata(transfer.Metadata),
+	}
+}
+
+func convertTransferActivity(row *db.SearchTransferActivityRow, currencies *currency.Registry) *pb.TransferActivity {
+	res := &pb.TransferActivity{
+		Status:          row.Status,
+		Direction:       row.Direction,
+		FromAccountId:   row.FromAccountID,
+		ToAccountId:     row.ToAccountID,
+		Amount:          row.Amount,
+		Fee:             row.Fee,
+		Currency:        row.Currency,
+		FormattedAmount: currencies.Format(row.Currency, row.Amount),
+		Memo:            row.Memo,
+		Reference:       row.Reference,
+		Metadata:        convertTransferMetadata(row.Metadata),
+		CreatedAt:       timestamppb.New(row.CreatedAt),
+	}
+	if row.Kind == db.TransferActivityTransfer {
+		res.TransferId = row.ID
+	} else {
+		res.RiskDecisionId = row.ID
+	}
+	return res
+}
+
+// convertTransferMetadata decodes the metadata of a transfer. Only objects of
+// strings are ever stored, so anything else is left out rather than failing the call
+func convertTransferMetadata(metadata json.RawMessage) map[string]string {
+	var res map[string]string
+	if err := json.Unmarshal(metadata, &res); err != nil {
+		return nil
+	}
+	return res
+}
+
+func convertTransferFee(fee *db.Fee) *pb.TransferFee {
+	return &pb.TransferFee{
+		FlatAmount:    fee.FlatAmount,
+		PercentBps:    fee.PercentBps,
+		PercentAmount: fee.PercentAmount,
+		Adjustment:    fee.Adjustment,
+		Total:         fee.Total,
+	}
+}
+
+func convertTransferReview(decision *db.RiskDecision) *pb.TransferReview {
+	review := &pb.TransferReview{
+		Id:            decision.ID,
+		Username:      decision.Username,
+		FromAccountId: decision.FromAccountID,
+		ToAccountId:   decision.ToAccountID,
+		Amount:        decision.Amount,
+		Currency:      decision.Currency,
+		UserAgent:     decision.UserAgent,
+		ClientIp:      decision.ClientIp,
+		Decision:      decision.Decision,
+		ReviewStatus:  decision.ReviewStatus.String,
+		TransferId:    decision.TransferID.Int64,
+		ReviewedBy:    decision.ReviewedBy.String,
+		ReviewNote:    decision.ReviewNote,
+		CreatedAt:     timestamppb.New(decision.CreatedAt),
+	}
+
+	if decision.ReviewedAt.Valid {
+		review.ReviewedAt = timestamppb.New(decision.ReviewedAt.Time)
+	}
+
+	var hits []risk.Hit
+	if err := json.Unmarshal(decision.Hits, &hits); err == nil {
+		for _, hit := range hits {
+			review.Hits = append(review.Hits, &pb.RiskHit{
+				Rule:     hit.Rule,
+				Decision: string(hit.Decision),
+				Reason:   hit.Reason,
+			})
+		}
+	}
+
+	return review
+}
+
+func convertAccountMember(member *db.AccountMember) *pb.AccountMember {
+	res := &pb.AccountMember{
+		AccountId: member.AccountID,
+		Username:  member.Username,
+		Role:      member.Role,
+		Status:    member.Status,
+		InvitedBy: member.InvitedBy.String,
+		CreatedAt: timestamppb.New(member.CreatedAt),
+	}
+
+	if member.AcceptedAt.Valid {
+		res.AcceptedAt = timestamppb.New(member.AcceptedAt.Time)
+	}
+	return res
+}
+
+func convertTransferApproval(approval *db.TransferApproval) *pb.TransferApproval {
+	res := &pb.TransferApproval{
+		Id:            approval.ID,
+		FromAccountId: approval.FromAccountID,
+		ToAccountId:   approval.ToAccountID,
+		Amount:        approval.Amount,
+		Currency:      approval.Currency,
+		RequestedBy:   approval.RequestedBy,
+		Status:        approval.Status,
+		DecidedBy:     approval.DecidedBy.String,
+		TransferId:    approval.TransferID.Int64,
+		CreatedAt:     timestamppb.New(approval.CreatedAt),
+	}
+
+	if approval.DecidedAt.Valid {
+		res.DecidedAt = timestamppb.New(approval.DecidedAt.Time)
+	}
+	return res
+}
+
+func convertPaymentRequest(request *db.PaymentRequest, currencies *currency.Registry) *pb.PaymentRequest {
+	res := &pb.PaymentRequest{
+		Id:              request.ID,
+		Requester:       request.Requester,
+		Payer:           request.Payer,
+		ToAccountId:     request.ToAccountID,
+		Amount:          request.Amount,
+		Currency:        request.Currency,
+		FormattedAmount: currencies.Format(request.Currency, request.Amount),
+		Memo:            request.Memo,
+		Status:          request.Status,
+		ExpiresAt:       timestamppb.New(request.ExpiresAt),
+		TransferId:      request.TransferID.Int64,
+		CreatedAt:       timestamppb.New(request.CreatedAt),
+	}
+
+	if request.SettledAt.Valid {
+		res.SettledAt = timestamppb.New(request.SettledAt.Time)
+	}
+	return res
+}
+
+func convertNotification(notification *db.Notification) *pb.Notification {
+	res := &pb.Notification{
+		Id:        notification.ID,
+		EventType: notification.EventType,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      string(notification.Data),
+		Read:      notification.ReadAt.Valid,
+		CreatedAt: timestamppb.New(notification.CreatedAt),
+	}
+
+	if notification.ReadAt.Valid {
+		res.ReadAt = timestamppb.New(notification.ReadAt.Time)
+	}
+	return res
+}
+
+// convertNotificationPreferences returns a preference for every event and
+// channel, filling in the defaults the user never changed
+func convertNotificationPreferences(preferences []db.NotificationPreference) []*pb.NotificationPreference {
+	res := make([]*pb.NotificationPreference, 0, len(db.NotificationEvents)*len(db.NotificationChannels))
+	for _, event := range db.NotificationEvents {
+		for _, channel := range db.NotificationChannels {
+			res = append(res, &pb.NotificationPreference{
+				EventType: event,
+				Channel:   channel,
+				Enabled:   db.NotificationEnabled(preferences, event, channel),
+			})
+		}
+	}
+	return res
+}
+
+func convertKycDocument(document *db.KycDocument) *pb.KycDocument {
+	res := &pb.KycDocument{
+		Id:          document.ID,
+		Username:    document.Username,
+		Kind:        document.Kind,
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		SizeBytes:   document.SizeBytes,
+		Sha256:      document.Sha256,
+		Status:      document.Status,
+		ReviewedBy:  document.ReviewedBy.String,
+		ReviewNote:  document.ReviewNote,
+		CreatedAt:   timestamppb.New(document.CreatedAt),
+	}
+
+	if document.ReviewedAt.Valid {
+		res.ReviewedAt = timestamppb.New(document.ReviewedAt.Time)
+	}
+	return res
+}
+
+func convertTrialBalanceLine(line *db.TrialBalanceLine) *pb.TrialBalanceLine {
+	return &pb.TrialBalanceLine{
+		Code:     line.Code,
+		Number:   line.Number,
+		Name:     line.Name,
+		Category: line.Category,
+		Debit:    line.Debit,
+		Credit:   line.Credit,
+	}
+}
@@ -1,9 +1,11 @@
 package gapi
 
 import (
+	db "github.com/joefazee/simplebank/db/sqlc"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"strconv"
 )
 
 func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
@@ -23,3 +25,51 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 	}
 	return statusDetails.Err()
 }
+
+// limitExceededError reports a transfer limit as RESOURCE_EXHAUSTED with the
+// limit, the amount used and the amount remaining in the details
+func limitExceededError(username string, limitErr *db.LimitExceededError) error {
+	statusExhausted := status.New(codes.ResourceExhausted, limitErr.Error())
+
+	statusDetails, err := statusExhausted.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: "TRANSFER_LIMIT_EXCEEDED",
+			Domain: "simplebank",
+			Metadata: map[string]string{
+				"period":    limitErr.Period,
+				"currency":  limitErr.Currency,
+				"limit":     strconv.FormatInt(limitErr.Limit, 10),
+				"used":      strconv.FormatInt(limitErr.Used, 10),
+				"remaining": strconv.FormatInt(limitErr.Remaining, 10),
+			},
+		},
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     "user:" + username,
+				Description: limitErr.Error(),
+			}},
+		},
+	)
+	if err != nil {
+		return statusExhausted.Err()
+	}
+	return statusDetails.Err()
+}
+
+// transferBlockedError reports a transfer stopped by risk screening. The rule
+// hits are kept for the reviewers and not returned to the client.
+func transferBlockedError(decisionID int64) error {
+	statusDenied := status.New(codes.PermissionDenied, "transfer was blocked by risk screening")
+
+	statusDetails, err := statusDenied.WithDetails(&errdetails.ErrorInfo{
+		Reason: "TRANSFER_BLOCKED",
+		Domain: "simplebank",
+		Metadata: map[string]string{
+			"decision_id": strconv.FormatInt(decisionID, 10),
+		},
+	})
+	if err != nil {
+		return statusDenied.Err()
+	}
+	return statusDetails.Err()
+}
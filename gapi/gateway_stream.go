@@ -0,0 +1,129 @@
+package gapi
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"github.com/joefazee/simplebank/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+)
+
+// WatchAccountPattern is the gateway route of WatchAccount
+const WatchAccountPattern = "/v1/accounts/{account_id}/watch"
+
+// HandleWatchAccount serves WatchAccount on the gateway as newline delimited
+// JSON, one {"result": ...} object per entry, the same framing the generated
+// gateway uses for streams. The generated handler cannot call streaming
+// methods in process, so this one is registered over it.
+func (server *Server) HandleWatchAccount(mux *runtime.ServeMux) runtime.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+		_, marshaler := runtime.MarshalerForRequest(mux, req)
+
+		ctx, err := runtime.AnnotateIncomingContext(req.Context(), mux, req, "/pb.SimpleBank/WatchAccount",
+			runtime.WithHTTPPathPattern(WatchAccountPattern))
+		if err != nil {
+			runtime.HTTPError(req.Context(), mux, marshaler, res, req, err)
+			return
+		}
+
+		watchReq, err := parseWatchAccountRequest(req, pathParams)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, marshaler, res, req, err)
+			return
+		}
+
+		stream := &gatewayStream{
+			ctx:       ctx,
+			res:       res,
+			marshaler: marshaler,
+		}
+
+		err = server.WatchAccount(watchReq, stream)
+		if err == nil || status.Code(err) == codes.Canceled {
+			return
+		}
+
+		if !stream.started {
+			runtime.HTTPError(ctx, mux, marshaler, res, req, err)
+			return
+		}
+
+		stream.write(map[string]proto.Message{"error": status.Convert(err).Proto()})
+	}
+}
+
+func parseWatchAccountRequest(req *http.Request, pathParams map[string]string) (*pb.WatchAccountRequest, error) {
+
+	var watchReq pb.WatchAccountRequest
+
+	if err := req.ParseForm(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := runtime.PopulateQueryParameters(&watchReq, req.Form, &utilities.DoubleArray{}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	accountID, err := runtime.Int64(pathParams["account_id"])
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: account_id, error: %v", err)
+	}
+	watchReq.AccountId = accountID
+
+	return &watchReq, nil
+}
+
+// gatewayStream writes the messages of a server stream to an HTTP response
+type gatewayStream struct {
+	pb.SimpleBank_WatchAccountServer
+	ctx       context.Context
+	res       http.ResponseWriter
+	marshaler runtime.Marshaler
+	started   bool
+}
+
+func (stream *gatewayStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *gatewayStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (stream *gatewayStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (stream *gatewayStream) SetTrailer(metadata.MD) {}
+
+func (stream *gatewayStream) Send(res *pb.WatchAccountResponse) error {
+	return stream.write(map[string]proto.Message{"result": res})
+}
+
+func (stream *gatewayStream) write(chunk map[string]proto.Message) error {
+
+	if !stream.started {
+		stream.res.Header().Set("Content-Type", "application/x-ndjson")
+		stream.res.WriteHeader(http.StatusOK)
+		stream.started = true
+	}
+
+	data, err := stream.marshaler.Marshal(chunk)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to marshal response: %s", err)
+	}
+
+	if _, err = stream.res.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if flusher, ok := stream.res.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package gapi
+
+import (
+	"context"
+	"github.com/joefazee/simplebank/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func GrpcMetrics(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+
+		startTime := time.Now()
+		result, err := handler(ctx, req)
+		duration := time.Since(startTime)
+
+		statusCode := status.Code(err).String()
+		m.GrpcRequests.WithLabelValues(info.FullMethod, statusCode).Inc()
+		m.GrpcDuration.WithLabelValues(info.FullMethod, statusCode).Observe(duration.Seconds())
+
+		return result, err
+	}
+}
+
+func HttpMetrics(m *metrics.Metrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+
+		startTime := time.Now()
+		rec := &ResponseRecorder{
+			ResponseWriter: res,
+			StatusCode:     http.StatusOK,
+		}
+
+		next.ServeHTTP(rec, req)
+		duration := time.Since(startTime)
+
+		// unknown paths are collapsed so scanners cannot blow up label cardinality
+		path := req.URL.Path
+		if rec.StatusCode == http.StatusNotFound {
+			path = "unmatched"
+		}
+
+		statusCode := strconv.Itoa(rec.StatusCode)
+		m.HttpRequests.WithLabelValues(req.Method, path, statusCode).Inc()
+		m.HttpDuration.WithLabelValues(req.Method, path, statusCode).Observe(duration.Seconds())
+	})
+}
@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+	"github.com/joefazee/simplebank/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGrpcMetrics(t *testing.T) {
+
+	m := metrics.New(prometheus.NewRegistry())
+	interceptor := GrpcMetrics(m)
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.SimpleBank/LoginUser"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	})
+	require.Error(t, err)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.GrpcRequests.WithLabelValues(info.FullMethod, codes.NotFound.String())))
+	require.Equal(t, 1, testutil.CollectAndCount(m.GrpcDuration))
+}
+
+func TestHttpMetrics(t *testing.T) {
+
+	m := metrics.New(prometheus.NewRegistry())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/login_user", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusUnauthorized)
+	})
+	handler := HttpMetrics(m, mux)
+
+	for _, path := range []string{"/v1/login_user", "/random/path"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.HttpRequests.WithLabelValues(http.MethodPost, "/v1/login_user", "401")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.HttpRequests.WithLabelValues(http.MethodPost, "unmatched", "404")))
+}
@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+)
+
+// healthServicePrefix is exempt so probes keep working while the server is saturated
+const healthServicePrefix = "/grpc.health.v1."
+
+// RateLimiter caps the number of requests the server accepts per second. It is
+// shared by the gRPC server and the gateway and can be changed while running.
+type RateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewRateLimiter creates a limiter allowing rps requests per second with the
+// given burst. A zero rps disables limiting.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	limiter := &RateLimiter{limiter: rate.NewLimiter(rate.Inf, 0)}
+	limiter.Update(rps, burst)
+	return limiter
+}
+
+// Update applies a new rate and burst to requests from now on
+func (l *RateLimiter) Update(rps float64, burst int) {
+	if rps <= 0 {
+		l.limiter.SetLimit(rate.Inf)
+		return
+	}
+
+	l.limiter.SetBurst(burst)
+	l.limiter.SetLimit(rate.Limit(rps))
+}
+
+func (l *RateLimiter) allow() bool {
+	return l.limiter.Allow()
+}
+
+func GrpcRateLimiter(l *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if !strings.HasPrefix(info.FullMethod, healthServicePrefix) && !l.allow() {
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func HttpRateLimiter(l *RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if !l.allow() {
+			http.Error(res, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(res, req)
+	})
+}
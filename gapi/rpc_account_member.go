@@ -0,0 +1,297 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InviteAccountMember invites a user to an account. Only owners can invite.
+func (server *Server) InviteAccountMember(ctx context.Context, req *pb.InviteAccountMemberRequest) (*pb.InviteAccountMemberResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateInviteAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = server.requireOwner(ctx, account, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	member, err := server.store.InviteAccountMember(ctx, db.InviteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+		Role:      req.GetRole(),
+		InvitedBy: sql.NullString{String: authPayload.Username, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s is already a member of the account", req.GetUsername())
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUsername())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to invite account member: %s", err)
+	}
+
+	res := &pb.InviteAccountMemberResponse{
+		Member: convertAccountMember(&member),
+	}
+	return res, nil
+}
+
+// AcceptAccountInvitation makes the authenticated user an active member of an
+// account they were invited to
+func (server *Server) AcceptAccountInvitation(ctx context.Context, req *pb.AcceptAccountInvitationRequest) (*pb.AcceptAccountInvitationResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountID(req.GetAccountId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	member, err := server.store.AcceptAccountInvitation(ctx, db.AcceptAccountInvitationParams{
+		AccountID: req.GetAccountId(),
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "invitation not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to accept invitation: %s", err)
+	}
+
+	res := &pb.AcceptAccountInvitationResponse{
+		Member: convertAccountMember(&member),
+	}
+	return res, nil
+}
+
+// ListAccountMembers lists the members and pending invitations of an account
+func (server *Server) ListAccountMembers(ctx context.Context, req *pb.ListAccountMembersRequest) (*pb.ListAccountMembersResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountID(req.GetAccountId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = server.memberRole(ctx, account, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account members: %s", err)
+	}
+
+	res := &pb.ListAccountMembersResponse{
+		Members: make([]*pb.AccountMember, 0, len(members)),
+	}
+	for i := range members {
+		res.Members = append(res.Members, convertAccountMember(&members[i]))
+	}
+	return res, nil
+}
+
+// RemoveAccountMember removes a member from an account or revokes a pending
+// invitation. The primary owner can remove anyone else; other members, co-owners
+// included, can only remove themselves, which also lets an invitee decline.
+func (server *Server) RemoveAccountMember(ctx context.Context, req *pb.RemoveAccountMemberRequest) (*pb.RemoveAccountMemberResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRemoveAccountMemberRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUsername() == account.Owner {
+		return nil, status.Error(codes.FailedPrecondition, "the primary owner cannot be removed from the account")
+	}
+
+	if req.GetUsername() != authPayload.Username {
+		if err = requirePrimaryOwner(account, authPayload.Username); err != nil {
+			return nil, err
+		}
+	}
+
+	member, err := server.store.DeleteAccountMember(ctx, db.DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  req.GetUsername(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "%s is not a member of the account", req.GetUsername())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to remove account member: %s", err)
+	}
+
+	res := &pb.RemoveAccountMemberResponse{
+		Member: convertAccountMember(&member),
+	}
+	return res, nil
+}
+
+// SetApprovalThreshold sets the amount from which transfers out of an account
+// need a second signer. Zero turns dual approval off. Only the primary owner can
+// change it, so a co-owner cannot switch off the approval meant to check them.
+func (server *Server) SetApprovalThreshold(ctx context.Context, req *pb.SetApprovalThresholdRequest) (*pb.SetApprovalThresholdResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSetApprovalThresholdRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = requirePrimaryOwner(account, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	account, err = server.store.SetAccountApprovalThreshold(ctx, db.SetAccountApprovalThresholdParams{
+		ID:                account.ID,
+		ApprovalThreshold: req.GetApprovalThreshold(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set approval threshold: %s", err)
+	}
+
+	res := &pb.SetApprovalThresholdResponse{
+		AccountId:         account.ID,
+		ApprovalThreshold: account.ApprovalThreshold,
+	}
+	return res, nil
+}
+
+func (server *Server) getAccount(ctx context.Context, id int64) (db.Account, error) {
+
+	account, err := server.store.GetAccount(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", id)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	return account, nil
+}
+
+func (server *Server) requireOwner(ctx context.Context, account db.Account, username string) error {
+
+	role, err := server.memberRole(ctx, account, username)
+	if err != nil {
+		return err
+	}
+
+	if role != db.MemberRoleOwner {
+		return status.Error(codes.PermissionDenied, "only account owners can manage the account")
+	}
+
+	return nil
+}
+
+// requirePrimaryOwner allows only the user who opened the account, for the
+// changes a co-owner must not make on their own
+func requirePrimaryOwner(account db.Account, username string) error {
+
+	if account.Owner != username {
+		return status.Error(codes.PermissionDenied, "only the primary owner of the account can do this")
+	}
+
+	return nil
+}
+
+func validateAccountID(id int64) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if id <= 0 {
+		violations = append(violations, fieldViolation("account_id", errors.New("must be a positive integer")))
+	}
+
+	return violations
+}
+
+func validateInviteAccountMemberRequest(req *pb.InviteAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	violations = validateAccountID(req.GetAccountId())
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	switch req.GetRole() {
+	case db.MemberRoleOwner, db.MemberRoleSigner, db.MemberRoleViewer:
+	default:
+		violations = append(violations, fieldViolation("role", errors.New("must be owner, signer or viewer")))
+	}
+
+	return violations
+}
+
+func validateRemoveAccountMemberRequest(req *pb.RemoveAccountMemberRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	violations = validateAccountID(req.GetAccountId())
+
+	if err := val.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
+
+func validateSetApprovalThresholdRequest(req *pb.SetApprovalThresholdRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	violations = validateAccountID(req.GetAccountId())
+
+	if req.GetApprovalThreshold() < 0 {
+		violations = append(violations, fieldViolation("approval_threshold", errors.New("must not be negative")))
+	}
+
+	return violations
+}
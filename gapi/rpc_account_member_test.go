@@ -0,0 +1,343 @@
+package gapi
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestServer_InviteAccountMember(t *testing.T) {
+
+	owner, _ := createRandomUser(t)
+	signer, _ := createRandomUser(t)
+	invitee, _ := createRandomUser(t)
+
+	account := db.Account{ID: 1, Owner: owner.Username, Balance: 1000, Currency: util.USD}
+
+	testCases := []struct {
+		name       string
+		req        *pb.InviteAccountMemberRequest
+		username   string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.InviteAccountMemberResponse, err error)
+	}{
+		{
+			name:     "OK",
+			req:      &pb.InviteAccountMemberRequest{AccountId: account.ID, Username: invitee.Username, Role: db.MemberRoleSigner},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					InviteAccountMember(gomock.Any(), gomock.Eq(db.InviteAccountMemberParams{
+						AccountID: account.ID,
+						Username:  invitee.Username,
+						Role:      db.MemberRoleSigner,
+						InvitedBy: sql.NullString{String: owner.Username, Valid: true},
+					})).
+					Times(1).
+					Return(db.AccountMember{
+						AccountID: account.ID,
+						Username:  invitee.Username,
+						Role:      db.MemberRoleSigner,
+						Status:    db.MemberStatusInvited,
+						InvitedBy: sql.NullString{String: owner.Username, Valid: true},
+						CreatedAt: time.Now(),
+					}, nil)
+			},
+			check: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, invitee.Username, res.GetMember().GetUsername())
+				require.Equal(t, db.MemberStatusInvited, res.GetMember().GetStatus())
+				require.Equal(t, owner.Username, res.GetMember().GetInvitedBy())
+				require.Nil(t, res.GetMember().GetAcceptedAt())
+			},
+		},
+		{
+			name:     "SignerCannotInvite",
+			req:      &pb.InviteAccountMemberRequest{AccountId: account.ID, Username: invitee.Username, Role: db.MemberRoleViewer},
+			username: signer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.AccountMember{AccountID: account.ID, Username: signer.Username, Role: db.MemberRoleSigner, Status: db.MemberStatusActive}, nil)
+				store.EXPECT().InviteAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "AlreadyMember",
+			req:      &pb.InviteAccountMemberRequest{AccountId: account.ID, Username: signer.Username, Role: db.MemberRoleViewer},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().InviteAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				require.Equal(t, codes.AlreadyExists, status.Code(err))
+			},
+		},
+		{
+			name:     "UserNotFound",
+			req:      &pb.InviteAccountMemberRequest{AccountId: account.ID, Username: "nobody", Role: db.MemberRoleViewer},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().InviteAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, &pq.Error{Code: "23503"})
+			},
+			check: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InvalidRole",
+			req:      &pb.InviteAccountMemberRequest{AccountId: account.ID, Username: invitee.Username, Role: "admin"},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.InviteAccountMemberResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, time.Minute)
+			res, err := server.InviteAccountMember(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_AcceptAccountInvitation(t *testing.T) {
+
+	invitee, _ := createRandomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		AcceptAccountInvitation(gomock.Any(), gomock.Eq(db.AcceptAccountInvitationParams{AccountID: 1, Username: invitee.Username})).
+		Times(1).
+		Return(db.AccountMember{
+			AccountID:  1,
+			Username:   invitee.Username,
+			Role:       db.MemberRoleSigner,
+			Status:     db.MemberStatusActive,
+			AcceptedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		}, nil)
+
+	server := newTestServer(t, store)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, invitee.Username, time.Minute)
+	res, err := server.AcceptAccountInvitation(ctx, &pb.AcceptAccountInvitationRequest{AccountId: 1})
+	require.NoError(t, err)
+	require.Equal(t, db.MemberStatusActive, res.GetMember().GetStatus())
+	require.NotNil(t, res.GetMember().GetAcceptedAt())
+}
+
+func TestServer_RemoveAccountMember(t *testing.T) {
+
+	owner, _ := createRandomUser(t)
+	coOwner, _ := createRandomUser(t)
+	signer, _ := createRandomUser(t)
+	invitee, _ := createRandomUser(t)
+
+	account := db.Account{ID: 1, Owner: owner.Username, Balance: 1000, Currency: util.USD}
+	signerMember := db.AccountMember{AccountID: account.ID, Username: signer.Username, Role: db.MemberRoleSigner, Status: db.MemberStatusActive}
+	invitation := db.AccountMember{AccountID: account.ID, Username: invitee.Username, Role: db.MemberRoleViewer, Status: db.MemberStatusInvited}
+
+	testCases := []struct {
+		name       string
+		req        *pb.RemoveAccountMemberRequest
+		username   string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error)
+	}{
+		{
+			name:     "OwnerRevokesInvitation",
+			req:      &pb.RemoveAccountMemberRequest{AccountId: account.ID, Username: invitee.Username},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					DeleteAccountMember(gomock.Any(), gomock.Eq(db.DeleteAccountMemberParams{AccountID: account.ID, Username: invitee.Username})).
+					Times(1).
+					Return(invitation, nil)
+			},
+			check: func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, invitee.Username, res.GetMember().GetUsername())
+				require.Equal(t, db.MemberStatusInvited, res.GetMember().GetStatus())
+			},
+		},
+		{
+			name:     "MemberLeaves",
+			req:      &pb.RemoveAccountMemberRequest{AccountId: account.ID, Username: signer.Username},
+			username: signer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					DeleteAccountMember(gomock.Any(), gomock.Eq(db.DeleteAccountMemberParams{AccountID: account.ID, Username: signer.Username})).
+					Times(1).
+					Return(signerMember, nil)
+			},
+			check: func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, signer.Username, res.GetMember().GetUsername())
+			},
+		},
+		{
+			name:     "SignerCannotRemoveOthers",
+			req:      &pb.RemoveAccountMemberRequest{AccountId: account.ID, Username: invitee.Username},
+			username: signer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "CoOwnerCannotRemoveOthers",
+			req:      &pb.RemoveAccountMemberRequest{AccountId: account.ID, Username: signer.Username},
+			username: coOwner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DeleteAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "PrimaryOwner",
+			req:      &pb.RemoveAccountMemberRequest{AccountId: account.ID, Username: owner.Username},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DeleteAccountMember(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name:     "NotMember",
+			req:      &pb.RemoveAccountMemberRequest{AccountId: account.ID, Username: invitee.Username},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DeleteAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InvalidUsername",
+			req:      &pb.RemoveAccountMemberRequest{AccountId: account.ID, Username: "#"},
+			username: owner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.RemoveAccountMemberResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, time.Minute)
+			res, err := server.RemoveAccountMember(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_SetApprovalThreshold(t *testing.T) {
+
+	owner, _ := createRandomUser(t)
+	account := db.Account{ID: 1, Owner: owner.Username, Balance: 1000, Currency: util.USD}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+	updated := account
+	updated.ApprovalThreshold = 50000
+	store.EXPECT().
+		SetAccountApprovalThreshold(gomock.Any(), gomock.Eq(db.SetAccountApprovalThresholdParams{ID: account.ID, ApprovalThreshold: 50000})).
+		Times(1).
+		Return(updated, nil)
+
+	server := newTestServer(t, store)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, owner.Username, time.Minute)
+	res, err := server.SetApprovalThreshold(ctx, &pb.SetApprovalThresholdRequest{AccountId: account.ID, ApprovalThreshold: 50000})
+	require.NoError(t, err)
+	require.Equal(t, int64(50000), res.GetApprovalThreshold())
+
+	_, err = server.SetApprovalThreshold(ctx, &pb.SetApprovalThresholdRequest{AccountId: account.ID, ApprovalThreshold: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_SetApprovalThresholdCoOwner(t *testing.T) {
+
+	owner, _ := createRandomUser(t)
+	coOwner, _ := createRandomUser(t)
+	account := db.Account{ID: 1, Owner: owner.Username, Balance: 1000, Currency: util.USD, ApprovalThreshold: 50000}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// a co-owner could otherwise turn off the approval that checks their own transfers
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().SetAccountApprovalThreshold(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, coOwner.Username, time.Minute)
+	_, err := server.SetApprovalThreshold(ctx, &pb.SetApprovalThresholdRequest{AccountId: account.ID, ApprovalThreshold: 0})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
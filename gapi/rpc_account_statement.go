@@ -0,0 +1,109 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const defaultStatementPeriod = 30 * 24 * time.Hour
+
+// GetAccountStatement lists the entries of an account in a period, newest
+// first. Any member of the account may read its statement
+func (server *Server) GetAccountStatement(ctx context.Context, req *pb.GetAccountStatementRequest) (*pb.GetAccountStatementResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountStatementRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if _, err = server.memberRole(ctx, account, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	fromTime, toTime := statementPeriod(req)
+
+	pageID, pageSize := req.GetPageId(), req.GetPageSize()
+	if pageID == 0 {
+		pageID = 1
+	}
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	rows, err := server.store.ListAccountStatement(ctx, db.ListAccountStatementParams{
+		AccountID: account.ID,
+		FromTime:  fromTime,
+		ToTime:    toTime,
+		Limit:     pageSize,
+		Offset:    (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list statement entries: %s", err)
+	}
+
+	res := &pb.GetAccountStatementResponse{
+		AccountId: account.ID,
+		Currency:  account.Currency,
+		Entries:   make([]*pb.StatementEntry, 0, len(rows)),
+	}
+	for i := range rows {
+		res.Entries = append(res.Entries, convertStatementEntry(&rows[i], account.Currency, server.currencies))
+	}
+	return res, nil
+}
+
+// statementPeriod fills in the bounds the client left out: the period ends now
+// and starts 30 days before its end
+func statementPeriod(req *pb.GetAccountStatementRequest) (fromTime, toTime time.Time) {
+	toTime = time.Now()
+	if req.GetToTime() != nil {
+		toTime = req.GetToTime().AsTime()
+	}
+
+	fromTime = toTime.Add(-defaultStatementPeriod)
+	if req.GetFromTime() != nil {
+		fromTime = req.GetFromTime().AsTime()
+	}
+	return fromTime, toTime
+}
+
+func validateGetAccountStatementRequest(req *pb.GetAccountStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetAccountId() <= 0 {
+		violations = append(violations, fieldViolation("account_id", errors.New("must be a positive integer")))
+	}
+
+	if req.FromTime != nil && req.ToTime != nil && !req.GetFromTime().AsTime().Before(req.GetToTime().AsTime()) {
+		violations = append(violations, fieldViolation("from_time", errors.New("must be before to_time")))
+	}
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", errors.New("must not be negative")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > 100 {
+		violations = append(violations, fieldViolation("page_size", errors.New("must be between 1 and 100")))
+	}
+
+	return violations
+}
@@ -0,0 +1,174 @@
+package gapi
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestServer_GetAccountStatement(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+	other, _ := createRandomUser(t)
+	account := db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    user.Username,
+		Balance:  util.RandomMoney(),
+		Currency: util.USD,
+	}
+
+	toTime := time.Now().Truncate(time.Second)
+	fromTime := toTime.Add(-7 * 24 * time.Hour)
+
+	rows := []db.ListAccountStatementRow{
+		{
+			ID:                    3,
+			AccountID:             account.ID,
+			Amount:                -1050,
+			EntryType:             db.EntryTypeTransfer,
+			TransferID:            sql.NullInt64{Int64: 9, Valid: true},
+			CreatedAt:             toTime,
+			CounterpartyAccountID: sql.NullInt64{Int64: 42, Valid: true},
+			CounterpartyOwner:     sql.NullString{String: other.Username, Valid: true},
+			CounterpartyName:      sql.NullString{String: other.FullName, Valid: true},
+		},
+		{
+			ID:         4,
+			AccountID:  account.ID,
+			Amount:     -25,
+			EntryType:  db.EntryTypeFee,
+			TransferID: sql.NullInt64{Int64: 9, Valid: true},
+			CreatedAt:  toTime,
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		req        *pb.GetAccountStatementRequest
+		username   string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.GetAccountStatementResponse, err error)
+	}{
+		{
+			name:     "OK",
+			req:      &pb.GetAccountStatementRequest{AccountId: account.ID, FromTime: timestamppb.New(fromTime), ToTime: timestamppb.New(toTime), PageId: 2, PageSize: 5},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListAccountStatement(gomock.Any(), gomock.Eq(db.ListAccountStatementParams{
+						AccountID: account.ID,
+						FromTime:  fromTime.UTC(),
+						ToTime:    toTime.UTC(),
+						Limit:     5,
+						Offset:    5,
+					})).
+					Times(1).
+					Return(rows, nil)
+			},
+			check: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.ID, res.GetAccountId())
+				require.Equal(t, account.Currency, res.GetCurrency())
+				require.Len(t, res.GetEntries(), 2)
+
+				transfer := res.GetEntries()[0]
+				require.Equal(t, db.EntryTypeTransfer, transfer.GetEntry().GetEntryType())
+				require.Equal(t, int64(9), transfer.GetEntry().GetTransferId())
+				require.Equal(t, int64(42), transfer.GetCounterpartyAccountId())
+				require.Equal(t, util.MaskName(other.FullName), transfer.GetCounterpartyName())
+				require.Equal(t, "-$10.50", transfer.GetFormattedAmount())
+
+				fee := res.GetEntries()[1]
+				require.Equal(t, db.EntryTypeFee, fee.GetEntry().GetEntryType())
+				require.Zero(t, fee.GetCounterpartyAccountId())
+				require.Empty(t, fee.GetCounterpartyName())
+			},
+		},
+		{
+			name:     "DefaultPeriod",
+			req:      &pb.GetAccountStatementRequest{AccountId: account.ID},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListAccountStatement(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ListAccountStatementParams) ([]db.ListAccountStatementRow, error) {
+						require.Equal(t, defaultStatementPeriod, arg.ToTime.Sub(arg.FromTime))
+						require.WithinDuration(t, time.Now(), arg.ToTime, time.Second)
+						require.Equal(t, int32(20), arg.Limit)
+						require.Zero(t, arg.Offset)
+						return []db.ListAccountStatementRow{}, nil
+					})
+			},
+			check: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetEntries())
+			},
+		},
+		{
+			name:     "NotMember",
+			req:      &pb.GetAccountStatementRequest{AccountId: account.ID},
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().ListAccountStatement(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "AccountNotFound",
+			req:      &pb.GetAccountStatementRequest{AccountId: account.ID},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().ListAccountStatement(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InvalidPeriod",
+			req:      &pb.GetAccountStatementRequest{AccountId: account.ID, FromTime: timestamppb.New(toTime), ToTime: timestamppb.New(fromTime)},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.GetAccountStatementResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, time.Minute)
+			res, err := server.GetAccountStatement(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
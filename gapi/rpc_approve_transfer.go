@@ -0,0 +1,100 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTransferApprovals lists the transfers out of an account waiting for a
+// second signer, oldest first
+func (server *Server) ListTransferApprovals(ctx context.Context, req *pb.ListTransferApprovalsRequest) (*pb.ListTransferApprovalsResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountID(req.GetAccountId())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = server.memberRole(ctx, account, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	approvals, err := server.store.ListPendingTransferApprovals(ctx, account.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer approvals: %s", err)
+	}
+
+	res := &pb.ListTransferApprovalsResponse{
+		Approvals: make([]*pb.TransferApproval, 0, len(approvals)),
+	}
+	for i := range approvals {
+		res.Approvals = append(res.Approvals, convertTransferApproval(&approvals[i]))
+	}
+	return res, nil
+}
+
+// ApproveTransfer approves or rejects a transfer waiting for a second signer
+func (server *Server) ApproveTransfer(ctx context.Context, req *pb.ApproveTransferRequest) (*pb.ApproveTransferResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateApproveTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ApproveTransferTx(ctx, db.ApproveTransferTxParams{
+		ID:       req.GetId(),
+		Approver: authPayload.Username,
+		Approve:  req.GetApprove(),
+	})
+	if err != nil {
+		var limitErr *db.LimitExceededError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Error(codes.NotFound, "transfer approval not found")
+		case errors.Is(err, db.ErrApprovalNotPending):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, db.ErrNotSigner), errors.Is(err, db.ErrSelfApproval):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.As(err, &limitErr):
+			return nil, limitExceededError(result.Approval.RequestedBy, limitErr)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to approve transfer: %s", err)
+	}
+
+	res := &pb.ApproveTransferResponse{
+		Approval: convertTransferApproval(&result.Approval),
+	}
+	if result.Approval.TransferID.Valid {
+		res.Transfer = convertTransfer(&result.Transfer.Transfer, result.Approval.Currency, server.currencies)
+	}
+	return res, nil
+}
+
+func validateApproveTransferRequest(req *pb.ApproveTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetId() <= 0 {
+		violations = append(violations, fieldViolation("id", errors.New("must be a positive integer")))
+	}
+
+	return violations
+}
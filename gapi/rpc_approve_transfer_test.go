@@ -0,0 +1,162 @@
+package gapi
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestServer_ApproveTransfer(t *testing.T) {
+
+	requester, _ := createRandomUser(t)
+	approver, _ := createRandomUser(t)
+
+	pending := db.TransferApproval{
+		ID:            7,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        50000,
+		Currency:      util.USD,
+		RequestedBy:   requester.Username,
+		Status:        db.ApprovalStatusPending,
+		CreatedAt:     time.Now(),
+	}
+
+	approved := pending
+	approved.Status = db.ApprovalStatusApproved
+	approved.DecidedBy = sql.NullString{String: approver.Username, Valid: true}
+	approved.DecidedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	approved.TransferID = sql.NullInt64{Int64: 11, Valid: true}
+
+	testCases := []struct {
+		name       string
+		req        *pb.ApproveTransferRequest
+		username   string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.ApproveTransferResponse, err error)
+	}{
+		{
+			name:     "Approve",
+			req:      &pb.ApproveTransferRequest{Id: pending.ID, Approve: true},
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveTransferTx(gomock.Any(), gomock.Eq(db.ApproveTransferTxParams{
+						ID:       pending.ID,
+						Approver: approver.Username,
+						Approve:  true,
+					})).
+					Times(1).
+					Return(db.ApproveTransferTxResult{
+						Approval: approved,
+						Transfer: db.TransferTxResult{Transfer: db.Transfer{ID: 11, Amount: pending.Amount}},
+					}, nil)
+			},
+			check: func(t *testing.T, res *pb.ApproveTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.ApprovalStatusApproved, res.GetApproval().GetStatus())
+				require.Equal(t, approver.Username, res.GetApproval().GetDecidedBy())
+				require.Equal(t, int64(11), res.GetTransfer().GetId())
+				require.Equal(t, "$500.00", res.GetTransfer().GetFormattedAmount())
+			},
+		},
+		{
+			name:     "SelfApproval",
+			req:      &pb.ApproveTransferRequest{Id: pending.ID, Approve: true},
+			username: requester.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{Approval: pending}, db.ErrSelfApproval)
+			},
+			check: func(t *testing.T, res *pb.ApproveTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "NotSigner",
+			req:      &pb.ApproveTransferRequest{Id: pending.ID, Approve: false},
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{Approval: pending}, db.ErrNotSigner)
+			},
+			check: func(t *testing.T, res *pb.ApproveTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "NotPending",
+			req:      &pb.ApproveTransferRequest{Id: pending.ID, Approve: true},
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{Approval: approved}, db.ErrApprovalNotPending)
+			},
+			check: func(t *testing.T, res *pb.ApproveTransferResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name:     "NotFound",
+			req:      &pb.ApproveTransferRequest{Id: 99, Approve: true},
+			username: approver.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveTransferTxResult{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, res *pb.ApproveTransferResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, time.Minute)
+			res, err := server.ApproveTransfer(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_ListTransferApprovals(t *testing.T) {
+
+	viewer, _ := createRandomUser(t)
+	account := db.Account{ID: 1, Owner: "someone_else", Balance: 1000, Currency: util.USD}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).
+		Return(db.AccountMember{AccountID: account.ID, Username: viewer.Username, Role: db.MemberRoleViewer, Status: db.MemberStatusActive}, nil)
+	store.EXPECT().ListPendingTransferApprovals(gomock.Any(), gomock.Eq(account.ID)).Times(1).
+		Return([]db.TransferApproval{{ID: 1, FromAccountID: account.ID, Status: db.ApprovalStatusPending}}, nil)
+
+	server := newTestServer(t, store)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, viewer.Username, time.Minute)
+	res, err := server.ListTransferApprovals(ctx, &pb.ListTransferApprovalsRequest{AccountId: account.ID})
+	require.NoError(t, err)
+	require.Len(t, res.GetApprovals(), 1)
+	require.Equal(t, db.ApprovalStatusPending, res.GetApprovals()[0].GetStatus())
+}
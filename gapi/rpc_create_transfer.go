@@ -0,0 +1,159 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/joefazee/simplebank/currency"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/risk"
+	"github.com/joefazee/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	transferStatusCompleted       = db.TransferStatusCompleted
+	transferStatusPendingReview   = db.TransferStatusPendingReview
+	transferStatusPendingApproval = db.TransferStatusPendingApproval
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req, server.currencies)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.transferAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := server.memberRole(ctx, fromAccount, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !db.CanSign(role) {
+		return nil, status.Error(codes.PermissionDenied, db.ErrNotSigner.Error())
+	}
+
+	if _, err = server.transferAccount(ctx, req.GetToAccountId(), req.GetCurrency()); err != nil {
+		return nil, err
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	result, err := server.screener.Transfer(ctx, risk.Request{
+		Username:      authPayload.Username,
+		FromAccountID: req.GetFromAccountId(),
+		ToAccountID:   req.GetToAccountId(),
+		Amount:        req.GetAmount(),
+		Currency:      req.GetCurrency(),
+		UserAgent:     mtdt.UserAgent,
+		ClientIP:      mtdt.ClientIP,
+		Memo:          req.GetMemo(),
+		Reference:     req.GetReference(),
+		Metadata:      req.GetMetadata(),
+	})
+	if err != nil {
+		var limitErr *db.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, limitExceededError(authPayload.Username, limitErr)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create transfer: %s", err)
+	}
+
+	switch result.Decision.Decision {
+	case db.RiskDecisionReview:
+		return &pb.CreateTransferResponse{
+			Status:   transferStatusPendingReview,
+			ReviewId: result.Decision.ID,
+		}, nil
+	case db.RiskDecisionBlock:
+		return nil, transferBlockedError(result.Decision.ID)
+	}
+
+	if result.Approval.ID != 0 {
+		return &pb.CreateTransferResponse{
+			Status:     transferStatusPendingApproval,
+			ApprovalId: result.Approval.ID,
+		}, nil
+	}
+
+	res := &pb.CreateTransferResponse{
+		Transfer:  convertTransfer(&result.Transfer.Transfer, req.GetCurrency(), server.currencies),
+		FromEntry: convertEntry(&result.Transfer.FromEntry),
+		ToEntry:   convertEntry(&result.Transfer.ToEntry),
+		Status:    transferStatusCompleted,
+		Fee:       convertTransferFee(&result.Transfer.Fee),
+	}
+	if result.Transfer.FeeEntry.ID != 0 {
+		res.FeeEntry = convertEntry(&result.Transfer.FeeEntry)
+	}
+	return res, nil
+}
+
+// transferAccount gets an account taking part in a transfer and checks its currency
+func (server *Server) transferAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err == nil && account.Kind == db.AccountKindGL {
+		// general ledger accounts are not there for customers
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest, currencies *currency.Registry) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetFromAccountId() <= 0 {
+		violations = append(violations, fieldViolation("from_account_id", errors.New("must be a positive integer")))
+	}
+
+	if req.GetToAccountId() <= 0 {
+		violations = append(violations, fieldViolation("to_account_id", errors.New("must be a positive integer")))
+	} else if req.GetToAccountId() == req.GetFromAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", errors.New("must differ from from_account_id")))
+	}
+
+	if req.GetAmount() <= 0 {
+		violations = append(violations, fieldViolation("amount", errors.New("must be greater than zero")))
+	}
+
+	if !currencies.IsSupported(req.GetCurrency()) {
+		violations = append(violations, fieldViolation("currency", fmt.Errorf("unsupported currency %q", req.GetCurrency())))
+	}
+
+	if err := val.ValidateTransferMemo(req.GetMemo()); err != nil {
+		violations = append(violations, fieldViolation("memo", err))
+	}
+
+	if err := val.ValidateTransferReference(req.GetReference()); err != nil {
+		violations = append(violations, fieldViolation("reference", err))
+	}
+
+	if err := val.ValidateTransferMetadata(req.GetMetadata()); err != nil {
+		violations = append(violations, fieldViolation("metadata", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,330 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_CreateTransfer(t *testing.T) {
+
+	user1, _ := createRandomUser(t)
+	user2, _ := createRandomUser(t)
+
+	account1 := db.Account{ID: 1, Owner: user1.Username, Balance: 1000, Currency: util.USD}
+	account2 := db.Account{ID: 2, Owner: user2.Username, Balance: 1000, Currency: util.USD}
+	account3 := db.Account{ID: 3, Owner: user2.Username, Balance: 1000, Currency: util.EUR}
+	amount := int64(10)
+
+	testCases := []struct {
+		name       string
+		req        *pb.CreateTransferRequest
+		username   string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name:     "OK",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.ScreenedTransferTxParams) (db.ScreenedTransferTxResult, error) {
+						require.Equal(t, user1.Username, arg.Username)
+						require.Equal(t, account1.ID, arg.FromAccountID)
+						require.Equal(t, account2.ID, arg.ToAccountID)
+						require.Equal(t, amount, arg.Amount)
+						require.Equal(t, db.RiskDecisionAllow, arg.Decision)
+
+						return db.ScreenedTransferTxResult{
+							Decision: db.RiskDecision{ID: 3, Decision: db.RiskDecisionAllow},
+							Transfer: db.TransferTxResult{
+								Transfer:  db.Transfer{ID: 9, FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: amount, Fee: 1},
+								FromEntry: db.Entry{ID: 17, AccountID: account1.ID, Amount: -amount},
+								ToEntry:   db.Entry{ID: 18, AccountID: account2.ID, Amount: amount},
+								Fee:       db.Fee{ScheduleID: 1, FlatAmount: 1, Total: 1},
+								FeeEntry:  db.Entry{ID: 19, AccountID: account1.ID, Amount: -1},
+							},
+						}, nil
+					})
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, transferStatusCompleted, res.GetStatus())
+				require.Equal(t, int64(9), res.GetTransfer().GetId())
+				require.Equal(t, -amount, res.GetFromEntry().GetAmount())
+				require.Equal(t, amount, res.GetToEntry().GetAmount())
+				require.Equal(t, int64(1), res.GetTransfer().GetFee())
+				require.Equal(t, "$0.10", res.GetTransfer().GetFormattedAmount())
+				require.Equal(t, int64(1), res.GetFee().GetTotal())
+				require.Equal(t, int64(-1), res.GetFeeEntry().GetAmount())
+			},
+		},
+		{
+			name:     "LimitExceeded",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ScreenedTransferTxResult{}, &db.LimitExceededError{
+					Period:    db.LimitMonthly,
+					Currency:  util.USD,
+					Limit:     1000,
+					Used:      995,
+					Remaining: 5,
+				})
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+
+				var info *errdetails.ErrorInfo
+				for _, detail := range st.Details() {
+					if d, ok := detail.(*errdetails.ErrorInfo); ok {
+						info = d
+					}
+				}
+				require.NotNil(t, info)
+				require.Equal(t, "TRANSFER_LIMIT_EXCEEDED", info.GetReason())
+				require.Equal(t, map[string]string{
+					"period":    db.LimitMonthly,
+					"currency":  util.USD,
+					"limit":     "1000",
+					"used":      "995",
+					"remaining": "5",
+				}, info.GetMetadata())
+			},
+		},
+		{
+			name:     "HeldForReview",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.GetTransferRiskSignalsRow{PayeeTransfers: 1, PreviousSessions: 2}, nil)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.ScreenedTransferTxParams) (db.ScreenedTransferTxResult, error) {
+						require.Equal(t, db.RiskDecisionReview, arg.Decision)
+						require.JSONEq(t, `[
+							{"rule":"new_device","decision":"review","reason":"user agent not seen in earlier sessions"},
+							{"rule":"unusual_ip","decision":"review","reason":"IP address not seen in earlier sessions"}
+						]`, string(arg.Hits))
+
+						return db.ScreenedTransferTxResult{Decision: db.RiskDecision{ID: 4, Decision: arg.Decision}}, nil
+					})
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, transferStatusPendingReview, res.GetStatus())
+				require.Equal(t, int64(4), res.GetReviewId())
+				require.Nil(t, res.GetTransfer())
+			},
+		},
+		{
+			name:     "Blocked",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ScreenedTransferTxResult{Decision: db.RiskDecision{ID: 5, Decision: db.RiskDecisionBlock}}, nil)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "PermissionDenied",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "JointAccountViewer",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.AccountMember{AccountID: account1.ID, Username: user2.Username, Role: db.MemberRoleViewer, Status: db.MemberStatusActive}, nil)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "JointAccountSignerPendingApproval",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.AccountMember{AccountID: account1.ID, Username: user2.Username, Role: db.MemberRoleSigner, Status: db.MemberStatusActive}, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ScreenedTransferTxResult{
+					Decision: db.RiskDecision{ID: 3, Decision: db.RiskDecisionAllow},
+					Approval: db.TransferApproval{ID: 5, Status: db.ApprovalStatusPending},
+				}, nil)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, transferStatusPendingApproval, res.GetStatus())
+				require.Equal(t, int64(5), res.GetApprovalId())
+				require.Nil(t, res.GetTransfer())
+			},
+		},
+		{
+			name:     "CurrencyMismatch",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account3.ID, Amount: amount, Currency: util.USD},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name:     "FromGLAccount",
+			req:      &pb.CreateTransferRequest{FromAccountId: 4, ToAccountId: account2.ID, Amount: amount, Currency: util.USD},
+			username: db.SystemOwner,
+			buildStubs: func(store *mockdb.MockStore) {
+				glAccount := db.Account{ID: 4, Owner: db.SystemOwner, Currency: util.USD, Kind: db.AccountKindGL}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(glAccount.ID)).Times(1).Return(glAccount, nil)
+				store.EXPECT().ScreenedTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InvalidArgument",
+			req:      &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account1.ID, Amount: 0, Currency: "XYZ"},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+
+				badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+				require.True(t, ok)
+				require.Len(t, badRequest.GetFieldViolations(), 3)
+			},
+		},
+		{
+			name: "WithDetails",
+			req: &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD,
+				Memo: "rent for march", Reference: "INV-2031", Metadata: map[string]string{"order_id": "77"}},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					ScreenedTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.ScreenedTransferTxParams) (db.ScreenedTransferTxResult, error) {
+						require.Equal(t, "rent for march", arg.Memo)
+						require.Equal(t, "INV-2031", arg.Reference)
+						require.JSONEq(t, `{"order_id":"77"}`, string(arg.Metadata))
+
+						return db.ScreenedTransferTxResult{
+							Decision: db.RiskDecision{ID: 3, Decision: db.RiskDecisionAllow},
+							Transfer: db.TransferTxResult{
+								Transfer: db.Transfer{ID: 9, FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: amount,
+									Memo: arg.Memo, Reference: arg.Reference, Metadata: arg.Metadata},
+							},
+						}, nil
+					})
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "rent for march", res.GetTransfer().GetMemo())
+				require.Equal(t, "INV-2031", res.GetTransfer().GetReference())
+				require.Equal(t, map[string]string{"order_id": "77"}, res.GetTransfer().GetMetadata())
+			},
+		},
+		{
+			name: "InvalidDetails",
+			req: &pb.CreateTransferRequest{FromAccountId: account1.ID, ToAccountId: account2.ID, Amount: amount, Currency: util.USD,
+				Memo: "line one\nline two", Reference: strings.Repeat("r", 141), Metadata: map[string]string{"": "empty key"}},
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+
+				badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+				require.True(t, ok)
+
+				var fields []string
+				for _, violation := range badRequest.GetFieldViolations() {
+					fields = append(fields, violation.GetField())
+				}
+				require.Equal(t, []string{"memo", "reference", "metadata"}, fields)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, time.Minute)
+			res, err := server.CreateTransfer(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/kyc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/val"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+// kycContentTypes are the file types accepted for documents. The type is
+// detected from the content, not taken from the client.
+var kycContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// SubmitKycDocument stores an identity document of the authenticated user and
+// queues it for review
+func (server *Server) SubmitKycDocument(ctx context.Context, req *pb.SubmitKycDocumentRequest) (*pb.SubmitKycDocumentResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSubmitKycDocumentRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	key, err := kyc.NewKey(authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store document: %s", err)
+	}
+
+	size, sha, err := server.documents.Save(ctx, key, bytes.NewReader(req.GetContent()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store document: %s", err)
+	}
+
+	document, err := server.store.SubmitKycDocumentTx(ctx, db.CreateKycDocumentParams{
+		Username:    authPayload.Username,
+		Kind:        req.GetKind(),
+		FileName:    req.GetFileName(),
+		ContentType: http.DetectContentType(req.GetContent()),
+		SizeBytes:   size,
+		Sha256:      sha,
+		StorageKey:  key,
+	})
+	if err != nil {
+		if deleteErr := server.documents.Delete(ctx, key); deleteErr != nil {
+			log.Error().Err(deleteErr).Str("storage_key", key).Msg("failed to delete orphaned document")
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to submit document: %s", err)
+	}
+
+	res := &pb.SubmitKycDocumentResponse{
+		Document: convertKycDocument(&document),
+	}
+	return res, nil
+}
+
+// GetKycStatus returns the verification status and tier of the authenticated user
+func (server *Server) GetKycStatus(ctx context.Context, req *pb.GetKycStatusRequest) (*pb.GetKycStatusResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	kycStatus, err := server.store.GetUserKyc(ctx, authPayload.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get KYC status: %s", err)
+	}
+
+	documents, err := server.store.ListKycDocuments(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list documents: %s", err)
+	}
+
+	res := &pb.GetKycStatusResponse{
+		Status:        kycStatus.KycStatus,
+		Tier:          kycStatus.KycTier,
+		EffectiveTier: db.EffectiveKycTier(kycStatus.KycTier, kycStatus.EmailVerified),
+		EmailVerified: kycStatus.EmailVerified,
+		Documents:     make([]*pb.KycDocument, 0, len(documents)),
+	}
+	for i := range documents {
+		res.Documents = append(res.Documents, convertKycDocument(&documents[i]))
+	}
+	return res, nil
+}
+
+// ListKycReviews lists the documents waiting for review, oldest first
+func (server *Server) ListKycReviews(ctx context.Context, req *pb.ListKycReviewsRequest) (*pb.ListKycReviewsResponse, error) {
+
+	if _, err := server.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	violations := validateListKycReviewsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageID, pageSize := req.GetPageId(), req.GetPageSize()
+	if pageID == 0 {
+		pageID = 1
+	}
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	documents, err := server.store.ListPendingKycDocuments(ctx, db.ListPendingKycDocumentsParams{
+		Limit:  pageSize,
+		Offset: (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list KYC reviews: %s", err)
+	}
+
+	res := &pb.ListKycReviewsResponse{
+		Documents: make([]*pb.KycDocument, 0, len(documents)),
+	}
+	for i := range documents {
+		res.Documents = append(res.Documents, convertKycDocument(&documents[i]))
+	}
+	return res, nil
+}
+
+// ReviewKycDocument approves or rejects a submitted document
+func (server *Server) ReviewKycDocument(ctx context.Context, req *pb.ReviewKycDocumentRequest) (*pb.ReviewKycDocumentResponse, error) {
+
+	authPayload, err := server.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateReviewKycDocumentRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ReviewKycDocumentTx(ctx, db.ReviewKycDocumentTxParams{
+		DocumentID: req.GetId(),
+		Reviewer:   authPayload.Username,
+		Approve:    req.GetApprove(),
+		Tier:       req.GetTier(),
+		Note:       req.GetNote(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Error(codes.NotFound, "document not found")
+		case errors.Is(err, db.ErrKycDocumentNotPending):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to review document: %s", err)
+	}
+
+	res := &pb.ReviewKycDocumentResponse{
+		Document:   convertKycDocument(&result.Document),
+		UserStatus: result.User.KycStatus,
+		UserTier:   result.User.KycTier,
+	}
+	return res, nil
+}
+
+func validateSubmitKycDocumentRequest(req *pb.SubmitKycDocumentRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if !db.IsKycDocumentKind(req.GetKind()) {
+		violations = append(violations, fieldViolation("kind",
+			errors.New("must be passport, national_id, drivers_license or proof_of_address")))
+	}
+
+	if err := val.ValidateString(req.GetFileName(), 1, 255); err != nil {
+		violations = append(violations, fieldViolation("file_name", err))
+	}
+
+	switch content := req.GetContent(); {
+	case len(content) == 0:
+		violations = append(violations, fieldViolation("content", errors.New("must not be empty")))
+	case len(content) > kyc.MaxDocumentSize:
+		violations = append(violations, fieldViolation("content",
+			fmt.Errorf("must not be larger than %d bytes", kyc.MaxDocumentSize)))
+	case !kycContentTypes[http.DetectContentType(content)]:
+		violations = append(violations, fieldViolation("content", errors.New("must be a JPEG, PNG or PDF file")))
+	}
+
+	return violations
+}
+
+func validateListKycReviewsRequest(req *pb.ListKycReviewsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", errors.New("must not be negative")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > 100 {
+		violations = append(violations, fieldViolation("page_size", errors.New("must be between 1 and 100")))
+	}
+
+	return violations
+}
+
+func validateReviewKycDocumentRequest(req *pb.ReviewKycDocumentRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetId() <= 0 {
+		violations = append(violations, fieldViolation("id", errors.New("must be a positive integer")))
+	}
+
+	if req.GetApprove() && (req.GetTier() < db.KycTierBasic || req.GetTier() > db.KycTierFull) {
+		violations = append(violations, fieldViolation("tier", errors.New("must be 1 or 2 when approving")))
+	}
+
+	if err := val.ValidateString(req.GetNote(), 0, maxReviewNoteLength); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
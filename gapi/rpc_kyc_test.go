@@ -0,0 +1,264 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/kyc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pngContent starts with the PNG signature, which is all content type detection looks at
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), []byte("image data")...)
+
+func TestServer_SubmitKycDocument(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	testCases := []struct {
+		name       string
+		req        *pb.SubmitKycDocumentRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, server *Server, res *pb.SubmitKycDocumentResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.SubmitKycDocumentRequest{Kind: db.KycDocumentPassport, FileName: "passport.png", Content: pngContent},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SubmitKycDocumentTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateKycDocumentParams) (db.KycDocument, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, "image/png", arg.ContentType)
+						require.Equal(t, int64(len(pngContent)), arg.SizeBytes)
+						require.True(t, strings.HasPrefix(arg.StorageKey, user.Username+"/"))
+
+						return db.KycDocument{
+							ID:          1,
+							Username:    arg.Username,
+							Kind:        arg.Kind,
+							FileName:    arg.FileName,
+							ContentType: arg.ContentType,
+							SizeBytes:   arg.SizeBytes,
+							Sha256:      arg.Sha256,
+							StorageKey:  arg.StorageKey,
+							Status:      db.KycDocumentStatusPending,
+							CreatedAt:   time.Now(),
+						}, nil
+					})
+			},
+			check: func(t *testing.T, server *Server, res *pb.SubmitKycDocumentResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.KycDocumentStatusPending, res.GetDocument().GetStatus())
+				require.Len(t, res.GetDocument().GetSha256(), 64)
+			},
+		},
+		{
+			name: "StoreFailed",
+			req:  &pb.SubmitKycDocumentRequest{Kind: db.KycDocumentPassport, FileName: "passport.png", Content: pngContent},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SubmitKycDocumentTx(gomock.Any(), gomock.Any()).Times(1).Return(db.KycDocument{}, sql.ErrConnDone)
+			},
+			check: func(t *testing.T, server *Server, res *pb.SubmitKycDocumentResponse, err error) {
+				require.Equal(t, codes.Internal, status.Code(err))
+
+				// the stored file is removed again
+				entries, readErr := os.ReadDir(filepath.Join(server.config.KYCStorageDir, user.Username))
+				require.NoError(t, readErr)
+				require.Empty(t, entries)
+			},
+		},
+		{
+			name: "NotAnImage",
+			req:  &pb.SubmitKycDocumentRequest{Kind: db.KycDocumentPassport, FileName: "passport.txt", Content: []byte("plain text")},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SubmitKycDocumentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, server *Server, res *pb.SubmitKycDocumentResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "TooLarge",
+			req:  &pb.SubmitKycDocumentRequest{Kind: db.KycDocumentPassport, FileName: "passport.png", Content: append(pngContent, make([]byte, kyc.MaxDocumentSize)...)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SubmitKycDocumentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, server *Server, res *pb.SubmitKycDocumentResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "UnknownKind",
+			req:  &pb.SubmitKycDocumentRequest{Kind: "library_card", FileName: "card.png", Content: pngContent},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SubmitKycDocumentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, server *Server, res *pb.SubmitKycDocumentResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+			res, err := server.SubmitKycDocument(ctx, tc.req)
+			tc.check(t, server, res, err)
+		})
+	}
+}
+
+func TestServer_GetKycStatus(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, _ := createRandomUser(t)
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserKyc(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(db.GetUserKycRow{Username: user.Username, KycStatus: db.KycStatusVerified, KycTier: db.KycTierBasic}, nil)
+	store.EXPECT().
+		ListKycDocuments(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return([]db.KycDocument{{ID: 1, Username: user.Username, Status: db.KycDocumentStatusApproved}}, nil)
+
+	server := newTestServer(t, store)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+	res, err := server.GetKycStatus(ctx, &pb.GetKycStatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, db.KycStatusVerified, res.GetStatus())
+	require.Equal(t, db.KycTierBasic, res.GetTier())
+	// the email is not verified yet, so the user stays at tier 0
+	require.False(t, res.GetEmailVerified())
+	require.Equal(t, db.KycTierNone, res.GetEffectiveTier())
+	require.Len(t, res.GetDocuments(), 1)
+}
+
+func TestServer_ReviewKycDocument(t *testing.T) {
+
+	admin, _ := createRandomUser(t)
+	admin.Role = util.AdminRole
+	depositor, _ := createRandomUser(t)
+	depositor.Role = util.DepositorRole
+
+	testCases := []struct {
+		name       string
+		req        *pb.ReviewKycDocumentRequest
+		user       db.User
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.ReviewKycDocumentResponse, err error)
+	}{
+		{
+			name: "Approve",
+			req:  &pb.ReviewKycDocumentRequest{Id: 1, Approve: true, Tier: db.KycTierBasic, Note: "passport matches"},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewKycDocumentTx(gomock.Any(), gomock.Eq(db.ReviewKycDocumentTxParams{
+						DocumentID: 1,
+						Reviewer:   admin.Username,
+						Approve:    true,
+						Tier:       db.KycTierBasic,
+						Note:       "passport matches",
+					})).
+					Times(1).
+					Return(db.ReviewKycDocumentTxResult{
+						Document: db.KycDocument{
+							ID:         1,
+							Username:   depositor.Username,
+							Status:     db.KycDocumentStatusApproved,
+							ReviewedBy: sql.NullString{String: admin.Username, Valid: true},
+							ReviewedAt: sql.NullTime{Time: time.Now(), Valid: true},
+						},
+						User: db.User{Username: depositor.Username, KycStatus: db.KycStatusVerified, KycTier: db.KycTierBasic},
+					}, nil)
+			},
+			check: func(t *testing.T, res *pb.ReviewKycDocumentResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.KycDocumentStatusApproved, res.GetDocument().GetStatus())
+				require.Equal(t, admin.Username, res.GetDocument().GetReviewedBy())
+				require.Equal(t, db.KycStatusVerified, res.GetUserStatus())
+				require.Equal(t, db.KycTierBasic, res.GetUserTier())
+			},
+		},
+		{
+			name: "AlreadyReviewed",
+			req:  &pb.ReviewKycDocumentRequest{Id: 1},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycDocumentTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ReviewKycDocumentTxResult{}, db.ErrKycDocumentNotPending)
+			},
+			check: func(t *testing.T, res *pb.ReviewKycDocumentResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "ApproveWithoutTier",
+			req:  &pb.ReviewKycDocumentRequest{Id: 1, Approve: true},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycDocumentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.ReviewKycDocumentResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "NotAdmin",
+			req:  &pb.ReviewKycDocumentRequest{Id: 1, Approve: true, Tier: db.KycTierFull},
+			user: depositor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycDocumentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.ReviewKycDocumentResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(tc.user.Username)).Times(1).Return(tc.user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.user.Username, time.Minute)
+			res, err := server.ReviewKycDocument(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
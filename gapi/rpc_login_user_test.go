@@ -32,7 +32,7 @@ func TestServer_LoginUser(t *testing.T) {
 					GetUser(gomock.Any(), gomock.Eq(user.Username)).
 					Times(1).
 					Return(user, nil)
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(1)
 			},
 			checkResponse: func(rr *pb.LoginUserResponse, err error) {
 				require.NoError(t, err)
@@ -51,7 +51,7 @@ func TestServer_LoginUser(t *testing.T) {
 					GetUser(gomock.Any(), gomock.Eq(user.Username)).
 					Times(1).
 					Return(user, nil)
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *pb.LoginUserResponse, err error) {
 				require.NotNil(t, err)
@@ -70,7 +70,7 @@ func TestServer_LoginUser(t *testing.T) {
 					Times(1).
 					Return(db.User{}, sql.ErrNoRows)
 
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *pb.LoginUserResponse, err error) {
 				require.NotNil(t, err)
@@ -89,7 +89,7 @@ func TestServer_LoginUser(t *testing.T) {
 					Times(1).
 					Return(db.User{}, sql.ErrConnDone)
 
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *pb.LoginUserResponse, err error) {
 				require.NotNil(t, err)
@@ -107,7 +107,7 @@ func TestServer_LoginUser(t *testing.T) {
 				store.EXPECT().
 					GetUser(gomock.Any(), gomock.Any()).
 					Times(0)
-				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSessionTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(rr *pb.LoginUserResponse, err error) {
 				require.NotNil(t, err)
@@ -0,0 +1,192 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListNotifications lists the in-app notifications of the authenticated user,
+// newest first
+func (server *Server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListNotificationsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageID, pageSize := req.GetPageId(), req.GetPageSize()
+	if pageID == 0 {
+		pageID = 1
+	}
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	notifications, err := server.store.ListNotifications(ctx, db.ListNotificationsParams{
+		Username:   authPayload.Username,
+		UnreadOnly: req.GetUnreadOnly(),
+		Limit:      pageSize,
+		Offset:     (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list notifications: %s", err)
+	}
+
+	unread, err := server.store.CountUnreadNotifications(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count unread notifications: %s", err)
+	}
+
+	res := &pb.ListNotificationsResponse{
+		Notifications: make([]*pb.Notification, 0, len(notifications)),
+		UnreadCount:   unread,
+	}
+	for i := range notifications {
+		res.Notifications = append(res.Notifications, convertNotification(&notifications[i]))
+	}
+	return res, nil
+}
+
+// MarkNotificationsRead marks notifications of the authenticated user as read.
+// Ids of notifications that belong to someone else are ignored
+func (server *Server) MarkNotificationsRead(ctx context.Context, req *pb.MarkNotificationsReadRequest) (*pb.MarkNotificationsReadResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateMarkNotificationsReadRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var updated int64
+	if req.GetAll() {
+		updated, err = server.store.MarkAllNotificationsRead(ctx, authPayload.Username)
+	} else {
+		updated, err = server.store.MarkNotificationsRead(ctx, db.MarkNotificationsReadParams{
+			Username: authPayload.Username,
+			Ids:      req.GetIds(),
+		})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to mark notifications read: %s", err)
+	}
+
+	return &pb.MarkNotificationsReadResponse{Updated: updated}, nil
+}
+
+// ListNotificationPreferences lists whether the authenticated user is notified
+// about each event on each channel
+func (server *Server) ListNotificationPreferences(ctx context.Context, req *pb.ListNotificationPreferencesRequest) (*pb.ListNotificationPreferencesResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	preferences, err := server.store.ListNotificationPreferences(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list notification preferences: %s", err)
+	}
+
+	res := &pb.ListNotificationPreferencesResponse{
+		Preferences: convertNotificationPreferences(preferences),
+	}
+	return res, nil
+}
+
+// UpdateNotificationPreferences turns notifications about events on channels
+// on or off for the authenticated user
+func (server *Server) UpdateNotificationPreferences(ctx context.Context, req *pb.UpdateNotificationPreferencesRequest) (*pb.UpdateNotificationPreferencesResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateNotificationPreferencesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	for _, preference := range req.GetPreferences() {
+		_, err = server.store.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+			Username:  authPayload.Username,
+			EventType: preference.GetEventType(),
+			Channel:   preference.GetChannel(),
+			Enabled:   preference.GetEnabled(),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update notification preference: %s", err)
+		}
+	}
+
+	preferences, err := server.store.ListNotificationPreferences(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list notification preferences: %s", err)
+	}
+
+	res := &pb.UpdateNotificationPreferencesResponse{
+		Preferences: convertNotificationPreferences(preferences),
+	}
+	return res, nil
+}
+
+func validateListNotificationsRequest(req *pb.ListNotificationsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", errors.New("must not be negative")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > 100 {
+		violations = append(violations, fieldViolation("page_size", errors.New("must be between 1 and 100")))
+	}
+
+	return violations
+}
+
+func validateMarkNotificationsReadRequest(req *pb.MarkNotificationsReadRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetAll() == (len(req.GetIds()) > 0) {
+		violations = append(violations, fieldViolation("ids", errors.New("pass either ids or all")))
+	}
+
+	if len(req.GetIds()) > 100 {
+		violations = append(violations, fieldViolation("ids", errors.New("must not have more than 100 ids")))
+	}
+
+	return violations
+}
+
+func validateUpdateNotificationPreferencesRequest(req *pb.UpdateNotificationPreferencesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if len(req.GetPreferences()) == 0 {
+		violations = append(violations, fieldViolation("preferences", errors.New("must not be empty")))
+	}
+
+	for i, preference := range req.GetPreferences() {
+		if !db.IsNotificationEvent(preference.GetEventType()) {
+			violations = append(violations, fieldViolation(fmt.Sprintf("preferences[%d].event_type", i),
+				fmt.Errorf("unknown event %q", preference.GetEventType())))
+		}
+		if !db.IsNotificationChannel(preference.GetChannel()) {
+			violations = append(violations, fieldViolation(fmt.Sprintf("preferences[%d].channel", i),
+				fmt.Errorf("must be one of %v", db.NotificationChannels)))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,284 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestServer_ListNotifications(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	notifications := []db.Notification{
+		{ID: 2, Username: user.Username, EventType: db.NotificationTransferReceived, Title: "Money received", Data: []byte(`{"transfer_id":9}`), InApp: true, CreatedAt: time.Now()},
+		{ID: 1, Username: user.Username, EventType: db.NotificationNewDeviceLogin, Title: "New login", Data: []byte(`{}`), InApp: true,
+			ReadAt: sql.NullTime{Time: time.Now(), Valid: true}, CreatedAt: time.Now()},
+	}
+
+	testCases := []struct {
+		name       string
+		req        *pb.ListNotificationsRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.ListNotificationsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ListNotificationsRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListNotifications(gomock.Any(), gomock.Eq(db.ListNotificationsParams{Username: user.Username, Limit: 20, Offset: 0})).
+					Times(1).
+					Return(notifications, nil)
+				store.EXPECT().CountUnreadNotifications(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(int64(1), nil)
+			},
+			check: func(t *testing.T, res *pb.ListNotificationsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetNotifications(), 2)
+				require.Equal(t, int64(1), res.GetUnreadCount())
+				require.False(t, res.GetNotifications()[0].GetRead())
+				require.Equal(t, `{"transfer_id":9}`, res.GetNotifications()[0].GetData())
+				require.True(t, res.GetNotifications()[1].GetRead())
+				require.NotNil(t, res.GetNotifications()[1].GetReadAt())
+			},
+		},
+		{
+			name: "UnreadOnly",
+			req:  &pb.ListNotificationsRequest{UnreadOnly: true, PageId: 2, PageSize: 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListNotifications(gomock.Any(), gomock.Eq(db.ListNotificationsParams{Username: user.Username, UnreadOnly: true, Limit: 5, Offset: 5})).
+					Times(1).
+					Return([]db.Notification{}, nil)
+				store.EXPECT().CountUnreadNotifications(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			check: func(t *testing.T, res *pb.ListNotificationsResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetNotifications())
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			req:  &pb.ListNotificationsRequest{PageSize: 101},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListNotifications(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.ListNotificationsResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+			res, err := server.ListNotifications(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_MarkNotificationsRead(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	testCases := []struct {
+		name       string
+		req        *pb.MarkNotificationsReadRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.MarkNotificationsReadResponse, err error)
+	}{
+		{
+			name: "Ids",
+			req:  &pb.MarkNotificationsReadRequest{Ids: []int64{1, 2}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MarkNotificationsRead(gomock.Any(), gomock.Eq(db.MarkNotificationsReadParams{Username: user.Username, Ids: []int64{1, 2}})).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			check: func(t *testing.T, res *pb.MarkNotificationsReadResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(2), res.GetUpdated())
+			},
+		},
+		{
+			name: "All",
+			req:  &pb.MarkNotificationsReadRequest{All: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MarkAllNotificationsRead(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(int64(5), nil)
+			},
+			check: func(t *testing.T, res *pb.MarkNotificationsReadResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(5), res.GetUpdated())
+			},
+		},
+		{
+			name: "IdsAndAll",
+			req:  &pb.MarkNotificationsReadRequest{Ids: []int64{1}, All: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MarkNotificationsRead(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().MarkAllNotificationsRead(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.MarkNotificationsReadResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "Nothing",
+			req:  &pb.MarkNotificationsReadRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MarkNotificationsRead(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.MarkNotificationsReadResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+			res, err := server.MarkNotificationsRead(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_UpdateNotificationPreferences(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	testCases := []struct {
+		name       string
+		req        *pb.UpdateNotificationPreferencesRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.UpdateNotificationPreferencesResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.UpdateNotificationPreferencesRequest{Preferences: []*pb.NotificationPreference{
+				{EventType: db.NotificationTransferReceived, Channel: db.ChannelSMS, Enabled: true},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				preference := db.NotificationPreference{
+					Username:  user.Username,
+					EventType: db.NotificationTransferReceived,
+					Channel:   db.ChannelSMS,
+					Enabled:   true,
+				}
+				store.EXPECT().
+					UpsertNotificationPreference(gomock.Any(), gomock.Eq(db.UpsertNotificationPreferenceParams{
+						Username:  user.Username,
+						EventType: db.NotificationTransferReceived,
+						Channel:   db.ChannelSMS,
+						Enabled:   true,
+					})).
+					Times(1).
+					Return(preference, nil)
+				store.EXPECT().
+					ListNotificationPreferences(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.NotificationPreference{preference}, nil)
+			},
+			check: func(t *testing.T, res *pb.UpdateNotificationPreferencesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetPreferences(), len(db.NotificationEvents)*len(db.NotificationChannels))
+				for _, preference := range res.GetPreferences() {
+					switch {
+					case preference.GetEventType() == db.NotificationTransferReceived && preference.GetChannel() == db.ChannelSMS:
+						require.True(t, preference.GetEnabled())
+					case preference.GetChannel() == db.ChannelSMS:
+						require.False(t, preference.GetEnabled())
+					default:
+						require.True(t, preference.GetEnabled())
+					}
+				}
+			},
+		},
+		{
+			name: "UnknownChannel",
+			req: &pb.UpdateNotificationPreferencesRequest{Preferences: []*pb.NotificationPreference{
+				{EventType: db.NotificationTransferReceived, Channel: "pigeon", Enabled: true},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.UpdateNotificationPreferencesResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "UnknownEvent",
+			req: &pb.UpdateNotificationPreferencesRequest{Preferences: []*pb.NotificationPreference{
+				{EventType: "birthday", Channel: db.ChannelEmail},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.UpdateNotificationPreferencesResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+			res, err := server.UpdateNotificationPreferences(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_ListNotificationPreferencesUnauthenticated(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListNotificationPreferences(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+
+	_, err := server.ListNotificationPreferences(context.Background(), &pb.ListNotificationPreferencesRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
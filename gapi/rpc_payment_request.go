@@ -0,0 +1,291 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/joefazee/simplebank/currency"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/risk"
+	"github.com/joefazee/simplebank/val"
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const (
+	defaultPaymentRequestExpiryDays = 7
+	maxPaymentRequestExpiryDays     = 30
+	maxPaymentRequestMemoLength     = 140
+
+	paymentRequestsIncoming = "incoming"
+	paymentRequestsOutgoing = "outgoing"
+)
+
+// CreatePaymentRequest asks another user to pay into an account the
+// authenticated user can sign for
+func (server *Server) CreatePaymentRequest(ctx context.Context, req *pb.CreatePaymentRequestRequest) (*pb.CreatePaymentRequestResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreatePaymentRequestRequest(req, authPayload.Username, server.currencies)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getAccount(ctx, req.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := server.memberRole(ctx, account, authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !db.CanSign(role) {
+		return nil, status.Error(codes.PermissionDenied, db.ErrNotSigner.Error())
+	}
+
+	if account.Currency != req.GetCurrency() {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("currency", fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, req.GetCurrency())),
+		})
+	}
+
+	expiryDays := req.GetExpiresInDays()
+	if expiryDays == 0 {
+		expiryDays = defaultPaymentRequestExpiryDays
+	}
+
+	request, err := server.store.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		Requester:   authPayload.Username,
+		Payer:       req.GetPayer(),
+		ToAccountID: account.ID,
+		Amount:      req.GetAmount(),
+		Currency:    req.GetCurrency(),
+		Memo:        req.GetMemo(),
+		ExpiresAt:   time.Now().AddDate(0, 0, int(expiryDays)),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetPayer())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create payment request: %s", err)
+	}
+
+	res := &pb.CreatePaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(&request, server.currencies),
+	}
+	return res, nil
+}
+
+// ListPaymentRequests lists the payment requests sent to or by the
+// authenticated user, newest first
+func (server *Server) ListPaymentRequests(ctx context.Context, req *pb.ListPaymentRequestsRequest) (*pb.ListPaymentRequestsResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListPaymentRequestsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageID, pageSize := req.GetPageId(), req.GetPageSize()
+	if pageID == 0 {
+		pageID = 1
+	}
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	var requests []db.PaymentRequest
+	if req.GetDirection() == paymentRequestsOutgoing {
+		requests, err = server.store.ListOutgoingPaymentRequests(ctx, db.ListOutgoingPaymentRequestsParams{
+			Requester: authPayload.Username,
+			Limit:     pageSize,
+			Offset:    (pageID - 1) * pageSize,
+		})
+	} else {
+		requests, err = server.store.ListIncomingPaymentRequests(ctx, db.ListIncomingPaymentRequestsParams{
+			Payer:  authPayload.Username,
+			Limit:  pageSize,
+			Offset: (pageID - 1) * pageSize,
+		})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payment requests: %s", err)
+	}
+
+	res := &pb.ListPaymentRequestsResponse{
+		PaymentRequests: make([]*pb.PaymentRequest, 0, len(requests)),
+	}
+	for i := range requests {
+		res.PaymentRequests = append(res.PaymentRequests, convertPaymentRequest(&requests[i], server.currencies))
+	}
+	return res, nil
+}
+
+// DeclinePaymentRequest declines a payment request sent to the authenticated user
+func (server *Server) DeclinePaymentRequest(ctx context.Context, req *pb.DeclinePaymentRequestRequest) (*pb.DeclinePaymentRequestResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if req.GetId() <= 0 {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation("id", errors.New("must be a positive integer")),
+		})
+	}
+
+	request, err := server.store.DeclinePaymentRequestTx(ctx, db.DeclinePaymentRequestTxParams{
+		ID:    req.GetId(),
+		Payer: authPayload.Username,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Error(codes.NotFound, "payment request not found")
+		case errors.Is(err, db.ErrPaymentRequestNotPending):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to decline payment request: %s", err)
+	}
+
+	res := &pb.DeclinePaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(&request, server.currencies),
+	}
+	return res, nil
+}
+
+// PayPaymentRequest pays a payment request sent to the authenticated user.
+// The payment is screened like a transfer and refused when screening does not
+// allow it or the account needs a second signer for it.
+func (server *Server) PayPaymentRequest(ctx context.Context, req *pb.PayPaymentRequestRequest) (*pb.PayPaymentRequestResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validatePayPaymentRequestRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// a missing account is reported here, so not found below means the request
+	if _, err = server.getAccount(ctx, req.GetFromAccountId()); err != nil {
+		return nil, err
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	result, err := server.screener.PayPaymentRequest(ctx, req.GetId(), risk.Request{
+		Username:      authPayload.Username,
+		FromAccountID: req.GetFromAccountId(),
+		UserAgent:     mtdt.UserAgent,
+		ClientIP:      mtdt.ClientIP,
+	})
+	if err != nil {
+		var limitErr *db.LimitExceededError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Error(codes.NotFound, "payment request not found")
+		case errors.Is(err, db.ErrPaymentRequestNotPending), errors.Is(err, db.ErrPaymentRequestExpired):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, db.ErrNotSigner):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, db.ErrPaymentNeedsApproval):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, db.ErrPaymentAccount):
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("from_account_id", err)})
+		case errors.As(err, &limitErr):
+			return nil, limitExceededError(authPayload.Username, limitErr)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to pay payment request: %s", err)
+	}
+
+	// a payment request cannot wait for a review, so a held payment is refused
+	if result.Decision.Decision != db.RiskDecisionAllow {
+		return nil, transferBlockedError(result.Decision.ID)
+	}
+
+	res := &pb.PayPaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(&result.Request, server.currencies),
+		Transfer:       convertTransfer(&result.Transfer.Transfer, result.Request.Currency, server.currencies),
+	}
+	return res, nil
+}
+
+func validateCreatePaymentRequestRequest(req *pb.CreatePaymentRequestRequest, requester string, currencies *currency.Registry) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if err := val.ValidateUsername(req.GetPayer()); err != nil {
+		violations = append(violations, fieldViolation("payer", err))
+	} else if req.GetPayer() == requester {
+		violations = append(violations, fieldViolation("payer", errors.New("cannot request money from yourself")))
+	}
+
+	if req.GetToAccountId() <= 0 {
+		violations = append(violations, fieldViolation("to_account_id", errors.New("must be a positive integer")))
+	}
+
+	if req.GetAmount() <= 0 {
+		violations = append(violations, fieldViolation("amount", errors.New("must be greater than zero")))
+	}
+
+	if !currencies.IsSupported(req.GetCurrency()) {
+		violations = append(violations, fieldViolation("currency", fmt.Errorf("unsupported currency %q", req.GetCurrency())))
+	}
+
+	if err := val.ValidateString(req.GetMemo(), 0, maxPaymentRequestMemoLength); err != nil {
+		violations = append(violations, fieldViolation("memo", err))
+	}
+
+	if req.GetExpiresInDays() < 0 || req.GetExpiresInDays() > maxPaymentRequestExpiryDays {
+		violations = append(violations, fieldViolation("expires_in_days", fmt.Errorf("must be between 0 and %d (0 means %d)", maxPaymentRequestExpiryDays, defaultPaymentRequestExpiryDays)))
+	}
+
+	return violations
+}
+
+func validateListPaymentRequestsRequest(req *pb.ListPaymentRequestsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	switch req.GetDirection() {
+	case "", paymentRequestsIncoming, paymentRequestsOutgoing:
+	default:
+		violations = append(violations, fieldViolation("direction", errors.New("must be incoming or outgoing")))
+	}
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", errors.New("must not be negative")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > 100 {
+		violations = append(violations, fieldViolation("page_size", errors.New("must be between 1 and 100")))
+	}
+
+	return violations
+}
+
+func validatePayPaymentRequestRequest(req *pb.PayPaymentRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetId() <= 0 {
+		violations = append(violations, fieldViolation("id", errors.New("must be a positive integer")))
+	}
+
+	if req.GetFromAccountId() <= 0 {
+		violations = append(violations, fieldViolation("from_account_id", errors.New("must be a positive integer")))
+	}
+
+	return violations
+}
@@ -0,0 +1,351 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestServer_CreatePaymentRequest(t *testing.T) {
+
+	requester, _ := createRandomUser(t)
+	payer, _ := createRandomUser(t)
+
+	account := db.Account{ID: 1, Owner: requester.Username, Balance: 1000, Currency: util.USD}
+
+	testCases := []struct {
+		name       string
+		req        *pb.CreatePaymentRequestRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.CreatePaymentRequestResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.CreatePaymentRequestRequest{Payer: payer.Username, ToAccountId: account.ID, Amount: 2500, Currency: util.USD, Memo: "dinner"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					CreatePaymentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+						require.Equal(t, requester.Username, arg.Requester)
+						require.Equal(t, payer.Username, arg.Payer)
+						require.Equal(t, account.ID, arg.ToAccountID)
+						require.Equal(t, "dinner", arg.Memo)
+						require.WithinDuration(t, time.Now().AddDate(0, 0, defaultPaymentRequestExpiryDays), arg.ExpiresAt, time.Minute)
+
+						return db.PaymentRequest{
+							ID:          4,
+							Requester:   arg.Requester,
+							Payer:       arg.Payer,
+							ToAccountID: arg.ToAccountID,
+							Amount:      arg.Amount,
+							Currency:    arg.Currency,
+							Memo:        arg.Memo,
+							Status:      db.PaymentRequestStatusPending,
+							ExpiresAt:   arg.ExpiresAt,
+							CreatedAt:   time.Now(),
+						}, nil
+					})
+			},
+			check: func(t *testing.T, res *pb.CreatePaymentRequestResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(4), res.GetPaymentRequest().GetId())
+				require.Equal(t, "$25.00", res.GetPaymentRequest().GetFormattedAmount())
+				require.Equal(t, db.PaymentRequestStatusPending, res.GetPaymentRequest().GetStatus())
+				require.Nil(t, res.GetPaymentRequest().GetSettledAt())
+			},
+		},
+		{
+			name: "PayerNotFound",
+			req:  &pb.CreatePaymentRequestRequest{Payer: "nobody", ToAccountId: account.ID, Amount: 2500, Currency: util.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentRequest{}, &pq.Error{Code: "23503"})
+			},
+			check: func(t *testing.T, res *pb.CreatePaymentRequestResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req:  &pb.CreatePaymentRequestRequest{Payer: payer.Username, ToAccountId: account.ID, Amount: 2500, Currency: util.EUR},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreatePaymentRequestResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "FromYourself",
+			req:  &pb.CreatePaymentRequestRequest{Payer: requester.Username, ToAccountId: account.ID, Amount: 2500, Currency: util.USD, ExpiresInDays: 31},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreatePaymentRequestResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "NotAccountMember",
+			req:  &pb.CreatePaymentRequestRequest{Payer: payer.Username, ToAccountId: 2, Amount: 2500, Currency: util.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(2))).Times(1).Return(db.Account{ID: 2, Owner: payer.Username, Currency: util.USD}, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.CreatePaymentRequestResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, requester.Username, time.Minute)
+			res, err := server.CreatePaymentRequest(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_PayPaymentRequest(t *testing.T) {
+
+	payer, _ := createRandomUser(t)
+	account := db.Account{ID: 2, Owner: payer.Username, Balance: 5000, Currency: util.USD}
+
+	pending := db.PaymentRequest{
+		ID:          4,
+		Requester:   "requester",
+		Payer:       payer.Username,
+		ToAccountID: 1,
+		Amount:      2500,
+		Currency:    util.USD,
+		Status:      db.PaymentRequestStatusPending,
+	}
+	paid := pending
+	paid.Status = db.PaymentRequestStatusPaid
+	paid.TransferID = sql.NullInt64{Int64: 9, Valid: true}
+	paid.SettledAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name       string
+		req        *pb.PayPaymentRequestRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.PayPaymentRequestResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.PayPaymentRequestRequest{Id: paid.ID, FromAccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.GetTransferRiskSignalsRow{PayeeTransfers: 1}, nil)
+				store.EXPECT().
+					PayPaymentRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
+						require.Equal(t, pending.ID, arg.ID)
+						require.Equal(t, payer.Username, arg.Payer)
+						require.Equal(t, account.ID, arg.FromAccountID)
+						require.Equal(t, db.RiskDecisionAllow, arg.Screening.Decision)
+
+						return db.PayPaymentRequestTxResult{
+							Request:  paid,
+							Decision: db.RiskDecision{ID: 3, Decision: db.RiskDecisionAllow},
+							Transfer: db.TransferTxResult{Transfer: db.Transfer{ID: 9, FromAccountID: account.ID, ToAccountID: 1, Amount: 2500}},
+						}, nil
+					})
+			},
+			check: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.PaymentRequestStatusPaid, res.GetPaymentRequest().GetStatus())
+				require.Equal(t, int64(9), res.GetPaymentRequest().GetTransferId())
+				require.Equal(t, int64(9), res.GetTransfer().GetId())
+				require.NotNil(t, res.GetPaymentRequest().GetSettledAt())
+			},
+		},
+		{
+			name: "HeldByScreening",
+			req:  &pb.PayPaymentRequestRequest{Id: pending.ID, FromAccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.GetTransferRiskSignalsRow{PayeeTransfers: 1, PreviousSessions: 2}, nil)
+				store.EXPECT().
+					PayPaymentRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
+						require.Equal(t, db.RiskDecisionReview, arg.Screening.Decision)
+						return db.PayPaymentRequestTxResult{
+							Request:  pending,
+							Decision: db.RiskDecision{ID: 5, Decision: arg.Screening.Decision},
+						}, nil
+					})
+			},
+			check: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "NeedsApproval",
+			req:  &pb.PayPaymentRequestRequest{Id: pending.ID, FromAccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PayPaymentRequestTxResult{}, db.ErrPaymentNeedsApproval)
+			},
+			check: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "Expired",
+			req:  &pb.PayPaymentRequestRequest{Id: pending.ID, FromAccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PayPaymentRequestTxResult{}, db.ErrPaymentRequestExpired)
+			},
+			check: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "WrongCurrency",
+			req:  &pb.PayPaymentRequestRequest{Id: pending.ID, FromAccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PayPaymentRequestTxResult{}, db.ErrPaymentAccount)
+			},
+			check: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "SentToSomeoneElse",
+			req:  &pb.PayPaymentRequestRequest{Id: pending.ID, FromAccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				other := pending
+				other.Payer = "someone_else"
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(other, nil)
+				store.EXPECT().GetTransferRiskSignals(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req:  &pb.PayPaymentRequestRequest{Id: pending.ID, FromAccountId: 99},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(99))).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, payer.Username, time.Minute)
+			res, err := server.PayPaymentRequest(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_DeclinePaymentRequest(t *testing.T) {
+
+	payer, _ := createRandomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		DeclinePaymentRequestTx(gomock.Any(), gomock.Eq(db.DeclinePaymentRequestTxParams{ID: 4, Payer: payer.Username})).
+		Times(1).
+		Return(db.PaymentRequest{ID: 4, Payer: payer.Username, Currency: util.USD, Status: db.PaymentRequestStatusDeclined}, nil)
+	store.EXPECT().
+		DeclinePaymentRequestTx(gomock.Any(), gomock.Eq(db.DeclinePaymentRequestTxParams{ID: 5, Payer: payer.Username})).
+		Times(1).
+		Return(db.PaymentRequest{}, db.ErrPaymentRequestNotPending)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, payer.Username, time.Minute)
+
+	res, err := server.DeclinePaymentRequest(ctx, &pb.DeclinePaymentRequestRequest{Id: 4})
+	require.NoError(t, err)
+	require.Equal(t, db.PaymentRequestStatusDeclined, res.GetPaymentRequest().GetStatus())
+
+	_, err = server.DeclinePaymentRequest(ctx, &pb.DeclinePaymentRequestRequest{Id: 5})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestServer_ListPaymentRequests(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListOutgoingPaymentRequests(gomock.Any(), gomock.Eq(db.ListOutgoingPaymentRequestsParams{Requester: user.Username, Limit: 10, Offset: 10})).
+		Times(1).
+		Return([]db.PaymentRequest{{ID: 1, Requester: user.Username, Currency: util.USD}}, nil)
+	store.EXPECT().ListIncomingPaymentRequests(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+
+	res, err := server.ListPaymentRequests(ctx, &pb.ListPaymentRequestsRequest{Direction: paymentRequestsOutgoing, PageId: 2, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, res.GetPaymentRequests(), 1)
+
+	_, err = server.ListPaymentRequests(ctx, &pb.ListPaymentRequestsRequest{Direction: "sideways"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
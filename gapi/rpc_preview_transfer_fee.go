@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/joefazee/simplebank/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) PreviewTransferFee(ctx context.Context, req *pb.PreviewTransferFeeRequest) (*pb.PreviewTransferFeeResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validatePreviewTransferFeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetFromAccountId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", req.GetFromAccountId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if _, err = server.memberRole(ctx, account, authPayload.Username); err != nil {
+		return nil, err
+	}
+
+	fee, err := server.store.PreviewTransferFee(ctx, account.ID, req.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to compute transfer fee: %s", err)
+	}
+
+	res := &pb.PreviewTransferFeeResponse{
+		Fee:        convertTransferFee(&fee),
+		TotalDebit: req.GetAmount() + fee.Total,
+	}
+	return res, nil
+}
+
+func validatePreviewTransferFeeRequest(req *pb.PreviewTransferFeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetFromAccountId() <= 0 {
+		violations = append(violations, fieldViolation("from_account_id", errors.New("must be a positive integer")))
+	}
+
+	if req.GetAmount() <= 0 {
+		violations = append(violations, fieldViolation("amount", errors.New("must be greater than zero")))
+	}
+
+	return violations
+}
@@ -0,0 +1,116 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestServer_PreviewTransferFee(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+	account := db.Account{ID: 4, Owner: user.Username, Balance: 500000, Currency: util.USD, Product: "savings"}
+	fee := db.Fee{ScheduleID: 2, PercentBps: 50, PercentAmount: 1000, Total: 1000}
+
+	testCases := []struct {
+		name       string
+		req        *pb.PreviewTransferFeeRequest
+		username   string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.PreviewTransferFeeResponse, err error)
+	}{
+		{
+			name:     "OK",
+			req:      &pb.PreviewTransferFeeRequest{FromAccountId: account.ID, Amount: 200000},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					PreviewTransferFee(gomock.Any(), gomock.Eq(account.ID), gomock.Eq(int64(200000))).
+					Times(1).
+					Return(fee, nil)
+			},
+			check: func(t *testing.T, res *pb.PreviewTransferFeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int32(50), res.GetFee().GetPercentBps())
+				require.Equal(t, fee.Total, res.GetFee().GetTotal())
+				require.Equal(t, int64(201000), res.GetTotalDebit())
+			},
+		},
+		{
+			name:     "PermissionDenied",
+			req:      &pb.PreviewTransferFeeRequest{FromAccountId: account.ID, Amount: 200000},
+			username: "unauthorized_user",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().PreviewTransferFee(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.PreviewTransferFeeResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "NotFound",
+			req:      &pb.PreviewTransferFeeRequest{FromAccountId: account.ID, Amount: 200000},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, res *pb.PreviewTransferFeeResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InvalidAmount",
+			req:      &pb.PreviewTransferFeeRequest{FromAccountId: account.ID},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.PreviewTransferFeeResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "Unauthenticated",
+			req:  &pb.PreviewTransferFeeRequest{FromAccountId: account.ID, Amount: 200000},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.PreviewTransferFeeResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := context.Background()
+			if tc.username != "" {
+				ctx = newContextWithBearerToken(t, server.tokenMaker, tc.username, time.Minute)
+			}
+
+			res, err := server.PreviewTransferFee(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxReviewNoteLength = 500
+
+// ListTransferReviews lists the transfers held by risk screening, oldest first
+func (server *Server) ListTransferReviews(ctx context.Context, req *pb.ListTransferReviewsRequest) (*pb.ListTransferReviewsResponse, error) {
+
+	if _, err := server.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	violations := validateListTransferReviewsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageID, pageSize := req.GetPageId(), req.GetPageSize()
+	if pageID == 0 {
+		pageID = 1
+	}
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	decisions, err := server.store.ListPendingRiskDecisions(ctx, db.ListPendingRiskDecisionsParams{
+		Limit:  pageSize,
+		Offset: (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfer reviews: %s", err)
+	}
+
+	res := &pb.ListTransferReviewsResponse{
+		Reviews: make([]*pb.TransferReview, 0, len(decisions)),
+	}
+	for i := range decisions {
+		res.Reviews = append(res.Reviews, convertTransferReview(&decisions[i]))
+	}
+	return res, nil
+}
+
+// ReviewTransfer approves or rejects a transfer held by risk screening
+func (server *Server) ReviewTransfer(ctx context.Context, req *pb.ReviewTransferRequest) (*pb.ReviewTransferResponse, error) {
+
+	authPayload, err := server.authorizeAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validateReviewTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ReviewTransferTx(ctx, db.ReviewTransferTxParams{
+		ID:       req.GetId(),
+		Reviewer: authPayload.Username,
+		Approve:  req.GetApprove(),
+		Note:     req.GetNote(),
+	})
+	if err != nil {
+		var limitErr *db.LimitExceededError
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Error(codes.NotFound, "transfer review not found")
+		case errors.Is(err, db.ErrReviewNotPending):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.As(err, &limitErr):
+			return nil, limitExceededError(result.Decision.Username, limitErr)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to review transfer: %s", err)
+	}
+
+	res := &pb.ReviewTransferResponse{
+		Review: convertTransferReview(&result.Decision),
+	}
+	if result.Decision.TransferID.Valid {
+		res.Transfer = convertTransfer(&result.Transfer.Transfer, result.Decision.Currency, server.currencies)
+	}
+	res.ApprovalId = result.Approval.ID
+	return res, nil
+}
+
+func validateListTransferReviewsRequest(req *pb.ListTransferReviewsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", errors.New("must not be negative")))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > 100 {
+		violations = append(violations, fieldViolation("page_size", errors.New("must be between 1 and 100")))
+	}
+
+	return violations
+}
+
+func validateReviewTransferRequest(req *pb.ReviewTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetId() <= 0 {
+		violations = append(violations, fieldViolation("id", errors.New("must be a positive integer")))
+	}
+
+	if err := val.ValidateString(req.GetNote(), 0, maxReviewNoteLength); err != nil {
+		violations = append(violations, fieldViolation("note", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,179 @@
+package gapi
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/risk"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func randomPendingReview(t *testing.T, username string) db.RiskDecision {
+
+	hits, err := json.Marshal([]risk.Hit{{Rule: "new_device", Decision: risk.Review, Reason: "user agent not seen in earlier sessions"}})
+	require.NoError(t, err)
+
+	return db.RiskDecision{
+		ID:            util.RandomInt(1, 1000),
+		Username:      username,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        util.RandomMoney(),
+		Currency:      util.USD,
+		Decision:      db.RiskDecisionReview,
+		Hits:          hits,
+		ReviewStatus:  sql.NullString{String: db.ReviewStatusPending, Valid: true},
+		CreatedAt:     time.Now().Truncate(time.Second),
+	}
+}
+
+func TestServer_ReviewTransfer(t *testing.T) {
+
+	admin, _ := createRandomUser(t)
+	admin.Role = util.AdminRole
+	depositor, _ := createRandomUser(t)
+	depositor.Role = util.DepositorRole
+
+	review := randomPendingReview(t, depositor.Username)
+
+	approved := review
+	approved.ReviewStatus.String = db.ReviewStatusApproved
+	approved.ReviewedBy = sql.NullString{String: admin.Username, Valid: true}
+	approved.TransferID = sql.NullInt64{Int64: 11, Valid: true}
+
+	testCases := []struct {
+		name       string
+		req        *pb.ReviewTransferRequest
+		user       db.User
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.ReviewTransferResponse, err error)
+	}{
+		{
+			name: "Approve",
+			req:  &pb.ReviewTransferRequest{Id: review.ID, Approve: true, Note: "called the customer"},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewTransferTx(gomock.Any(), gomock.Eq(db.ReviewTransferTxParams{
+						ID:       review.ID,
+						Reviewer: admin.Username,
+						Approve:  true,
+						Note:     "called the customer",
+					})).
+					Times(1).
+					Return(db.ReviewTransferTxResult{
+						Decision: approved,
+						Transfer: db.TransferTxResult{Transfer: db.Transfer{ID: 11, Amount: review.Amount}},
+					}, nil)
+			},
+			check: func(t *testing.T, res *pb.ReviewTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.ReviewStatusApproved, res.GetReview().GetReviewStatus())
+				require.Equal(t, admin.Username, res.GetReview().GetReviewedBy())
+				require.Equal(t, int64(11), res.GetTransfer().GetId())
+				require.Len(t, res.GetReview().GetHits(), 1)
+				require.Equal(t, "new_device", res.GetReview().GetHits()[0].GetRule())
+			},
+		},
+		{
+			name: "NotAdmin",
+			req:  &pb.ReviewTransferRequest{Id: review.ID, Approve: true},
+			user: depositor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.ReviewTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "AlreadyReviewed",
+			req:  &pb.ReviewTransferRequest{Id: review.ID},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ReviewTransferTxResult{}, db.ErrReviewNotPending)
+			},
+			check: func(t *testing.T, res *pb.ReviewTransferResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "NotFound",
+			req:  &pb.ReviewTransferRequest{Id: review.ID},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ReviewTransferTxResult{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, res *pb.ReviewTransferResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidID",
+			req:  &pb.ReviewTransferRequest{Id: 0},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.ReviewTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(tc.user.Username)).Times(1).Return(tc.user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.user.Username, time.Minute)
+			res, err := server.ReviewTransfer(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_ListTransferReviews(t *testing.T) {
+
+	admin, _ := createRandomUser(t)
+	admin.Role = util.AdminRole
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	reviews := []db.RiskDecision{randomPendingReview(t, "alice"), randomPendingReview(t, "bob")}
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+	store.EXPECT().
+		ListPendingRiskDecisions(gomock.Any(), gomock.Eq(db.ListPendingRiskDecisionsParams{Limit: 20, Offset: 0})).
+		Times(1).
+		Return(reviews, nil)
+
+	server := newTestServer(t, store)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, admin.Username, time.Minute)
+	res, err := server.ListTransferReviews(ctx, &pb.ListTransferReviewsRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.GetReviews(), 2)
+	require.Equal(t, "alice", res.GetReviews()[0].GetUsername())
+	require.Equal(t, db.ReviewStatusPending, res.GetReviews()[1].GetReviewStatus())
+}
@@ -0,0 +1,120 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxTransferSearchQueryLength = 100
+
+// SearchTransfers searches the transfers of the accounts the authenticated
+// user is a member of. Transfers held back on the way out are included.
+func (server *Server) SearchTransfers(ctx context.Context, req *pb.SearchTransfersRequest) (*pb.SearchTransfersResponse, error) {
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSearchTransfersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	pageSize := req.GetPageSize()
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	arg := db.SearchTransfersParams{
+		Username:              authPayload.Username,
+		AccountID:             req.GetAccountId(),
+		MinAmount:             req.GetMinAmount(),
+		MaxAmount:             req.GetMaxAmount(),
+		CounterpartyAccountID: req.GetCounterpartyAccountId(),
+		Direction:             req.GetDirection(),
+		Status:                req.GetStatus(),
+		Reference:             req.GetReference(),
+		Query:                 req.GetQuery(),
+		Sort:                  req.GetSort(),
+		Cursor:                req.GetPageToken(),
+		Limit:                 pageSize,
+	}
+	if req.FromTime != nil {
+		arg.FromTime = req.GetFromTime().AsTime()
+	}
+	if req.ToTime != nil {
+		arg.ToTime = req.GetToTime().AsTime()
+	}
+
+	result, err := db.SearchTransfers(ctx, server.store, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidTransferCursor) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("page_token", err)})
+		}
+		return nil, status.Errorf(codes.Internal, "failed to search transfers: %s", err)
+	}
+
+	res := &pb.SearchTransfersResponse{
+		Transfers:     make([]*pb.TransferActivity, 0, len(result.Transfers)),
+		NextPageToken: result.NextCursor,
+	}
+	for i := range result.Transfers {
+		res.Transfers = append(res.Transfers, convertTransferActivity(&result.Transfers[i], server.currencies))
+	}
+	return res, nil
+}
+
+func validateSearchTransfersRequest(req *pb.SearchTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetAccountId() < 0 {
+		violations = append(violations, fieldViolation("account_id", errors.New("must not be negative")))
+	}
+
+	if req.FromTime != nil && req.ToTime != nil && !req.GetFromTime().AsTime().Before(req.GetToTime().AsTime()) {
+		violations = append(violations, fieldViolation("from_time", errors.New("must be before to_time")))
+	}
+
+	if req.GetMinAmount() < 0 {
+		violations = append(violations, fieldViolation("min_amount", errors.New("must not be negative")))
+	}
+
+	if req.GetMaxAmount() < 0 {
+		violations = append(violations, fieldViolation("max_amount", errors.New("must not be negative")))
+	} else if req.GetMaxAmount() > 0 && req.GetMaxAmount() < req.GetMinAmount() {
+		violations = append(violations, fieldViolation("max_amount", errors.New("must not be less than min_amount")))
+	}
+
+	if req.GetCounterpartyAccountId() < 0 {
+		violations = append(violations, fieldViolation("counterparty_account_id", errors.New("must not be negative")))
+	}
+
+	if req.GetDirection() != "" && !db.IsTransferDirection(req.GetDirection()) {
+		violations = append(violations, fieldViolation("direction", fmt.Errorf("unknown direction %q", req.GetDirection())))
+	}
+
+	if req.GetStatus() != "" && !db.IsTransferStatus(req.GetStatus()) {
+		violations = append(violations, fieldViolation("status", fmt.Errorf("unknown status %q", req.GetStatus())))
+	}
+
+	if err := val.ValidateString(req.GetQuery(), 0, maxTransferSearchQueryLength); err != nil {
+		violations = append(violations, fieldViolation("query", err))
+	}
+
+	if req.GetSort() != "" && !db.IsTransferSort(req.GetSort()) {
+		violations = append(violations, fieldViolation("sort", fmt.Errorf("unknown sort %q", req.GetSort())))
+	}
+
+	if req.GetPageSize() < 0 || req.GetPageSize() > 100 {
+		violations = append(violations, fieldViolation("page_size", errors.New("must be between 1 and 100")))
+	}
+
+	return violations
+}
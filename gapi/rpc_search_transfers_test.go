@@ -0,0 +1,183 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func randomTransferActivity(id int64, kind string, status string) db.SearchTransferActivityRow {
+	return db.SearchTransferActivityRow{
+		Kind:          kind,
+		ID:            id,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        util.RandomMoney() + 1,
+		Currency:      util.USD,
+		Memo:          "coffee beans",
+		Metadata:      json.RawMessage(`{}`),
+		Status:        status,
+		Direction:     db.TransferDirectionOutgoing,
+		CreatedAt:     time.Now().Add(-time.Duration(id) * time.Minute).Truncate(time.Second),
+	}
+}
+
+func TestServer_SearchTransfers(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+
+	fromTime := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	completed := randomTransferActivity(7, db.TransferActivityTransfer, db.TransferStatusCompleted)
+	held := randomTransferActivity(3, db.TransferActivityDecision, db.TransferStatusPendingReview)
+
+	testCases := []struct {
+		name       string
+		req        *pb.SearchTransfersRequest
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.SearchTransfersResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.SearchTransfersRequest{
+				AccountId:             1,
+				FromTime:              timestamppb.New(fromTime),
+				MinAmount:             5,
+				MaxAmount:             500,
+				CounterpartyAccountId: 2,
+				Direction:             db.TransferDirectionOutgoing,
+				Query:                 "coffee",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchTransferActivity(gomock.Any(), gomock.Eq(db.SearchTransferActivityParams{
+						Username:              user.Username,
+						AccountID:             sql.NullInt64{Int64: 1, Valid: true},
+						FromTime:              sql.NullTime{Time: fromTime.UTC(), Valid: true},
+						MinAmount:             sql.NullInt64{Int64: 5, Valid: true},
+						MaxAmount:             sql.NullInt64{Int64: 500, Valid: true},
+						CounterpartyAccountID: sql.NullInt64{Int64: 2, Valid: true},
+						Direction:             sql.NullString{String: db.TransferDirectionOutgoing, Valid: true},
+						Query:                 sql.NullString{String: "coffee", Valid: true},
+						Sort:                  db.TransferSortNewest,
+						PageLimit:             21,
+					})).
+					Times(1).
+					Return([]db.SearchTransferActivityRow{completed, held}, nil)
+			},
+			check: func(t *testing.T, res *pb.SearchTransfersResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetNextPageToken())
+				require.Len(t, res.GetTransfers(), 2)
+
+				require.Equal(t, completed.ID, res.GetTransfers()[0].GetTransferId())
+				require.Zero(t, res.GetTransfers()[0].GetRiskDecisionId())
+				require.Equal(t, db.TransferStatusCompleted, res.GetTransfers()[0].GetStatus())
+				require.Equal(t, "coffee beans", res.GetTransfers()[0].GetMemo())
+
+				require.Zero(t, res.GetTransfers()[1].GetTransferId())
+				require.Equal(t, held.ID, res.GetTransfers()[1].GetRiskDecisionId())
+				require.Equal(t, db.TransferStatusPendingReview, res.GetTransfers()[1].GetStatus())
+			},
+		},
+		{
+			name: "InvalidPageToken",
+			req:  &pb.SearchTransfersRequest{PageToken: "not-a-cursor"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransferActivity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.SearchTransfersResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidArguments",
+			req:  &pb.SearchTransfersRequest{MinAmount: 10, MaxAmount: 5, Direction: "sideways", Status: "lost", Sort: "name"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransferActivity(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.SearchTransfersResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+			res, err := server.SearchTransfers(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
+
+func TestServer_SearchTransfersPages(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	user, _ := createRandomUser(t)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, time.Minute)
+
+	rows := []db.SearchTransferActivityRow{
+		randomTransferActivity(9, db.TransferActivityTransfer, db.TransferStatusCompleted),
+		randomTransferActivity(4, db.TransferActivityDecision, db.TransferStatusBlocked),
+		randomTransferActivity(8, db.TransferActivityTransfer, db.TransferStatusCompleted),
+	}
+
+	gomock.InOrder(
+		store.EXPECT().
+			SearchTransferActivity(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.SearchTransferActivityParams) ([]db.SearchTransferActivityRow, error) {
+				require.False(t, arg.CursorID.Valid)
+				require.Equal(t, int32(3), arg.PageLimit)
+				return rows, nil
+			}),
+		store.EXPECT().
+			SearchTransferActivity(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.SearchTransferActivityParams) ([]db.SearchTransferActivityRow, error) {
+				// the second page starts after the last row of the first one
+				require.Equal(t, sql.NullInt64{Int64: rows[1].ID, Valid: true}, arg.CursorID)
+				require.Equal(t, sql.NullString{String: db.TransferActivityDecision, Valid: true}, arg.CursorKind)
+				require.Equal(t, rows[1].Amount, arg.CursorAmount.Int64)
+				return rows[2:], nil
+			}),
+	)
+
+	res, err := server.SearchTransfers(ctx, &pb.SearchTransfersRequest{Sort: db.TransferSortLargest, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, res.GetTransfers(), 2)
+	require.NotEmpty(t, res.GetNextPageToken())
+
+	// a token only continues a search with the same sort order
+	_, err = server.SearchTransfers(ctx, &pb.SearchTransfersRequest{Sort: db.TransferSortNewest, PageSize: 2, PageToken: res.GetNextPageToken()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, err = server.SearchTransfers(ctx, &pb.SearchTransfersRequest{Sort: db.TransferSortLargest, PageSize: 2, PageToken: res.GetNextPageToken()})
+	require.NoError(t, err)
+	require.Len(t, res.GetTransfers(), 1)
+	require.Empty(t, res.GetNextPageToken())
+}
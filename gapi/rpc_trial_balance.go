@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const trialBalanceDateLayout = "2006-01-02"
+
+// GetTrialBalance lists the balances of the chart of accounts in a currency
+// at the end of a day. Admins only
+func (server *Server) GetTrialBalance(ctx context.Context, req *pb.GetTrialBalanceRequest) (*pb.GetTrialBalanceResponse, error) {
+
+	if _, err := server.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	violations := server.validateGetTrialBalanceRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.GetDate() != "" {
+		day, _ = time.Parse(trialBalanceDateLayout, req.GetDate())
+	}
+	asOf := day.AddDate(0, 0, 1)
+
+	tb, err := db.GetTrialBalance(ctx, server.store, req.GetCurrency(), asOf)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get trial balance: %s", err)
+	}
+
+	res := &pb.GetTrialBalanceResponse{
+		Currency:    tb.Currency,
+		Date:        day.Format(trialBalanceDateLayout),
+		AsOf:        timestamppb.New(asOf),
+		Lines:       make([]*pb.TrialBalanceLine, 0, len(tb.Lines)),
+		TotalDebit:  tb.TotalDebit,
+		TotalCredit: tb.TotalCredit,
+		Balanced:    tb.Balanced(),
+	}
+	for i := range tb.Lines {
+		res.Lines = append(res.Lines, convertTrialBalanceLine(&tb.Lines[i]))
+	}
+	return res, nil
+}
+
+func (server *Server) validateGetTrialBalanceRequest(req *pb.GetTrialBalanceRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	// disabled currencies keep their ledger, so they can be reported on
+	if _, ok := server.currencies.Get(req.GetCurrency()); !ok {
+		violations = append(violations, fieldViolation("currency", fmt.Errorf("unknown currency %q", req.GetCurrency())))
+	}
+
+	if req.GetDate() != "" {
+		if _, err := time.Parse(trialBalanceDateLayout, req.GetDate()); err != nil {
+			violations = append(violations, fieldViolation("date", errors.New("must be a date like 2006-01-02")))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,123 @@
+package gapi
+
+import (
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestServer_GetTrialBalance(t *testing.T) {
+
+	admin, _ := createRandomUser(t)
+	admin.Role = util.AdminRole
+	depositor, _ := createRandomUser(t)
+	depositor.Role = util.DepositorRole
+
+	rows := []db.GetTrialBalanceRow{
+		{Code: db.CashAccount, Number: "1000", Name: "Cash and settlement", Category: db.GLCategoryAsset, Balance: -1500},
+		{Code: db.CustomerDepositsAccount, Number: "2000", Name: "Customer deposits", Category: db.GLCategoryLiability, Balance: 1450},
+		{Code: db.FeeIncomeAccount, Number: "4000", Name: "Transfer fee income", Category: db.GLCategoryIncome, Balance: 100},
+		{Code: db.InterestExpenseAccount, Number: "5000", Name: "Interest paid to customers", Category: db.GLCategoryExpense, Balance: -50},
+	}
+
+	testCases := []struct {
+		name       string
+		req        *pb.GetTrialBalanceRequest
+		user       db.User
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res *pb.GetTrialBalanceResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.GetTrialBalanceRequest{Currency: util.USD, Date: "2026-03-31"},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTrialBalance(gomock.Any(), gomock.Eq(db.GetTrialBalanceParams{
+						Currency: util.USD,
+						AsOf:     time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+					})).
+					Times(1).
+					Return(rows, nil)
+			},
+			check: func(t *testing.T, res *pb.GetTrialBalanceResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "2026-03-31", res.GetDate())
+				require.Len(t, res.GetLines(), len(rows))
+
+				require.Equal(t, int64(1500), res.GetLines()[0].GetDebit())
+				require.Zero(t, res.GetLines()[0].GetCredit())
+				require.Equal(t, int64(1450), res.GetLines()[1].GetCredit())
+				require.Equal(t, int64(50), res.GetLines()[3].GetDebit())
+
+				require.Equal(t, int64(1550), res.GetTotalDebit())
+				require.Equal(t, int64(1550), res.GetTotalCredit())
+				require.True(t, res.GetBalanced())
+			},
+		},
+		{
+			name: "Unbalanced",
+			req:  &pb.GetTrialBalanceRequest{Currency: util.EUR},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTrialBalance(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rows[:2], nil)
+			},
+			check: func(t *testing.T, res *pb.GetTrialBalanceResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, time.Now().UTC().Format("2006-01-02"), res.GetDate())
+				require.False(t, res.GetBalanced())
+			},
+		},
+		{
+			name: "InvalidArguments",
+			req:  &pb.GetTrialBalanceRequest{Currency: "XYZ", Date: "31/03/2026"},
+			user: admin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.GetTrialBalanceResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "NotAdmin",
+			req:  &pb.GetTrialBalanceRequest{Currency: util.USD},
+			user: depositor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res *pb.GetTrialBalanceResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(tc.user.Username)).Times(1).Return(tc.user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.user.Username, time.Minute)
+			res, err := server.GetTrialBalance(ctx, tc.req)
+			tc.check(t, res, err)
+		})
+	}
+}
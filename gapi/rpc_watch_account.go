@@ -0,0 +1,112 @@
+package gapi
+
+import (
+	"database/sql"
+	"errors"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const watchAccountBatchSize = 100
+
+// WatchAccount streams the entries of an account as they are committed. The
+// activity hub only wakes the stream up; entries are always read from the
+// database after the last one sent, so nothing is skipped if a notification is lost.
+func (server *Server) WatchAccount(req *pb.WatchAccountRequest, stream pb.SimpleBank_WatchAccountServer) error {
+
+	ctx := stream.Context()
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return unauthenticatedError(err)
+	}
+
+	violations := validateWatchAccountRequest(req)
+	if violations != nil {
+		return invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.NotFound, "account not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+
+	if _, err = server.memberRole(ctx, account, authPayload.Username); err != nil {
+		return err
+	}
+
+	// subscribe before reading so entries committed in between still wake us up
+	sub := server.activity.Subscribe(account.ID)
+	defer sub.Close()
+
+	lastEntryID := req.GetLastEntryId()
+	if lastEntryID == 0 {
+		lastEntryID, err = server.store.GetLastAccountEntryID(ctx, account.ID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get last entry: %s", err)
+		}
+	}
+
+	for {
+		lastEntryID, err = server.sendAccountEntries(stream, account.ID, lastEntryID)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case _, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.Unavailable, "server is shutting down, reconnect with last_entry_id to resume")
+			}
+		}
+	}
+}
+
+// sendAccountEntries sends every entry after afterID and returns the id of the last one sent
+func (server *Server) sendAccountEntries(stream pb.SimpleBank_WatchAccountServer, accountID int64, afterID int64) (int64, error) {
+
+	ctx := stream.Context()
+
+	for {
+		rows, err := server.store.ListAccountActivityAfter(ctx, db.ListAccountActivityAfterParams{
+			AccountID:  accountID,
+			AfterID:    afterID,
+			LimitCount: watchAccountBatchSize,
+		})
+		if err != nil {
+			return afterID, status.Errorf(codes.Internal, "failed to list entries: %s", err)
+		}
+
+		for i := range rows {
+			if err = stream.Send(convertAccountActivity(&rows[i])); err != nil {
+				return afterID, err
+			}
+			afterID = rows[i].ID
+		}
+
+		if len(rows) < watchAccountBatchSize {
+			return afterID, nil
+		}
+	}
+}
+
+func validateWatchAccountRequest(req *pb.WatchAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+
+	if req.GetAccountId() <= 0 {
+		violations = append(violations, fieldViolation("account_id", errors.New("must be a positive integer")))
+	}
+
+	if req.GetLastEntryId() < 0 {
+		violations = append(violations, fieldViolation("last_entry_id", errors.New("must not be negative")))
+	}
+
+	return violations
+}
@@ -0,0 +1,263 @@
+package gapi
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testWatchStream struct {
+	pb.SimpleBank_WatchAccountServer
+	ctx    context.Context
+	sent   []*pb.WatchAccountResponse
+	onSend func(sent int)
+}
+
+func (stream *testWatchStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *testWatchStream) Send(res *pb.WatchAccountResponse) error {
+	stream.sent = append(stream.sent, res)
+	if stream.onSend != nil {
+		stream.onSend(len(stream.sent))
+	}
+	return nil
+}
+
+func randomAccountActivity(accountID int64, id int64, balance int64) db.ListAccountActivityAfterRow {
+	return db.ListAccountActivityAfterRow{
+		ID:        id,
+		AccountID: accountID,
+		Amount:    util.RandomMoney(),
+		EntryType: db.EntryTypeTransfer,
+		CreatedAt: time.Now().Truncate(time.Second),
+		Balance:   balance,
+	}
+}
+
+func TestServer_WatchAccount(t *testing.T) {
+
+	user, _ := createRandomUser(t)
+	account := db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    user.Username,
+		Balance:  util.RandomMoney(),
+		Currency: util.USD,
+	}
+	entry1 := randomAccountActivity(account.ID, 6, 100)
+	entry2 := randomAccountActivity(account.ID, 7, 100+util.RandomMoney())
+
+	testCases := []struct {
+		name       string
+		req        *pb.WatchAccountRequest
+		username   string
+		buildStubs func(store *mockdb.MockStore, server *Server, stream *testWatchStream, cancel context.CancelFunc)
+		check      func(t *testing.T, stream *testWatchStream, err error)
+	}{
+		{
+			name:     "ResumeFromLastEntry",
+			req:      &pb.WatchAccountRequest{AccountId: account.ID, LastEntryId: 5},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore, server *Server, stream *testWatchStream, cancel context.CancelFunc) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetLastAccountEntryID(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					ListAccountActivityAfter(gomock.Any(), gomock.Eq(db.ListAccountActivityAfterParams{
+						AccountID:  account.ID,
+						AfterID:    5,
+						LimitCount: watchAccountBatchSize,
+					})).
+					Times(1).
+					Return([]db.ListAccountActivityAfterRow{entry1, entry2}, nil)
+
+				stream.onSend = func(sent int) {
+					if sent == 2 {
+						server.activity.Stop()
+					}
+				}
+			},
+			check: func(t *testing.T, stream *testWatchStream, err error) {
+				require.Equal(t, codes.Unavailable, status.Code(err))
+				require.Len(t, stream.sent, 2)
+				require.Equal(t, convertAccountActivity(&entry1), stream.sent[0])
+				require.Equal(t, convertAccountActivity(&entry2), stream.sent[1])
+				// every entry carries the balance right after it
+				require.Equal(t, entry1.Balance, stream.sent[0].Balance)
+				require.Equal(t, entry2.Balance, stream.sent[1].Balance)
+			},
+		},
+		{
+			name:     "NewActivityOnly",
+			req:      &pb.WatchAccountRequest{AccountId: account.ID},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore, server *Server, stream *testWatchStream, cancel context.CancelFunc) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetLastAccountEntryID(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(entry1.ID, nil)
+
+				gomock.InOrder(
+					store.EXPECT().
+						ListAccountActivityAfter(gomock.Any(), gomock.Eq(db.ListAccountActivityAfterParams{
+							AccountID:  account.ID,
+							AfterID:    entry1.ID,
+							LimitCount: watchAccountBatchSize,
+						})).
+						DoAndReturn(func(ctx context.Context, arg db.ListAccountActivityAfterParams) ([]db.ListAccountActivityAfterRow, error) {
+							server.activity.Notify(account.ID)
+							return []db.ListAccountActivityAfterRow{}, nil
+						}),
+					store.EXPECT().
+						ListAccountActivityAfter(gomock.Any(), gomock.Any()).
+						Return([]db.ListAccountActivityAfterRow{entry2}, nil),
+				)
+
+				stream.onSend = func(sent int) {
+					cancel()
+				}
+			},
+			check: func(t *testing.T, stream *testWatchStream, err error) {
+				require.Equal(t, codes.Canceled, status.Code(err))
+				require.Len(t, stream.sent, 1)
+				require.Equal(t, convertAccountActivity(&entry2), stream.sent[0])
+			},
+		},
+		{
+			name:     "PermissionDenied",
+			req:      &pb.WatchAccountRequest{AccountId: account.ID},
+			username: "unauthorized_user",
+			buildStubs: func(store *mockdb.MockStore, server *Server, stream *testWatchStream, cancel context.CancelFunc) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountMember(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountMember{}, sql.ErrNoRows)
+				store.EXPECT().ListAccountActivityAfter(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, stream *testWatchStream, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "NotFound",
+			req:      &pb.WatchAccountRequest{AccountId: account.ID},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore, server *Server, stream *testWatchStream, cancel context.CancelFunc) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, stream *testWatchStream, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InvalidAccountID",
+			req:      &pb.WatchAccountRequest{AccountId: 0, LastEntryId: -1},
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore, server *Server, stream *testWatchStream, cancel context.CancelFunc) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, stream *testWatchStream, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "Unauthenticated",
+			req:  &pb.WatchAccountRequest{AccountId: account.ID},
+			buildStubs: func(store *mockdb.MockStore, server *Server, stream *testWatchStream, cancel context.CancelFunc) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, stream *testWatchStream, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			ctx := context.Background()
+			if tc.username != "" {
+				ctx = newContextWithBearerToken(t, server.tokenMaker, tc.username, time.Minute)
+			}
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			stream := &testWatchStream{ctx: ctx}
+			tc.buildStubs(store, server, stream, cancel)
+
+			err := server.WatchAccount(tc.req, stream)
+			tc.check(t, stream, err)
+		})
+	}
+}
+
+func TestHandleWatchAccount(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, _ := createRandomUser(t)
+	account := db.Account{ID: 10, Owner: user.Username, Balance: 500, Currency: util.USD}
+	entry := randomAccountActivity(account.ID, 3, 500)
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().
+		ListAccountActivityAfter(gomock.Any(), gomock.Eq(db.ListAccountActivityAfterParams{
+			AccountID:  account.ID,
+			AfterID:    2,
+			LimitCount: watchAccountBatchSize,
+		})).
+		DoAndReturn(func(ctx context.Context, arg db.ListAccountActivityAfterParams) ([]db.ListAccountActivityAfterRow, error) {
+			server.activity.Stop()
+			return []db.ListAccountActivityAfterRow{entry}, nil
+		})
+
+	mux := runtime.NewServeMux()
+	require.NoError(t, mux.HandlePath(http.MethodGet, WatchAccountPattern, server.HandleWatchAccount(mux)))
+
+	accessToken, _, err := server.tokenMaker.CreateToken(user.Username, time.Minute)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/accounts/%d/watch?last_entry_id=2", account.ID), nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+	var lines []map[string]json.RawMessage
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var line map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "result")
+	require.Contains(t, string(lines[0]["result"]), `"balance":"500"`)
+	require.Contains(t, lines[1], "error")
+}
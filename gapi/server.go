@@ -2,7 +2,11 @@ package gapi
 
 import (
 	"fmt"
+	"github.com/joefazee/simplebank/activity"
+	"github.com/joefazee/simplebank/currency"
+	"github.com/joefazee/simplebank/kyc"
 	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/risk"
 	"github.com/joefazee/simplebank/worker"
 
 	db "github.com/joefazee/simplebank/db/sqlc"
@@ -17,10 +21,14 @@ type Server struct {
 	tokenMaker      token.Maker
 	config          util.Config
 	taskDistributor worker.TaskDistributor
+	activity        *activity.Hub
+	screener        *risk.Screener
+	currencies      *currency.Registry
+	documents       kyc.DocumentStorage
 }
 
 // NewServer creates new gRPC server.
-func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor, activityHub *activity.Hub, currencies *currency.Registry) (*Server, error) {
 
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
@@ -32,6 +40,10 @@ func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDi
 		tokenMaker:      tokenMaker,
 		config:          config,
 		taskDistributor: taskDistributor,
+		activity:        activityHub,
+		screener:        risk.NewScreener(config, store),
+		currencies:      currencies,
+		documents:       kyc.NewLocalStorage(config.KYCStorageDir),
 	}
 
 	return server, nil
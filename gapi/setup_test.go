@@ -1,8 +1,14 @@
 package gapi
 
 import (
+	"context"
+	"fmt"
 	"github.com/hibiken/asynq"
+	"github.com/joefazee/simplebank/activity"
+	"github.com/joefazee/simplebank/currency"
+	"github.com/joefazee/simplebank/token"
 	"github.com/joefazee/simplebank/worker"
+	"google.golang.org/grpc/metadata"
 	"os"
 	"testing"
 	"time"
@@ -17,17 +23,26 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
+		KYCStorageDir:       t.TempDir(),
 	}
 
 	redisOpt := asynq.RedisClientOpt{Addr: config.RedisAddress}
 	taskDistributor := worker.NewRedisTaskTaskDistributor(redisOpt)
 
-	server, err := NewServer(config, store, taskDistributor)
+	server, err := NewServer(config, store, taskDistributor, activity.NewHub(), testCurrencies)
 	require.NoError(t, err)
 
 	return server
 }
 
+// testCurrencies are the currencies util.RandomCurrency picks from
+var testCurrencies = currency.NewRegistry(
+	currency.Currency{Code: util.USD, Name: "US Dollar", MinorUnits: 2, Symbol: "$", Enabled: true},
+	currency.Currency{Code: util.EUR, Name: "Euro", MinorUnits: 2, Symbol: "€", Enabled: true},
+	currency.Currency{Code: util.NGN, Name: "Naira", MinorUnits: 2, Symbol: "₦", Enabled: true},
+	currency.Currency{Code: util.CAD, Name: "Canadian Dollar", MinorUnits: 2, Symbol: "CA$", Enabled: true},
+)
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
@@ -49,3 +64,13 @@ func createRandomUser(t *testing.T) (user db.User, password string) {
 	return
 
 }
+
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, duration time.Duration) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, duration)
+	require.NoError(t, err)
+
+	md := metadata.MD{
+		authorization: []string{fmt.Sprintf("%s %s", authAuthorizationTypeBearer, accessToken)},
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
+	"net/http"
+)
+
+// GrpcTracing starts a server span for every gRPC call, continuing the
+// caller's trace when the request carries one.
+func GrpcTracing() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor()
+}
+
+// GrpcStreamTracing is GrpcTracing for streaming calls
+func GrpcStreamTracing() grpc.StreamServerInterceptor {
+	return otelgrpc.StreamServerInterceptor()
+}
+
+// HttpTracing starts a server span for every gateway request. The gateway
+// calls the gRPC handlers in process, so this is the root span for them.
+func HttpTracing(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "gateway",
+		otelhttp.WithSpanNameFormatter(func(operation string, req *http.Request) string {
+			return req.Method + " " + req.URL.Path
+		}),
+	)
+}
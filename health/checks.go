@@ -0,0 +1,38 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Postgres checks that a connection to the database can be established
+func Postgres(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// Migrations checks that the schema is at expectedVersion and not left dirty
+// by a failed migration.
+func Migrations(db *sql.DB, expectedVersion uint) Check {
+	return func(ctx context.Context) error {
+		var version uint
+		var dirty bool
+
+		err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		if err != nil {
+			return fmt.Errorf("cannot read migration state: %w", err)
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+
+		if version != expectedVersion {
+			return fmt.Errorf("schema version %d, expected %d", version, expectedVersion)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	checkTimeout  = 2 * time.Second
+	checkInterval = 5 * time.Second
+)
+
+// ErrShuttingDown is reported by every check once Shutdown has been called
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Check reports whether a dependency is usable
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs readiness checks and mirrors the result into the gRPC health service
+type Checker struct {
+	mu           sync.RWMutex
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+	grpcServer   *health.Server
+	services     []string
+}
+
+// Report is the body returned by the readiness endpoint
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// NewChecker creates a checker. services are the gRPC service names whose
+// serving status follows readiness, in addition to the overall "" service.
+func NewChecker(services ...string) *Checker {
+	return &Checker{
+		grpcServer: health.NewServer(),
+		services:   append([]string{""}, services...),
+	}
+}
+
+// AddCheck registers a readiness check under name
+func (checker *Checker) AddCheck(name string, check Check) {
+	checker.mu.Lock()
+	defer checker.mu.Unlock()
+	checker.checks = append(checker.checks, namedCheck{name: name, check: check})
+}
+
+// GrpcServer returns the grpc.health.v1 implementation to register on the gRPC server
+func (checker *Checker) GrpcServer() healthpb.HealthServer {
+	return checker.grpcServer
+}
+
+// Shutdown marks the server as not ready. It cannot be undone.
+func (checker *Checker) Shutdown() {
+	checker.shuttingDown.Store(true)
+	checker.grpcServer.Shutdown()
+}
+
+// Ready runs every check and updates the gRPC serving status
+func (checker *Checker) Ready(ctx context.Context) (Report, bool) {
+
+	report := Report{Checks: map[string]string{}}
+	ready := true
+
+	if checker.shuttingDown.Load() {
+		report.Status = "not ready"
+		report.Checks["server"] = ErrShuttingDown.Error()
+		return report, false
+	}
+
+	checker.mu.RLock()
+	checks := checker.checks
+	checker.mu.RUnlock()
+
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := c.check(checkCtx)
+		cancel()
+
+		if err != nil {
+			ready = false
+			report.Checks[c.name] = err.Error()
+			continue
+		}
+		report.Checks[c.name] = "ok"
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	report.Status = "ready"
+	if !ready {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+		report.Status = "not ready"
+	}
+
+	for _, service := range checker.services {
+		checker.grpcServer.SetServingStatus(service, status)
+	}
+
+	return report, ready
+}
+
+// Start re-evaluates readiness periodically so gRPC health watchers see changes.
+// It returns when ctx is cancelled.
+func (checker *Checker) Start(ctx context.Context) {
+
+	checker.Ready(ctx)
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checker.Ready(ctx)
+		}
+	}
+}
+
+// LivenessHandler reports that the process is up and able to serve HTTP
+func (checker *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		writeJSON(res, http.StatusOK, Report{Status: "ok"})
+	})
+}
+
+// ReadinessHandler reports whether every dependency is reachable
+func (checker *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		report, ready := checker.Ready(req.Context())
+
+		statusCode := http.StatusOK
+		if !ready {
+			statusCode = http.StatusServiceUnavailable
+		}
+		writeJSON(res, statusCode, report)
+	})
+}
+
+func writeJSON(res http.ResponseWriter, statusCode int, body interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(statusCode)
+	_ = json.NewEncoder(res).Encode(body)
+}
@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const testService = "pb.SimpleBank"
+
+func requireGrpcStatus(t *testing.T, checker *Checker, expected healthpb.HealthCheckResponse_ServingStatus) {
+	res, err := checker.GrpcServer().Check(context.Background(), &healthpb.HealthCheckRequest{Service: testService})
+	require.NoError(t, err)
+	require.Equal(t, expected, res.Status)
+}
+
+func TestReadinessHandler(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		checks        map[string]Check
+		shutdown      bool
+		checkResponse func(rr *httptest.ResponseRecorder, checker *Checker)
+	}{
+		{
+			name: "Ready",
+			checks: map[string]Check{
+				"postgres": func(ctx context.Context) error { return nil },
+			},
+			checkResponse: func(rr *httptest.ResponseRecorder, checker *Checker) {
+				require.Equal(t, http.StatusOK, rr.Code)
+
+				var report Report
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+				require.Equal(t, "ok", report.Checks["postgres"])
+				requireGrpcStatus(t, checker, healthpb.HealthCheckResponse_SERVING)
+			},
+		},
+		{
+			name: "CheckFailed",
+			checks: map[string]Check{
+				"postgres": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return errors.New("connection refused") },
+			},
+			checkResponse: func(rr *httptest.ResponseRecorder, checker *Checker) {
+				require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+				var report Report
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+				require.Equal(t, "connection refused", report.Checks["redis"])
+				requireGrpcStatus(t, checker, healthpb.HealthCheckResponse_NOT_SERVING)
+			},
+		},
+		{
+			name: "ShuttingDown",
+			checks: map[string]Check{
+				"postgres": func(ctx context.Context) error { return nil },
+			},
+			shutdown: true,
+			checkResponse: func(rr *httptest.ResponseRecorder, checker *Checker) {
+				require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+				requireGrpcStatus(t, checker, healthpb.HealthCheckResponse_NOT_SERVING)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+
+			checker := NewChecker(testService)
+			for name, check := range tc.checks {
+				checker.AddCheck(name, check)
+			}
+			checker.Ready(context.Background())
+
+			if tc.shutdown {
+				checker.Shutdown()
+			}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			checker.ReadinessHandler().ServeHTTP(rr, req)
+			tc.checkResponse(rr, checker)
+		})
+	}
+}
+
+func TestLivenessHandler(t *testing.T) {
+
+	checker := NewChecker()
+	checker.AddCheck("redis", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	rr := httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+}
@@ -0,0 +1,64 @@
+// Package interest accrues interest on interest-bearing accounts every day
+// and posts it once a month. Amounts are integers: daily interest is kept in
+// millionths of the minor unit and only whole minor units are ever posted.
+package interest
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+)
+
+// Day count conventions a product can use
+const (
+	Actual365    = "actual/365"
+	Actual360    = "actual/360"
+	ActualActual = "actual/actual"
+)
+
+// basisPointsPerUnit is the number of basis points in a rate of 100%
+const basisPointsPerUnit = 10_000
+
+// DaysInYear returns the number of days a year has under a day count
+// convention, for the year containing day
+func DaysInYear(convention string, day time.Time) (int64, error) {
+	switch convention {
+	case Actual365:
+		return 365, nil
+	case Actual360:
+		return 360, nil
+	case ActualActual:
+		year := day.Year()
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return int64(start.AddDate(1, 0, 0).Sub(start).Hours() / 24), nil
+	}
+	return 0, fmt.Errorf("unknown day count convention %q", convention)
+}
+
+// DailyMicros returns the interest earned by balance during day, in millionths
+// of the minor unit, rounded down. Balances that are not positive earn nothing.
+func DailyMicros(balance int64, annualRateBps int32, convention string, day time.Time) (int64, error) {
+
+	daysInYear, err := DaysInYear(convention, day)
+	if err != nil {
+		return 0, err
+	}
+
+	if balance <= 0 || annualRateBps <= 0 {
+		return 0, nil
+	}
+
+	// balance * rate / 10000 / daysInYear, scaled to micros; big.Int keeps
+	// large balances from overflowing before the division
+	micros := new(big.Int).SetInt64(balance)
+	micros.Mul(micros, big.NewInt(int64(annualRateBps)))
+	micros.Mul(micros, big.NewInt(db.MicrosPerMinorUnit))
+	micros.Quo(micros, big.NewInt(basisPointsPerUnit*daysInYear))
+
+	if !micros.IsInt64() {
+		return 0, fmt.Errorf("daily interest on %d overflows", balance)
+	}
+	return micros.Int64(), nil
+}
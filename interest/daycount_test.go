@@ -0,0 +1,67 @@
+package interest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaysInYear(t *testing.T) {
+
+	leapDay := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	commonDay := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		convention string
+		day        time.Time
+		want       int64
+	}{
+		{Actual365, leapDay, 365},
+		{Actual360, leapDay, 360},
+		{ActualActual, leapDay, 366},
+		{ActualActual, commonDay, 365},
+	}
+
+	for _, tc := range testCases {
+		got, err := DaysInYear(tc.convention, tc.day)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "%s in %d", tc.convention, tc.day.Year())
+	}
+
+	_, err := DaysInYear("30/360", leapDay)
+	require.Error(t, err)
+}
+
+func TestDailyMicros(t *testing.T) {
+
+	day := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		balance    int64
+		rateBps    int32
+		convention string
+		want       int64
+	}{
+		// 10,000.00 at 2.5% earns 0.684931... a day
+		{"Actual365", 1_000_000, 250, Actual365, 68_493_150},
+		{"Actual360", 1_000_000, 250, Actual360, 69_444_444},
+		{"ActualActual", 1_000_000, 250, ActualActual, 68_306_010},
+		{"SmallBalance", 1, 250, Actual365, 68},
+		{"ZeroRate", 1_000_000, 0, Actual365, 0},
+		{"Overdrawn", -1_000_000, 250, Actual365, 0},
+		{"LargeBalance", 1 << 50, 250, Actual365, 77_116_431_975_522_191},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DailyMicros(tc.balance, tc.rateBps, tc.convention, day)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := DailyMicros(1_000_000, 250, "30/360", day)
+	require.Error(t, err)
+}
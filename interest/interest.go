@@ -0,0 +1,161 @@
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+)
+
+const accrualPageSize = 500
+
+// AccrualResult counts what one run of Accrue did
+type AccrualResult struct {
+	// Accounts is the number of interest-bearing accounts looked at
+	Accounts int
+	// Accrued is the number of accounts that got a new accrual for the day;
+	// accounts accrued by an earlier run are skipped
+	Accrued int
+}
+
+// Day truncates t to the start of its UTC day
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// MonthOf returns the first day of the month containing day
+func MonthOf(day time.Time) time.Time {
+	day = Day(day)
+	return day.AddDate(0, 0, 1-day.Day())
+}
+
+// IsMonthEnd reports whether day is the last day of its month
+func IsMonthEnd(day time.Time) bool {
+	return Day(day).AddDate(0, 0, 1).Day() == 1
+}
+
+// Accrue records the interest every interest-bearing account earned on its
+// end of day balance. Running it again for the same day changes nothing, and
+// days of a month that was already posted are not accrued anymore.
+func Accrue(ctx context.Context, store db.Store, day time.Time) (AccrualResult, error) {
+
+	var result AccrualResult
+	day = Day(day)
+
+	var afterID int64
+	for {
+		balances, err := store.ListInterestBearingBalances(ctx, db.ListInterestBearingBalancesParams{
+			DayEnd:     day.AddDate(0, 0, 1),
+			AfterID:    afterID,
+			LimitCount: accrualPageSize,
+		})
+		if err != nil {
+			return result, fmt.Errorf("cannot list interest-bearing accounts: %w", err)
+		}
+
+		for _, balance := range balances {
+			micros, err := DailyMicros(balance.Balance, balance.AnnualRateBps, balance.DayCount, day)
+			if err != nil {
+				return result, fmt.Errorf("account %d: %w", balance.AccountID, err)
+			}
+
+			rows, err := store.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+				AccountID:     balance.AccountID,
+				Day:           day,
+				Balance:       balance.Balance,
+				AnnualRateBps: balance.AnnualRateBps,
+				DayCount:      balance.DayCount,
+				AmountMicros:  micros,
+			})
+			if err != nil {
+				return result, fmt.Errorf("cannot accrue interest of account %d: %w", balance.AccountID, err)
+			}
+
+			result.Accounts++
+			result.Accrued += int(rows)
+			afterID = balance.AccountID
+		}
+
+		if len(balances) < accrualPageSize {
+			return result, nil
+		}
+	}
+}
+
+// AccrueMonthToDate accrues every day of the month of day up to and including
+// day. Days accrued before are skipped, so a month can be posted right after
+// even when the tasks of its earlier days have not run yet.
+func AccrueMonthToDate(ctx context.Context, store db.Store, day time.Time) (AccrualResult, error) {
+
+	var total AccrualResult
+	day = Day(day)
+
+	for d := MonthOf(day); !d.After(day); d = d.AddDate(0, 0, 1) {
+		result, err := Accrue(ctx, store, d)
+		if err != nil {
+			return total, fmt.Errorf("%s: %w", d.Format("2006-01-02"), err)
+		}
+		total.Accounts = result.Accounts
+		total.Accrued += result.Accrued
+	}
+
+	return total, nil
+}
+
+// DaysToAccrue lists the days from the one after the last accrued day up to
+// the day before today. Yesterday is always included; it is the only day
+// when nothing was accrued yet.
+func DaysToAccrue(ctx context.Context, store db.Store, today time.Time) ([]time.Time, error) {
+
+	yesterday := Day(today).AddDate(0, 0, -1)
+
+	last, err := store.GetLastInterestAccrualDay(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []time.Time{yesterday}, nil
+		}
+		return []time.Time{yesterday}, fmt.Errorf("cannot get the last accrual day: %w", err)
+	}
+
+	var days []time.Time
+	for d := Day(last).AddDate(0, 0, 1); d.Before(yesterday); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return append(days, yesterday), nil
+}
+
+// PostMonth posts the interest accrued during month to every account that
+// has not been paid for it yet and returns the number of postings made
+func PostMonth(ctx context.Context, store db.Store, month time.Time) (int, error) {
+
+	month = MonthOf(month)
+
+	accountIDs, err := store.ListAccountsToPostInterest(ctx, db.ListAccountsToPostInterestParams{
+		FromDay: month,
+		ToDay:   month.AddDate(0, 1, -1),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("cannot list accounts to post interest to: %w", err)
+	}
+
+	posted := 0
+	for _, accountID := range accountIDs {
+		result, err := store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: accountID,
+			Month:     month,
+		})
+		if err != nil {
+			return posted, fmt.Errorf("cannot post interest of account %d: %w", accountID, err)
+		}
+
+		if !result.AlreadyPosted {
+			posted++
+		}
+	}
+
+	return posted, nil
+}
@@ -0,0 +1,223 @@
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthHelpers(t *testing.T) {
+
+	day := time.Date(2024, time.February, 29, 23, 30, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), Day(day))
+	require.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), MonthOf(day))
+	require.True(t, IsMonthEnd(day))
+	require.False(t, IsMonthEnd(day.AddDate(0, 0, -1)))
+}
+
+func TestAccrue(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	day := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	// a full first page makes Accrue ask for the next one
+	firstPage := make([]db.ListInterestBearingBalancesRow, accrualPageSize)
+	for i := range firstPage {
+		firstPage[i] = db.ListInterestBearingBalancesRow{
+			AccountID:     int64(i + 1),
+			AnnualRateBps: 250,
+			DayCount:      Actual365,
+			Balance:       1_000_000,
+		}
+	}
+	last := db.ListInterestBearingBalancesRow{
+		AccountID:     accrualPageSize + 1,
+		AnnualRateBps: 250,
+		DayCount:      Actual365,
+		Balance:       1_000_000,
+	}
+
+	gomock.InOrder(
+		store.EXPECT().
+			ListInterestBearingBalances(gomock.Any(), gomock.Eq(db.ListInterestBearingBalancesParams{
+				DayEnd:     day.AddDate(0, 0, 1),
+				AfterID:    0,
+				LimitCount: accrualPageSize,
+			})).
+			Return(firstPage, nil),
+		store.EXPECT().
+			ListInterestBearingBalances(gomock.Any(), gomock.Eq(db.ListInterestBearingBalancesParams{
+				DayEnd:     day.AddDate(0, 0, 1),
+				AfterID:    accrualPageSize,
+				LimitCount: accrualPageSize,
+			})).
+			Return([]db.ListInterestBearingBalancesRow{last}, nil),
+	)
+
+	// the last account was already accrued by an earlier run
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Any()).
+		Times(accrualPageSize).
+		DoAndReturn(func(ctx context.Context, arg db.CreateInterestAccrualParams) (int64, error) {
+			require.Equal(t, day, arg.Day)
+			require.Equal(t, int64(68_493_150), arg.AmountMicros)
+			return 1, nil
+		})
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:     last.AccountID,
+			Day:           day,
+			Balance:       last.Balance,
+			AnnualRateBps: last.AnnualRateBps,
+			DayCount:      last.DayCount,
+			AmountMicros:  68_493_150,
+		})).
+		Times(1).
+		Return(int64(0), nil)
+
+	result, err := Accrue(context.Background(), store, day.Add(13*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, accrualPageSize+1, result.Accounts)
+	require.Equal(t, accrualPageSize, result.Accrued)
+}
+
+func TestAccrueMonthToDate(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	day := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+
+	// every day of the month is accrued, not only the last one
+	var accrued []time.Time
+	store.EXPECT().
+		ListInterestBearingBalances(gomock.Any(), gomock.Any()).
+		Times(29).
+		DoAndReturn(func(ctx context.Context, arg db.ListInterestBearingBalancesParams) ([]db.ListInterestBearingBalancesRow, error) {
+			accrued = append(accrued, arg.DayEnd.AddDate(0, 0, -1))
+			return []db.ListInterestBearingBalancesRow{{AccountID: 1, AnnualRateBps: 250, DayCount: Actual365, Balance: 100}}, nil
+		})
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Any()).
+		Times(29).
+		DoAndReturn(func(ctx context.Context, arg db.CreateInterestAccrualParams) (int64, error) {
+			if arg.Day.Equal(day) {
+				return 1, nil
+			}
+			return 0, nil
+		})
+
+	result, err := AccrueMonthToDate(context.Background(), store, day)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Accounts)
+	require.Equal(t, 1, result.Accrued)
+	require.Equal(t, MonthOf(day), accrued[0])
+	require.Equal(t, day, accrued[len(accrued)-1])
+}
+
+func TestDaysToAccrue(t *testing.T) {
+
+	today := time.Date(2024, time.March, 2, 0, 5, 0, 0, time.UTC)
+	yesterday := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		last time.Time
+		err  error
+		days []time.Time
+	}{
+		{
+			name: "UpToDate",
+			last: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			days: []time.Time{yesterday},
+		},
+		{
+			name: "MissedDays",
+			last: time.Date(2024, time.February, 27, 0, 0, 0, 0, time.UTC),
+			days: []time.Time{
+				time.Date(2024, time.February, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+				yesterday,
+			},
+		},
+		{
+			name: "YesterdayAlreadyAccrued",
+			last: yesterday,
+			days: []time.Time{yesterday},
+		},
+		{
+			name: "NothingAccruedYet",
+			err:  sql.ErrNoRows,
+			days: []time.Time{yesterday},
+		},
+		{
+			name: "InternalError",
+			err:  sql.ErrConnDone,
+			days: []time.Time{yesterday},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetLastInterestAccrualDay(gomock.Any()).
+				Times(1).
+				Return(tc.last, tc.err)
+
+			days, err := DaysToAccrue(context.Background(), store, today)
+			if tc.err != nil && tc.err != sql.ErrNoRows {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.days, days)
+		})
+	}
+}
+
+func TestPostMonth(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	month := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	store.EXPECT().
+		ListAccountsToPostInterest(gomock.Any(), gomock.Eq(db.ListAccountsToPostInterestParams{
+			FromDay: month,
+			ToDay:   time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		})).
+		Times(1).
+		Return([]int64{1, 2}, nil)
+
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 1, Month: month})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, nil)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 2, Month: month})).
+		Times(1).
+		Return(db.PostInterestTxResult{AlreadyPosted: true}, nil)
+
+	posted, err := PostMonth(context.Background(), store, month.AddDate(0, 0, 10))
+	require.NoError(t, err)
+	require.Equal(t, 1, posted)
+}
@@ -0,0 +1,129 @@
+// Package kyc keeps the identity documents users submit for verification.
+// Files go to a DocumentStorage; their metadata and review state live in the
+// kyc_documents table.
+package kyc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxDocumentSize is the largest document users can submit, in bytes. It
+// keeps uploads below the default 4MB gRPC message limit.
+const MaxDocumentSize = 3 << 20
+
+// ErrInvalidKey is returned for storage keys that were not made by NewKey
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// DocumentStorage stores document files under opaque keys
+type DocumentStorage interface {
+	// Save writes the content under key and returns its size and SHA-256 in hex
+	Save(ctx context.Context, key string, content io.Reader) (size int64, sha string, err error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewKey returns a new storage key for a document of username. Keys never
+// contain anything the user chose apart from the username.
+func NewKey(username string) (string, error) {
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %w", err)
+	}
+
+	return username + "/" + hex.EncodeToString(b), nil
+}
+
+// LocalStorage keeps documents as files below a directory on local disk
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage creates a storage rooted at dir. The directory is created
+// on the first save.
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{root: dir}
+}
+
+func (storage *LocalStorage) path(key string) (string, error) {
+
+	parts := strings.Split(key, "/")
+	if len(parts) != 2 {
+		return "", ErrInvalidKey
+	}
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `/\`) {
+			return "", ErrInvalidKey
+		}
+	}
+
+	return filepath.Join(storage.root, parts[0], parts[1]), nil
+}
+
+// Save writes the content to a temporary file and renames it into place, so
+// a failed upload never leaves a partial document behind
+func (storage *LocalStorage) Save(ctx context.Context, key string, content io.Reader) (int64, string, error) {
+
+	path, err := storage.path(key)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return 0, "", fmt.Errorf("failed to create document directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create document file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to write document: %w", err)
+	}
+
+	if err = os.Rename(file.Name(), path); err != nil {
+		return 0, "", fmt.Errorf("failed to store document: %w", err)
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (storage *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+
+	path, err := storage.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+func (storage *LocalStorage) Delete(ctx context.Context, key string) error {
+
+	path, err := storage.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
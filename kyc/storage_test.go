@@ -0,0 +1,56 @@
+package kyc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+
+	storage := NewLocalStorage(t.TempDir())
+	ctx := context.Background()
+
+	key, err := NewKey("alice")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key, "alice/"))
+
+	content := []byte("passport scan")
+	size, sha, err := storage.Save(ctx, key, bytes.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), size)
+
+	sum := sha256.Sum256(content)
+	require.Equal(t, hex.EncodeToString(sum[:]), sha)
+
+	file, err := storage.Open(ctx, key)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, content, stored)
+
+	require.NoError(t, storage.Delete(ctx, key))
+	_, err = storage.Open(ctx, key)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// deleting twice is fine
+	require.NoError(t, storage.Delete(ctx, key))
+}
+
+func TestLocalStorageInvalidKey(t *testing.T) {
+
+	storage := NewLocalStorage(t.TempDir())
+
+	for _, key := range []string{"", "alice", "../etc/passwd", "alice/../bob", "alice/x/y", "/abs"} {
+		_, _, err := storage.Save(context.Background(), key, strings.NewReader("x"))
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
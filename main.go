@@ -3,23 +3,38 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/hibiken/asynq"
+	"github.com/joefazee/simplebank/activity"
+	"github.com/joefazee/simplebank/currency"
 	"github.com/joefazee/simplebank/doc/swagger"
 	"github.com/joefazee/simplebank/gapi"
+	"github.com/joefazee/simplebank/health"
+	"github.com/joefazee/simplebank/metrics"
 	"github.com/joefazee/simplebank/pb"
+	"github.com/joefazee/simplebank/tracing"
 	"github.com/joefazee/simplebank/worker"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joefazee/simplebank/api"
 	db "github.com/joefazee/simplebank/db/sqlc"
@@ -29,82 +44,331 @@ import (
 
 const (
 	development = "development"
+	configPath  = "."
+
+	serveAll     = "all"
+	serveGrpc    = "grpc"
+	serveGateway = "gateway"
+	serveGin     = "gin"
+	serveWorker  = "worker"
+
+	// shutdownTimeout bounds how long in-flight requests get to finish
+	shutdownTimeout = 20 * time.Second
 )
 
+var serveModes = map[string]bool{
+	serveAll:     true,
+	serveGrpc:    true,
+	serveGateway: true,
+	serveGin:     true,
+	serveWorker:  true,
+}
+
+var interruptSignals = []os.Signal{
+	os.Interrupt,
+	syscall.SIGTERM,
+	syscall.SIGINT,
+}
+
 func main() {
 
-	config, err := util.LoadCondfig(".")
+	config, err := util.LoadConfig(configPath)
 
 	if err != nil {
-		log.Fatal().Err(err)
+		log.Fatal().Err(err).Msg("cannot load config")
 	}
 
 	if config.Environment == development {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
+	setLogLevel(config.LogLevel)
+
+	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
+	err = runCommand(ctx, config, os.Args[1:])
+	stop()
+
+	if err != nil {
+		log.Error().Err(err).Msg("command failed")
+		os.Exit(1)
+	}
+}
+
+// serveCommand runs the gRPC server, the gateway and the worker in one process,
+// or a single one when a mode is given. The Gin API is never part of all: it
+// listens on the HTTP address of the gateway, so gin runs it alone with the
+// worker it needs for batch transfers and the same metrics, tracing and health
+// endpoints as the gateway.
+func serveCommand(ctx context.Context, config util.Config, args []string) error {
+
+	mode := serveAll
+	if len(args) > 0 {
+		mode = args[0]
+	}
+	if !serveModes[mode] {
+		return fmt.Errorf("unknown serve mode %q, expected one of all, grpc, gateway, gin or worker", mode)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, config)
+	if err != nil {
+		return fmt.Errorf("unable to set up tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	conn, err := sql.Open(config.DBDriver, config.DBSource)
 	if err != nil {
-		log.Fatal().Err(err)
+		return fmt.Errorf("cannot open database: %w", err)
+	}
+	defer conn.Close()
+
+	schemaVersion, err := runDBMigration(config.DbMigrationURL, config.DBSource)
+	if err != nil {
+		return err
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	appMetrics := metrics.New(registry)
+	if err = appMetrics.RegisterDBStats(conn); err != nil {
+		return fmt.Errorf("unable to register db stats collector: %w", err)
 	}
 
-	runDBMigration(config.DbMigrationURL, config.DBSource)
+	store := metrics.InstrumentStore(db.NewStore(conn), appMetrics)
 
-	store := db.NewStore(conn)
+	currencies, err := currency.Load(ctx, store)
+	if err != nil {
+		return fmt.Errorf("unable to load currencies: %w", err)
+	}
+	log.Info().Strs("enabled", currencies.Enabled()).Msg("loaded currencies")
 
 	redisOpt := asynq.RedisClientOpt{Addr: config.RedisAddress}
 	taskDistributor := worker.NewRedisTaskTaskDistributor(redisOpt)
+	defer taskDistributor.Close()
+
+	healthChecker := health.NewChecker(pb.SimpleBank_ServiceDesc.ServiceName)
+	healthChecker.AddCheck("postgres", health.Postgres(conn))
+	healthChecker.AddCheck("redis", taskDistributor.Ping)
+	healthChecker.AddCheck("migrations", health.Migrations(conn, schemaVersion))
+
+	waitGroup, ctx := errgroup.WithContext(ctx)
+
+	rateLimiter := gapi.NewRateLimiter(config.RateLimitRPS, config.RateLimitBurst)
+	activityHub := activity.NewHub()
+
+	runConfigWatcher(ctx, waitGroup, config, rateLimiter)
+
+	runWorker := func() error {
+		if err := runTaskProcessor(ctx, waitGroup, redisOpt, store, appMetrics, currencies); err != nil {
+			return err
+		}
+		runOutboxRelay(ctx, waitGroup, store, taskDistributor)
+		runInterestScheduler(ctx, waitGroup, store, taskDistributor)
+		return nil
+	}
+
+	startServers := func() error {
+		switch mode {
+		case serveAll:
+			runHealthChecker(ctx, waitGroup, healthChecker)
+			runActivityListener(ctx, waitGroup, config, activityHub)
+			if err := runWorker(); err != nil {
+				return err
+			}
+			if err := runGatewayServer(ctx, waitGroup, config, store, taskDistributor, activityHub, currencies, appMetrics, rateLimiter, registry, healthChecker); err != nil {
+				return err
+			}
+			return runGrpcServer(ctx, waitGroup, config, store, taskDistributor, activityHub, currencies, appMetrics, rateLimiter, healthChecker)
+		case serveGrpc:
+			runHealthChecker(ctx, waitGroup, healthChecker)
+			runActivityListener(ctx, waitGroup, config, activityHub)
+			return runGrpcServer(ctx, waitGroup, config, store, taskDistributor, activityHub, currencies, appMetrics, rateLimiter, healthChecker)
+		case serveGateway:
+			runHealthChecker(ctx, waitGroup, healthChecker)
+			runActivityListener(ctx, waitGroup, config, activityHub)
+			return runGatewayServer(ctx, waitGroup, config, store, taskDistributor, activityHub, currencies, appMetrics, rateLimiter, registry, healthChecker)
+		case serveGin:
+			runHealthChecker(ctx, waitGroup, healthChecker)
+			if err := runWorker(); err != nil {
+				return err
+			}
+			return runGinServer(ctx, waitGroup, config, store, currencies, appMetrics, registry, healthChecker)
+		case serveWorker:
+			return runWorker()
+		}
+		return nil
+	}
+
+	if startErr := startServers(); startErr != nil {
+		// failing the group cancels ctx, so whatever already started shuts down
+		waitGroup.Go(func() error {
+			return startErr
+		})
+	}
+
+	if err = waitGroup.Wait(); err != nil {
+		return fmt.Errorf("server stopped with error: %w", err)
+	}
+
+	log.Info().Msg("shutdown complete")
+	return nil
+}
+
+// runHealthChecker keeps readiness up to date and fails it as soon as shutdown
+// starts, so the load balancer stops routing new requests to this instance.
+func runHealthChecker(ctx context.Context, waitGroup *errgroup.Group, healthChecker *health.Checker) {
+	waitGroup.Go(func() error {
+		healthChecker.Start(ctx)
+		healthChecker.Shutdown()
+		log.Info().Msg("readiness disabled, shutting down")
+		return nil
+	})
+}
+
+// runActivityListener feeds account watchers from Postgres notifications. When
+// it stops, open WatchAccount streams end so the servers can drain.
+func runActivityListener(ctx context.Context, waitGroup *errgroup.Group, config util.Config, activityHub *activity.Hub) {
+	waitGroup.Go(func() error {
+		log.Info().Msg("starting account activity listener")
+		err := activity.Listen(ctx, config.DBSource, activityHub)
+		log.Info().Msg("account activity listener is stopped")
+		return err
+	})
+}
 
-	go runTaskProcessor(redisOpt, store)
-	go runGatewayServer(config, store, taskDistributor)
-	runGrpcServer(config, store, taskDistributor)
+// runConfigWatcher applies reloadable settings when the config files change
+func runConfigWatcher(ctx context.Context, waitGroup *errgroup.Group, config util.Config, rateLimiter *gapi.RateLimiter) {
+	waitGroup.Go(func() error {
+		err := util.WatchConfig(ctx, configPath, config, func(config util.Config) {
+			setLogLevel(config.LogLevel)
+			rateLimiter.Update(config.RateLimitRPS, config.RateLimitBurst)
+			log.Info().Str("log_level", config.LogLevel).Float64("rate_limit_rps", config.RateLimitRPS).
+				Int("rate_limit_burst", config.RateLimitBurst).Msg("applied config changes")
+		})
+		if err != nil {
+			// the server keeps running with the settings it started with
+			log.Error().Err(err).Msg("config hot reload is disabled")
+		}
+		return nil
+	})
+}
 
+func setLogLevel(level string) {
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid log level")
+		return
+	}
+	zerolog.SetGlobalLevel(logLevel)
 }
 
-func runDBMigration(migrationURL, dbSource string) {
+func runDBMigration(migrationURL, dbSource string) (uint, error) {
 	migration, err := migrate.New(migrationURL, dbSource)
 
 	if err != nil {
-		log.Fatal().Msgf("migration error %s", err)
+		return 0, fmt.Errorf("migration error: %w", err)
 	}
+	defer migration.Close()
 
 	if err = migration.Up(); err != nil && err != migrate.ErrNoChange {
-		log.Fatal().Msgf("migration error %s", err)
+		return 0, fmt.Errorf("migration error: %w", err)
 	}
 
-	log.Info().Msg("migration successful")
-}
+	version, _, err := migration.Version()
+	if err != nil {
+		return 0, fmt.Errorf("migration error: %w", err)
+	}
 
-func runGrpcServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
+	log.Info().Uint("version", version).Msg("migration successful")
+	return version, nil
+}
 
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGrpcServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+	activityHub *activity.Hub,
+	currencies *currency.Registry,
+	appMetrics *metrics.Metrics,
+	rateLimiter *gapi.RateLimiter,
+	healthChecker *health.Checker,
+) error {
+
+	server, err := gapi.NewServer(config, store, taskDistributor, activityHub, currencies)
 	if err != nil {
-		log.Fatal().Msg("unable to create grpc server")
+		return fmt.Errorf("unable to create grpc server: %w", err)
 	}
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(grpcLogger)
+	interceptors := grpc.ChainUnaryInterceptor(
+		gapi.GrpcTracing(),
+		gapi.GrpcLogger,
+		gapi.GrpcMetrics(appMetrics),
+		gapi.GrpcRateLimiter(rateLimiter),
+	)
+	streamInterceptors := grpc.ChainStreamInterceptor(gapi.GrpcStreamTracing())
+	grpcServer := grpc.NewServer(interceptors, streamInterceptors)
 	pb.RegisterSimpleBankServer(grpcServer, server)
+	healthpb.RegisterHealthServer(grpcServer, healthChecker.GrpcServer())
 	reflection.Register(grpcServer)
 
 	listener, err := net.Listen("tcp", config.GrpcServerAddress)
 	if err != nil {
-		log.Fatal().Msgf("unable to listen on %s (%s)", config.GrpcServerAddress, err.Error())
+		return fmt.Errorf("unable to listen on %s: %w", config.GrpcServerAddress, err)
 	}
 
-	log.Printf("starting grpc server on %s", config.GrpcServerAddress)
-	err = grpcServer.Serve(listener)
-	if err != nil {
-		log.Fatal().Msgf("unable to serve request on %s (%s)", config.GrpcServerAddress, err.Error())
-	}
+	waitGroup.Go(func() error {
+		log.Printf("starting grpc server on %s", config.GrpcServerAddress)
+		err := grpcServer.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("unable to serve request on %s: %w", config.GrpcServerAddress, err)
+		}
+		return nil
+	})
 
-}
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown grpc server")
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			log.Warn().Msg("grpc server did not drain in time, forcing stop")
+			grpcServer.Stop()
+		}
+
+		log.Info().Msg("grpc server is stopped")
+		return nil
+	})
 
-func runGatewayServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) {
+	return nil
+}
 
-	server, err := gapi.NewServer(config, store, taskDistributor)
+func runGatewayServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	store db.Store,
+	taskDistributor worker.TaskDistributor,
+	activityHub *activity.Hub,
+	currencies *currency.Registry,
+	appMetrics *metrics.Metrics,
+	rateLimiter *gapi.RateLimiter,
+	gatherer prometheus.Gatherer,
+	healthChecker *health.Checker,
+) error {
+
+	server, err := gapi.NewServer(config, store, taskDistributor, activityHub, currencies)
 	if err != nil {
-		log.Fatal().Msgf("unable to create grpc server %s", err)
+		return fmt.Errorf("unable to create grpc server: %w", err)
 	}
 
 	jsonOption := runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
@@ -117,52 +381,138 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 	})
 
 	grpcMux := runtime.NewServeMux(jsonOption)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 
 	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
 	if err != nil {
-		log.Fatal().Msgf("unable to register server handler %s", err)
+		return fmt.Errorf("unable to register server handler: %w", err)
+	}
+
+	err = grpcMux.HandlePath(http.MethodGet, gapi.WatchAccountPattern, server.HandleWatchAccount(grpcMux))
+	if err != nil {
+		return fmt.Errorf("unable to register watch account handler: %w", err)
 	}
 
 	mux := http.NewServeMux()
-	mux.Handle("/", grpcMux)
+	mux.Handle("/", gapi.HttpRateLimiter(rateLimiter, grpcMux))
 
 	fs := http.FileServer(http.FS(swagger.Doc))
 	mux.Handle("/swagger/", http.StripPrefix("/swagger/", fs))
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	mux.Handle("/healthz", healthChecker.LivenessHandler())
+	mux.Handle("/readyz", healthChecker.ReadinessHandler())
 
-	listener, err := net.Listen("tcp", config.HttServerAddress)
-	if err != nil {
-		log.Fatal().Msgf("unable to listen on %s (%s)", config.HttServerAddress, err.Error())
+	httpServer := &http.Server{
+		Addr:    config.HttServerAddress,
+		Handler: gapi.HttpTracing(gapi.HttpLogger(gapi.HttpMetrics(appMetrics, mux))),
 	}
 
-	log.Printf("starting HTTP Gateway server on %s", config.HttServerAddress)
-	handler := gapi.HttpLogger(mux)
-	err = http.Serve(listener, handler)
+	runHTTPServer(ctx, waitGroup, "HTTP gateway server", httpServer)
+	return nil
+}
+
+func runGinServer(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	config util.Config,
+	store db.Store,
+	currencies *currency.Registry,
+	appMetrics *metrics.Metrics,
+	gatherer prometheus.Gatherer,
+	healthChecker *health.Checker,
+) error {
+
+	server, err := api.NewServer(config, store, currencies)
 	if err != nil {
-		log.Fatal().Msgf("unable to serve request on %s (%s)", config.HttServerAddress, err.Error())
+		return fmt.Errorf("unable to create gin server: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", server)
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	mux.Handle("/healthz", healthChecker.LivenessHandler())
+	mux.Handle("/readyz", healthChecker.ReadinessHandler())
+
+	httpServer := &http.Server{
+		Addr:    config.HttServerAddress,
+		Handler: gapi.HttpTracing(gapi.HttpMetrics(appMetrics, mux)),
 	}
 
+	runHTTPServer(ctx, waitGroup, "gin server", httpServer)
+	return nil
 }
 
-func runGinServer(config util.Config, store db.Store) {
-	server, err := api.NewServer(config, store)
-	if err != nil {
-		log.Fatal().Msgf("unable to create server")
-	}
+// runHTTPServer serves until ctx is cancelled, then lets in-flight requests finish
+func runHTTPServer(ctx context.Context, waitGroup *errgroup.Group, name string, httpServer *http.Server) {
 
-	err = server.Start(config.HttServerAddress)
+	waitGroup.Go(func() error {
+		log.Printf("starting %s on %s", name, httpServer.Addr)
+		err := httpServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("unable to serve request on %s: %w", httpServer.Addr, err)
+		}
+		return nil
+	})
 
-	if err != nil {
-		log.Fatal().Err(err)
-	}
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msgf("graceful shutdown %s", name)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shutdown %s: %w", name, err)
+		}
+
+		log.Info().Msgf("%s is stopped", name)
+		return nil
+	})
 }
 
-func runTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) {
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store)
+func runOutboxRelay(ctx context.Context, waitGroup *errgroup.Group, store db.Store, taskDistributor worker.TaskDistributor) {
+	relay := worker.NewOutboxRelay(store, worker.NewTaskEventPublisher(taskDistributor))
+
+	waitGroup.Go(func() error {
+		log.Info().Msg("starting outbox relay")
+		err := relay.Start(ctx)
+		log.Info().Msg("outbox relay is stopped")
+		return err
+	})
+}
+
+// runInterestScheduler enqueues the daily interest accrual
+func runInterestScheduler(ctx context.Context, waitGroup *errgroup.Group, store db.Store, taskDistributor worker.TaskDistributor) {
+	waitGroup.Go(func() error {
+		log.Info().Msg("starting interest scheduler")
+		worker.ScheduleInterestAccrual(ctx, store, taskDistributor)
+		log.Info().Msg("interest scheduler is stopped")
+		return nil
+	})
+}
+
+func runTaskProcessor(
+	ctx context.Context,
+	waitGroup *errgroup.Group,
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	appMetrics *metrics.Metrics,
+	currencies *currency.Registry,
+) error {
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, appMetrics, currencies)
 	log.Info().Msg("starting task processor")
 	err := taskProcessor.Start()
 	if err != nil {
-		log.Fatal().Err(err).Msg("unable to start task processor")
+		return fmt.Errorf("unable to start task processor: %w", err)
 	}
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		log.Info().Msg("graceful shutdown task processor")
+
+		taskProcessor.Shutdown()
+		log.Info().Msg("task processor is stopped")
+		return nil
+	})
+
+	return nil
 }
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "simple_bank"
+
+// Metrics holds every collector exported by the service
+type Metrics struct {
+	registerer prometheus.Registerer
+
+	GrpcRequests       *prometheus.CounterVec
+	GrpcDuration       *prometheus.HistogramVec
+	HttpRequests       *prometheus.CounterVec
+	HttpDuration       *prometheus.HistogramVec
+	TransferTxDuration prometheus.Histogram
+	TransferTxFailures *prometheus.CounterVec
+	TasksProcessed     *prometheus.CounterVec
+	TasksFailed        *prometheus.CounterVec
+}
+
+// New creates the collectors and registers them with registerer.
+// Tests should pass prometheus.NewRegistry() to stay isolated.
+func New(registerer prometheus.Registerer) *Metrics {
+
+	m := &Metrics{
+		registerer: registerer,
+		GrpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of gRPC requests by method and status code.",
+		}, []string{"method", "status_code"}),
+		GrpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of gRPC requests by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "status_code"}),
+		HttpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP gateway requests by method, path and status code.",
+		}, []string{"method", "path", "status_code"}),
+		HttpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP gateway requests by method, path and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "path", "status_code"}),
+		TransferTxDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "transfer_tx_duration_seconds",
+			Help:      "Duration of the transfer transaction.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		TransferTxFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "transfer_tx_failures_total",
+			Help:      "Number of failed transfer transactions by reason.",
+		}, []string{"reason"}),
+		TasksProcessed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "worker",
+			Name:      "tasks_processed_total",
+			Help:      "Number of tasks processed by task type, including failed ones.",
+		}, []string{"task_type"}),
+		TasksFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "worker",
+			Name:      "tasks_failed_total",
+			Help:      "Number of tasks that returned an error by task type.",
+		}, []string{"task_type"}),
+	}
+
+	registerer.MustRegister(
+		m.GrpcRequests,
+		m.GrpcDuration,
+		m.HttpRequests,
+		m.HttpDuration,
+		m.TransferTxDuration,
+		m.TransferTxFailures,
+		m.TasksProcessed,
+		m.TasksFailed,
+	)
+
+	return m
+}
+
+// RegisterDBStats exports the connection pool statistics of db
+func (m *Metrics) RegisterDBStats(db *sql.DB) error {
+	return m.registerer.Register(collectors.NewDBStatsCollector(db, namespace))
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type instrumentedStore struct {
+	db.Store
+	metrics *Metrics
+}
+
+// InstrumentStore wraps store so the money moving transactions are measured
+func InstrumentStore(store db.Store, m *Metrics) db.Store {
+	return &instrumentedStore{
+		Store:   store,
+		metrics: m,
+	}
+}
+
+func (store *instrumentedStore) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+
+	startTime := time.Now()
+	result, err := store.Store.TransferTx(ctx, arg)
+	store.metrics.TransferTxDuration.Observe(time.Since(startTime).Seconds())
+
+	if err != nil {
+		store.metrics.TransferTxFailures.WithLabelValues(failureReason(err)).Inc()
+	}
+
+	return result, err
+}
+
+// failureReason maps an error to a label with bounded cardinality
+func failureReason(err error) string {
+
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Name()
+	}
+
+	return "unknown"
+}
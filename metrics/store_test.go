@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentStoreTransferTx(t *testing.T) {
+
+	testCases := []struct {
+		name         string
+		buildStubs   func(store *mockdb.MockStore)
+		checkMetrics func(m *Metrics)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkMetrics: func(m *Metrics) {
+				require.Equal(t, 1, testutil.CollectAndCount(m.TransferTxDuration))
+				require.Equal(t, 0, testutil.CollectAndCount(m.TransferTxFailures))
+			},
+		},
+		{
+			name: "DeadlockDetected",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{}, &pq.Error{Code: "40P01"})
+			},
+			checkMetrics: func(m *Metrics) {
+				require.Equal(t, float64(1), testutil.ToFloat64(m.TransferTxFailures.WithLabelValues("deadlock_detected")))
+			},
+		},
+		{
+			name: "Canceled",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.TransferTxResult{}, context.Canceled)
+			},
+			checkMetrics: func(m *Metrics) {
+				require.Equal(t, float64(1), testutil.ToFloat64(m.TransferTxFailures.WithLabelValues("canceled")))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			m := New(prometheus.NewRegistry())
+			_, _ = InstrumentStore(store, m).TransferTx(context.Background(), db.TransferTxParams{})
+			tc.checkMetrics(m)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: rpc_preview_transfer_fee.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PreviewTransferFeeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromAccountId int64 `protobuf:"varint,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	Amount        int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *PreviewTransferFeeRequest) Reset() {
+	*x = PreviewTransferFeeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_preview_transfer_fee_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewTransferFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewTransferFeeRequest) ProtoMessage() {}
+
+func (x *PreviewTransferFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_preview_transfer_fee_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewTransferFeeRequest.ProtoReflect.Descriptor instead.
+func (*PreviewTransferFeeRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_preview_transfer_fee_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreviewTransferFeeRequest) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *PreviewTransferFeeRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type PreviewTransferFeeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fee *TransferFee `protobuf:"bytes,1,opt,name=fee,proto3" json:"fee,omitempty"`
+	// amount plus fee, what the sender's balance would go down by
+	TotalDebit int64 `protobuf:"varint,2,opt,name=total_debit,json=totalDebit,proto3" json:"total_debit,omitempty"`
+}
+
+func (x *PreviewTransferFeeResponse) Reset() {
+	*x = PreviewTransferFeeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_preview_transfer_fee_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewTransferFeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewTransferFeeResponse) ProtoMessage() {}
+
+func (x *PreviewTransferFeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_preview_transfer_fee_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewTransferFeeResponse.ProtoReflect.Descriptor instead.
+func (*PreviewTransferFeeResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_preview_transfer_fee_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PreviewTransferFeeResponse) GetFee() *TransferFee {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *PreviewTransferFeeResponse) GetTotalDebit() int64 {
+	if x != nil {
+		return x.TotalDebit
+	}
+	return 0
+}
+
+var File_rpc_preview_transfer_fee_proto protoreflect.FileDescriptor
+
+var file_rpc_preview_transfer_fee_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x1a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5b, 0x0a, 0x19, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x46, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x66, 0x72, 0x6f, 0x6d,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x60, 0x0a, 0x1a, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x46, 0x65, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x46, 0x65, 0x65, 0x52, 0x03, 0x66,
+	0x65, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x62, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x65,
+	0x62, 0x69, 0x74, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6a, 0x6f, 0x65, 0x66, 0x61, 0x7a, 0x65, 0x65, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_preview_transfer_fee_proto_rawDescOnce sync.Once
+	file_rpc_preview_transfer_fee_proto_rawDescData = file_rpc_preview_transfer_fee_proto_rawDesc
+)
+
+func file_rpc_preview_transfer_fee_proto_rawDescGZIP() []byte {
+	file_rpc_preview_transfer_fee_proto_rawDescOnce.Do(func() {
+		file_rpc_preview_transfer_fee_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_preview_transfer_fee_proto_rawDescData)
+	})
+	return file_rpc_preview_transfer_fee_proto_rawDescData
+}
+
+var file_rpc_preview_transfer_fee_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_preview_transfer_fee_proto_goTypes = []interface{}{
+	(*PreviewTransferFeeRequest)(nil),  // 0: pb.PreviewTransferFeeRequest
+	(*PreviewTransferFeeResponse)(nil), // 1: pb.PreviewTransferFeeResponse
+	(*TransferFee)(nil),                // 2: pb.TransferFee
+}
+var file_rpc_preview_transfer_fee_proto_depIdxs = []int32{
+	2, // 0: pb.PreviewTransferFeeResponse.fee:type_name -> pb.TransferFee
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_preview_transfer_fee_proto_init() }
+func file_rpc_preview_transfer_fee_proto_init() {
+	if File_rpc_preview_transfer_fee_proto != nil {
+		return
+	}
+	file_transfer_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_preview_transfer_fee_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreviewTransferFeeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_preview_transfer_fee_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreviewTransferFeeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_preview_transfer_fee_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_preview_transfer_fee_proto_goTypes,
+		DependencyIndexes: file_rpc_preview_transfer_fee_proto_depIdxs,
+		MessageInfos:      file_rpc_preview_transfer_fee_proto_msgTypes,
+	}.Build()
+	File_rpc_preview_transfer_fee_proto = out.File
+	file_rpc_preview_transfer_fee_proto_rawDesc = nil
+	file_rpc_preview_transfer_fee_proto_goTypes = nil
+	file_rpc_preview_transfer_fee_proto_depIdxs = nil
+}
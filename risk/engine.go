@@ -0,0 +1,50 @@
+package risk
+
+// Assessment is the decision for a transfer and the hits that led to it
+type Assessment struct {
+	Decision Decision
+	Hits     []Hit
+}
+
+// Engine evaluates a set of rules
+type Engine struct {
+	rules     []Rule
+	blockHits int
+}
+
+// NewEngine creates an engine running rules in order. When blockHits is
+// positive a transfer with that many hits is blocked even if every hit only
+// asked for a review.
+func NewEngine(blockHits int, rules ...Rule) *Engine {
+	return &Engine{
+		rules:     rules,
+		blockHits: blockHits,
+	}
+}
+
+// Evaluate runs every rule and returns the most severe decision
+func (engine *Engine) Evaluate(signals Signals) Assessment {
+
+	assessment := Assessment{
+		Decision: Allow,
+		Hits:     []Hit{},
+	}
+
+	for _, rule := range engine.rules {
+		hit := rule(signals)
+		if hit == nil {
+			continue
+		}
+
+		assessment.Hits = append(assessment.Hits, *hit)
+		if hit.Decision.severity() > assessment.Decision.severity() {
+			assessment.Decision = hit.Decision
+		}
+	}
+
+	if engine.blockHits > 0 && len(assessment.Hits) >= engine.blockHits {
+		assessment.Decision = Block
+	}
+
+	return assessment
+}
@@ -0,0 +1,141 @@
+package risk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func knownSender() Signals {
+	return Signals{
+		Amount:           10,
+		Currency:         "USD",
+		LargeAmount:      1000,
+		RecentTransfers:  1,
+		PayeeTransfers:   2,
+		PreviousSessions: 3,
+		DeviceSessions:   2,
+		IPSessions:       1,
+	}
+}
+
+func TestEngineEvaluate(t *testing.T) {
+
+	engine := NewEngine(3, Velocity(5), NewPayeeLargeAmount(), NewDevice(), UnusualIP())
+
+	testCases := []struct {
+		name     string
+		signals  func(signals *Signals)
+		decision Decision
+		rules    []string
+	}{
+		{
+			name:     "Allow",
+			signals:  func(signals *Signals) {},
+			decision: Allow,
+		},
+		{
+			name: "FirstSessionIsNotANewDevice",
+			signals: func(signals *Signals) {
+				signals.PreviousSessions = 0
+				signals.DeviceSessions = 0
+				signals.IPSessions = 0
+			},
+			decision: Allow,
+		},
+		{
+			name: "SmallAmountToNewPayee",
+			signals: func(signals *Signals) {
+				signals.PayeeTransfers = 0
+				signals.Amount = 999
+			},
+			decision: Allow,
+		},
+		{
+			name: "LargeAmountToNewPayee",
+			signals: func(signals *Signals) {
+				signals.PayeeTransfers = 0
+				signals.Amount = 1000
+			},
+			decision: Review,
+			rules:    []string{"new_payee_large_amount"},
+		},
+		{
+			name: "LargeAmountOfAnotherCurrency",
+			signals: func(signals *Signals) {
+				signals.PayeeTransfers = 0
+				signals.Amount = 1000
+				signals.Currency = "NGN"
+				signals.LargeAmount = 150000
+			},
+			decision: Allow,
+		},
+		{
+			name: "Velocity",
+			signals: func(signals *Signals) {
+				signals.RecentTransfers = 5
+			},
+			decision: Review,
+			rules:    []string{"velocity"},
+		},
+		{
+			name: "VelocityTwiceTheLimit",
+			signals: func(signals *Signals) {
+				signals.RecentTransfers = 10
+			},
+			decision: Block,
+			rules:    []string{"velocity"},
+		},
+		{
+			name: "NewDeviceAndUnusualIP",
+			signals: func(signals *Signals) {
+				signals.DeviceSessions = 0
+				signals.IPSessions = 0
+			},
+			decision: Review,
+			rules:    []string{"new_device", "unusual_ip"},
+		},
+		{
+			name: "TooManyHits",
+			signals: func(signals *Signals) {
+				signals.PayeeTransfers = 0
+				signals.Amount = 5000
+				signals.DeviceSessions = 0
+				signals.IPSessions = 0
+			},
+			decision: Block,
+			rules:    []string{"new_payee_large_amount", "new_device", "unusual_ip"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			signals := knownSender()
+			tc.signals(&signals)
+
+			assessment := engine.Evaluate(signals)
+			require.Equal(t, tc.decision, assessment.Decision)
+
+			rules := []string{}
+			for _, hit := range assessment.Hits {
+				require.NotEmpty(t, hit.Reason)
+				rules = append(rules, hit.Rule)
+			}
+			if tc.rules == nil {
+				tc.rules = []string{}
+			}
+			require.Equal(t, tc.rules, rules)
+		})
+	}
+}
+
+func TestDisabledRules(t *testing.T) {
+
+	engine := NewEngine(0, Velocity(0), NewPayeeLargeAmount())
+
+	assessment := engine.Evaluate(Signals{Amount: 1_000_000, RecentTransfers: 1000})
+	require.Equal(t, Allow, assessment.Decision)
+	require.Empty(t, assessment.Hits)
+}
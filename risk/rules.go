@@ -0,0 +1,131 @@
+// Package risk screens transfers before they execute. Rules look at what is
+// known about the sender and the transfer and each match contributes a hit;
+// the most severe hit decides whether the transfer is allowed, held for
+// review or blocked.
+package risk
+
+import (
+	"fmt"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+)
+
+// Decision is the outcome of screening a transfer
+type Decision string
+
+const (
+	Allow  Decision = db.RiskDecisionAllow
+	Review Decision = db.RiskDecisionReview
+	Block  Decision = db.RiskDecisionBlock
+)
+
+func (decision Decision) severity() int {
+	switch decision {
+	case Review:
+		return 1
+	case Block:
+		return 2
+	}
+	return 0
+}
+
+// Hit is a rule that matched a transfer
+type Hit struct {
+	Rule     string   `json:"rule"`
+	Decision Decision `json:"decision"`
+	Reason   string   `json:"reason"`
+}
+
+// Signals describes a transfer and what is known about its sender
+type Signals struct {
+	Amount   int64
+	Currency string
+	// LargeAmount is where large transfers start in Currency, 0 when the
+	// currency has no such amount
+	LargeAmount int64
+
+	// RecentTransfers is the number of transfers the sender made in the velocity window
+	RecentTransfers int64
+	// PayeeTransfers is the number of earlier transfers from the sender to the same account
+	PayeeTransfers int64
+	// PreviousSessions is the number of sessions the sender opened before the
+	// device and IP address of the request could count as known
+	PreviousSessions int64
+	// DeviceSessions is the part of PreviousSessions opened with the same user agent
+	DeviceSessions int64
+	// IPSessions is the part of PreviousSessions opened from the same IP address
+	IPSessions int64
+}
+
+// Rule returns a hit when the signals of a transfer match it, nil otherwise
+type Rule func(signals Signals) *Hit
+
+// Velocity holds the transfer for review once the sender makes more than
+// maxTransfers transfers in the velocity window, and blocks it past twice that
+func Velocity(maxTransfers int) Rule {
+	return func(signals Signals) *Hit {
+		count := signals.RecentTransfers + 1
+		if maxTransfers <= 0 || count <= int64(maxTransfers) {
+			return nil
+		}
+
+		decision := Review
+		if count > 2*int64(maxTransfers) {
+			decision = Block
+		}
+
+		return &Hit{
+			Rule:     "velocity",
+			Decision: decision,
+			Reason:   fmt.Sprintf("%d transfers in the window, at most %d expected", count, maxTransfers),
+		}
+	}
+}
+
+// NewPayeeLargeAmount holds a transfer to an account the sender never paid
+// before when it reaches the large amount of its currency
+func NewPayeeLargeAmount() Rule {
+	return func(signals Signals) *Hit {
+		if signals.LargeAmount <= 0 || signals.PayeeTransfers > 0 || signals.Amount < signals.LargeAmount {
+			return nil
+		}
+
+		return &Hit{
+			Rule:     "new_payee_large_amount",
+			Decision: Review,
+			Reason:   fmt.Sprintf("%d %s to a new payee, large amounts start at %d", signals.Amount, signals.Currency, signals.LargeAmount),
+		}
+	}
+}
+
+// NewDevice holds a transfer made with a user agent the sender never logged
+// in with before. Senders without earlier sessions have no known device yet.
+func NewDevice() Rule {
+	return func(signals Signals) *Hit {
+		if signals.PreviousSessions == 0 || signals.DeviceSessions > 0 {
+			return nil
+		}
+
+		return &Hit{
+			Rule:     "new_device",
+			Decision: Review,
+			Reason:   "user agent not seen in earlier sessions",
+		}
+	}
+}
+
+// UnusualIP holds a transfer made from an IP address the sender never logged
+// in from before. Senders without earlier sessions have no known address yet.
+func UnusualIP() Rule {
+	return func(signals Signals) *Hit {
+		if signals.PreviousSessions == 0 || signals.IPSessions > 0 {
+			return nil
+		}
+
+		return &Hit{
+			Rule:     "unusual_ip",
+			Decision: Review,
+			Reason:   "IP address not seen in earlier sessions",
+		}
+	}
+}
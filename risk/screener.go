@@ -0,0 +1,202 @@
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+)
+
+// Request is a transfer about to be made and where it comes from
+type Request struct {
+	Username      string
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Currency      string
+	UserAgent     string
+	ClientIP      string
+	// details kept on the transfer, they play no part in screening
+	Memo      string
+	Reference string
+	Metadata  map[string]string
+}
+
+// Screener gathers the signals of a transfer from the store and runs the
+// engine on them before the transfer executes
+type Screener struct {
+	store          db.Store
+	engine         *Engine
+	velocityWindow time.Duration
+	knownDeviceAge time.Duration
+}
+
+// NewScreener creates a screener with the built-in rules configured from config
+func NewScreener(config util.Config, store db.Store) *Screener {
+	return &Screener{
+		store: store,
+		engine: NewEngine(config.RiskBlockHits,
+			Velocity(config.RiskVelocityMaxTransfers),
+			NewPayeeLargeAmount(),
+			NewDevice(),
+			UnusualIP(),
+		),
+		velocityWindow: config.RiskVelocityWindow,
+		knownDeviceAge: config.RiskKnownDeviceAge,
+	}
+}
+
+// Screen evaluates a transfer without storing anything
+func (screener *Screener) Screen(ctx context.Context, req Request) (Assessment, error) {
+
+	now := time.Now()
+
+	signals, err := screener.store.GetTransferRiskSignals(ctx, db.GetTransferRiskSignalsParams{
+		Username:      req.Username,
+		VelocitySince: now.Add(-screener.velocityWindow),
+		ToAccountID:   req.ToAccountID,
+		KnownBefore:   now.Add(-screener.knownDeviceAge),
+		UserAgent:     req.UserAgent,
+		ClientIp:      hostOnly(req.ClientIP),
+		Currency:      req.Currency,
+	})
+	if err != nil {
+		return Assessment{}, fmt.Errorf("cannot get risk signals: %w", err)
+	}
+
+	return screener.engine.Evaluate(Signals{
+		Amount:           req.Amount,
+		Currency:         req.Currency,
+		LargeAmount:      signals.LargeAmount,
+		RecentTransfers:  signals.RecentTransfers,
+		PayeeTransfers:   signals.PayeeTransfers,
+		PreviousSessions: signals.PreviousSessions,
+		DeviceSessions:   signals.DeviceSessions,
+		IPSessions:       signals.IpSessions,
+	}), nil
+}
+
+// Transfer screens a transfer and hands the decision to the store, which
+// executes, holds or only records the transfer accordingly
+func (screener *Screener) Transfer(ctx context.Context, req Request) (db.ScreenedTransferTxResult, error) {
+
+	assessment, err := screener.Screen(ctx, req)
+	if err != nil {
+		return db.ScreenedTransferTxResult{}, err
+	}
+
+	hits, err := json.Marshal(assessment.Hits)
+	if err != nil {
+		return db.ScreenedTransferTxResult{}, fmt.Errorf("cannot marshal risk hits: %w", err)
+	}
+
+	metadata, err := db.TransferMetadata(req.Metadata)
+	if err != nil {
+		return db.ScreenedTransferTxResult{}, fmt.Errorf("cannot marshal transfer metadata: %w", err)
+	}
+
+	return screener.store.ScreenedTransferTx(ctx, db.ScreenedTransferTxParams{
+		CreateRiskDecisionParams: db.CreateRiskDecisionParams{
+			Username:      req.Username,
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   req.ToAccountID,
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+			UserAgent:     req.UserAgent,
+			ClientIp:      req.ClientIP,
+			Decision:      string(assessment.Decision),
+			Hits:          hits,
+			Memo:          req.Memo,
+			Reference:     req.Reference,
+			Metadata:      metadata,
+		},
+	})
+}
+
+// PayPaymentRequest screens the payment of a payment request from
+// req.FromAccountID and hands the decision to the store, which pays the
+// request only when the payment is allowed. The payee, amount and currency
+// of req are taken from the request.
+func (screener *Screener) PayPaymentRequest(ctx context.Context, id int64, req Request) (db.PayPaymentRequestTxResult, error) {
+
+	request, err := screener.store.GetPaymentRequest(ctx, id)
+	if err != nil {
+		return db.PayPaymentRequestTxResult{}, err
+	}
+	if request.Payer != req.Username {
+		// requests sent to someone else are not found
+		return db.PayPaymentRequestTxResult{}, sql.ErrNoRows
+	}
+
+	req.ToAccountID = request.ToAccountID
+	req.Amount = request.Amount
+	req.Currency = request.Currency
+
+	assessment, err := screener.Screen(ctx, req)
+	if err != nil {
+		return db.PayPaymentRequestTxResult{}, err
+	}
+
+	hits, err := json.Marshal(assessment.Hits)
+	if err != nil {
+		return db.PayPaymentRequestTxResult{}, fmt.Errorf("cannot marshal risk hits: %w", err)
+	}
+
+	return screener.store.PayPaymentRequestTx(ctx, db.PayPaymentRequestTxParams{
+		ID:            id,
+		Payer:         req.Username,
+		FromAccountID: req.FromAccountID,
+		Screening: db.CreateRiskDecisionParams{
+			UserAgent: req.UserAgent,
+			ClientIp:  req.ClientIP,
+			Decision:  string(assessment.Decision),
+			Hits:      hits,
+		},
+	})
+}
+
+// BatchTransfer screens every row of a batch sent from req.FromAccountID and
+// hands the decisions to the store with the batch, which fails the rows that
+// are not allowed. The payee, amount and reference of req are taken from each
+// row; the rows are screened independently of each other.
+func (screener *Screener) BatchTransfer(ctx context.Context, req Request, arg db.CreateBatchTransferTxParams) (db.CreateBatchTransferTxResult, error) {
+
+	arg.Screenings = make([]db.CreateRiskDecisionParams, len(arg.Rows))
+	for i, row := range arg.Rows {
+		req.ToAccountID = row.ToAccountID
+		req.Amount = row.Amount
+		req.Reference = row.Reference
+
+		assessment, err := screener.Screen(ctx, req)
+		if err != nil {
+			return db.CreateBatchTransferTxResult{}, err
+		}
+
+		hits, err := json.Marshal(assessment.Hits)
+		if err != nil {
+			return db.CreateBatchTransferTxResult{}, fmt.Errorf("cannot marshal risk hits: %w", err)
+		}
+
+		arg.Screenings[i] = db.CreateRiskDecisionParams{
+			UserAgent: req.UserAgent,
+			ClientIp:  req.ClientIP,
+			Decision:  string(assessment.Decision),
+			Hits:      hits,
+		}
+	}
+
+	return screener.store.CreateBatchTransferTx(ctx, arg)
+}
+
+// hostOnly drops the port gRPC peers report along with their address
+func hostOnly(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
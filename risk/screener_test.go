@@ -0,0 +1,92 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/joefazee/simplebank/db/mock"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreenerBatchTransfer(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := util.Config{
+		RiskVelocityWindow:       time.Hour,
+		RiskVelocityMaxTransfers: 5,
+	}
+
+	arg := db.CreateBatchTransferTxParams{
+		Owner:         "payer",
+		FromAccountID: 1,
+		Mode:          db.BatchModeBestEffort,
+		Rows: []db.BatchTransferRow{
+			{ToAccountID: 2, Amount: 10, Reference: "known payee"},
+			{ToAccountID: 3, Amount: 5000, Reference: "large amount to a new payee"},
+			{ToAccountID: 4, Amount: 10, Reference: "too many transfers"},
+		},
+	}
+
+	signals := map[int64]db.GetTransferRiskSignalsRow{
+		2: {PayeeTransfers: 3, LargeAmount: 1000},
+		3: {LargeAmount: 1000},
+		4: {PayeeTransfers: 1, RecentTransfers: 20, LargeAmount: 1000},
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetTransferRiskSignals(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(func(_ context.Context, params db.GetTransferRiskSignalsParams) (db.GetTransferRiskSignalsRow, error) {
+			require.Equal(t, "payer", params.Username)
+			require.Equal(t, util.USD, params.Currency)
+			require.Equal(t, "10.0.0.1", params.ClientIp)
+			return signals[params.ToAccountID], nil
+		})
+
+	var got db.CreateBatchTransferTxParams
+	store.EXPECT().
+		CreateBatchTransferTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, params db.CreateBatchTransferTxParams) (db.CreateBatchTransferTxResult, error) {
+			got = params
+			return db.CreateBatchTransferTxResult{}, nil
+		})
+
+	screener := NewScreener(config, store)
+	_, err := screener.BatchTransfer(context.Background(), Request{
+		Username:      "payer",
+		FromAccountID: 1,
+		Currency:      util.USD,
+		UserAgent:     "payroll/1.0",
+		ClientIP:      "10.0.0.1:52000",
+	}, arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Rows, got.Rows)
+	require.Len(t, got.Screenings, 3)
+
+	decisions := []string{db.RiskDecisionAllow, db.RiskDecisionReview, db.RiskDecisionBlock}
+	rules := []string{"", "new_payee_large_amount", "velocity"}
+	for i, screening := range got.Screenings {
+		require.Equal(t, decisions[i], screening.Decision)
+		require.Equal(t, "payroll/1.0", screening.UserAgent)
+		require.Equal(t, "10.0.0.1:52000", screening.ClientIp)
+
+		var hits []Hit
+		require.NoError(t, json.Unmarshal(screening.Hits, &hits))
+		if rules[i] == "" {
+			require.Empty(t, hits)
+			continue
+		}
+		require.Len(t, hits, 1)
+		require.Equal(t, rules[i], hits[0].Rule)
+	}
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joefazee/simplebank/util"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetup(t *testing.T) {
+
+	for _, exporter := range []string{"", ExporterNone, ExporterMemory, ExporterStdout} {
+		shutdown, err := Setup(context.Background(), util.Config{TracingExporter: exporter})
+		require.NoError(t, err, exporter)
+		require.NoError(t, shutdown(context.Background()))
+	}
+
+	_, err := Setup(context.Background(), util.Config{TracingExporter: "zipkin"})
+	require.Error(t, err)
+}
+
+func TestInjectExtract(t *testing.T) {
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	_, err := Setup(context.Background(), util.Config{})
+	require.NoError(t, err)
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "parent")
+	carrier := Inject(ctx)
+	span.End()
+	require.NotEmpty(t, carrier.Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), carrier))
+	require.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+
+	require.Equal(t, context.Background(), Extract(context.Background(), nil))
+}
@@ -1,11 +1,21 @@
 package util
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 )
 
+// Config stores all configuration of the application. Fields tagged
+// reload:"safe" are applied by WatchConfig without a restart.
 type Config struct {
 	DBDriver             string        `mapstructure:"DB_DRIVER"`
 	DBSource             string        `mapstructure:"DB_SOURCE"`
@@ -13,24 +23,267 @@ type Config struct {
 	GrpcServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFERESH_TOKEN_DURATION"`
+	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
 	DbMigrationURL       string        `mapstructure:"MIGRATION_URL"`
 	Environment          string        `mapstructure:"ENVIRONMENT"`
 	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
+	TracingExporter      string        `mapstructure:"TRACING_EXPORTER"`
+	OTLPEndpoint         string        `mapstructure:"OTLP_ENDPOINT"`
+	LogLevel             string        `mapstructure:"LOG_LEVEL" reload:"safe"`
+	RateLimitRPS         float64       `mapstructure:"RATE_LIMIT_RPS" reload:"safe"`
+	RateLimitBurst       int           `mapstructure:"RATE_LIMIT_BURST" reload:"safe"`
+	KYCStorageDir        string        `mapstructure:"KYC_STORAGE_DIR"`
+
+	RiskVelocityWindow       time.Duration `mapstructure:"RISK_VELOCITY_WINDOW"`
+	RiskVelocityMaxTransfers int           `mapstructure:"RISK_VELOCITY_MAX_TRANSFERS"`
+	RiskKnownDeviceAge       time.Duration `mapstructure:"RISK_KNOWN_DEVICE_AGE"`
+	RiskBlockHits            int           `mapstructure:"RISK_BLOCK_HITS"`
 }
 
-func LoadCondfig(p string) (config Config, err error) {
-	viper.AddConfigPath(p)
-	viper.SetConfigName(".env")
-	viper.SetConfigType("env")
+const (
+	baseConfigFile = "config.yaml"
+	dotEnvFile     = ".env"
 
-	viper.AutomaticEnv()
+	// secretFileSuffix marks an environment variable holding the path of a
+	// file with the real value, e.g. TOKEN_SYMMETRIC_KEY_FILE for mounted secrets.
+	secretFileSuffix = "_FILE"
 
-	err = viper.ReadInConfig()
-	if err != nil {
+	// legacyRefreshTokenDurationKey is the misspelled key older deployments still set
+	legacyRefreshTokenDurationKey = "REFERESH_TOKEN_DURATION"
+)
+
+var configDefaults = map[string]interface{}{
+	"DB_DRIVER":              "postgres",
+	"DB_SOURCE":              "",
+	"HTTP_SERVER_ADDRESS":    "0.0.0.0:4000",
+	"GRPC_SERVER_ADDRESS":    "0.0.0.0:4001",
+	"TOKEN_SYMMETRIC_KEY":    "",
+	"ACCESS_TOKEN_DURATION":  15 * time.Minute,
+	"REFRESH_TOKEN_DURATION": 24 * time.Hour,
+	"MIGRATION_URL":          "file://db/migration",
+	"ENVIRONMENT":            "development",
+	"REDIS_ADDRESS":          "0.0.0.0:6379",
+	"TRACING_EXPORTER":       "none",
+	"OTLP_ENDPOINT":          "0.0.0.0:4317",
+	"LOG_LEVEL":              "info",
+	"RATE_LIMIT_RPS":         0,
+	"RATE_LIMIT_BURST":       0,
+	"KYC_STORAGE_DIR":        "./data/kyc",
+
+	"RISK_VELOCITY_WINDOW":        time.Hour,
+	"RISK_VELOCITY_MAX_TRANSFERS": 10,
+	"RISK_KNOWN_DEVICE_AGE":       24 * time.Hour,
+	"RISK_BLOCK_HITS":             3,
+}
+
+// LoadConfig reads configuration from the files in path and the environment,
+// in increasing order of precedence:
+//
+//	built-in defaults
+//	.env
+//	config.yaml
+//	config.<ENVIRONMENT>.yaml
+//	environment variables
+//	files named by <KEY>_FILE environment variables
+//
+// Every file is optional. The result is validated and all problems are
+// reported together.
+func LoadConfig(path string) (config Config, err error) {
+
+	v := viper.New()
+	for key, value := range configDefaults {
+		v.SetDefault(key, value)
+	}
+
+	if err = mergeConfigFile(v, filepath.Join(path, dotEnvFile), "env"); err != nil {
+		return
+	}
+
+	if err = mergeConfigFile(v, filepath.Join(path, baseConfigFile), "yaml"); err != nil {
+		return
+	}
+
+	if err = mergeConfigFile(v, filepath.Join(path, profileConfigFile(v)), "yaml"); err != nil {
+		return
+	}
+
+	v.AutomaticEnv()
+
+	if err = readSecretFiles(v); err != nil {
+		return
+	}
+
+	applyLegacyKeys(v)
+
+	if err = v.Unmarshal(&config); err != nil {
 		return
 	}
 
-	err = viper.Unmarshal(&config)
+	err = config.Validate()
 	return
 }
+
+// profileConfigFile returns the name of the per environment file. The
+// ENVIRONMENT variable wins over the value read from the files so far.
+func profileConfigFile(v *viper.Viper) string {
+	environment := v.GetString("ENVIRONMENT")
+	if value, ok := os.LookupEnv("ENVIRONMENT"); ok {
+		environment = value
+	}
+
+	return fmt.Sprintf("config.%s.yaml", environment)
+}
+
+func mergeConfigFile(v *viper.Viper, filename string, configType string) error {
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("cannot read config file %s: %w", filename, err)
+	}
+
+	v.SetConfigType(configType)
+	if err = v.MergeConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("cannot parse config file %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+func readSecretFiles(v *viper.Viper) error {
+
+	for key := range configDefaults {
+		filename, ok := os.LookupEnv(key + secretFileSuffix)
+		if !ok || filename == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return fmt.Errorf("cannot read %s%s: %w", key, secretFileSuffix, err)
+		}
+
+		v.Set(key, strings.TrimSpace(string(data)))
+	}
+
+	return nil
+}
+
+func applyLegacyKeys(v *viper.Viper) {
+
+	_, newKeyInEnv := os.LookupEnv("REFRESH_TOKEN_DURATION")
+	if v.IsSet(legacyRefreshTokenDurationKey) && !newKeyInEnv && !v.InConfig("REFRESH_TOKEN_DURATION") {
+		v.Set("REFRESH_TOKEN_DURATION", v.Get(legacyRefreshTokenDurationKey))
+	}
+}
+
+// ValidationError lists every invalid setting found in a Config
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e, "; ")
+}
+
+var supportedTracingExporters = map[string]bool{
+	"none":   true,
+	"stdout": true,
+	"memory": true,
+	"otlp":   true,
+}
+
+// Validate checks every setting and returns a ValidationError listing all problems
+func (config Config) Validate() error {
+
+	var violations ValidationError
+	addf := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if config.DBDriver == "" {
+		addf("DB_DRIVER is required")
+	}
+
+	if config.DBSource == "" {
+		addf("DB_SOURCE is required")
+	}
+
+	for _, address := range []struct {
+		key   string
+		value string
+	}{
+		{"HTTP_SERVER_ADDRESS", config.HttServerAddress},
+		{"GRPC_SERVER_ADDRESS", config.GrpcServerAddress},
+		{"REDIS_ADDRESS", config.RedisAddress},
+	} {
+		if _, _, err := net.SplitHostPort(address.value); err != nil {
+			addf("%s must be a host:port address", address.key)
+		}
+	}
+
+	if len(config.TokenSymmetricKey) != 32 {
+		addf("TOKEN_SYMMETRIC_KEY must be exactly 32 characters")
+	}
+
+	if config.AccessTokenDuration <= 0 {
+		addf("ACCESS_TOKEN_DURATION must be positive")
+	}
+
+	if config.RefreshTokenDuration <= config.AccessTokenDuration {
+		addf("REFRESH_TOKEN_DURATION must be longer than ACCESS_TOKEN_DURATION")
+	}
+
+	if config.DbMigrationURL == "" {
+		addf("MIGRATION_URL is required")
+	}
+
+	if config.Environment == "" {
+		addf("ENVIRONMENT is required")
+	}
+
+	if !supportedTracingExporters[config.TracingExporter] {
+		addf("TRACING_EXPORTER %q is not supported", config.TracingExporter)
+	}
+
+	if config.KYCStorageDir == "" {
+		addf("KYC_STORAGE_DIR is required")
+	}
+
+	if config.TracingExporter == "otlp" && config.OTLPEndpoint == "" {
+		addf("OTLP_ENDPOINT is required when TRACING_EXPORTER is otlp")
+	}
+
+	if _, err := zerolog.ParseLevel(config.LogLevel); err != nil {
+		addf("LOG_LEVEL %q is not a valid level", config.LogLevel)
+	}
+
+	if config.RateLimitRPS < 0 {
+		addf("RATE_LIMIT_RPS must not be negative")
+	}
+
+	if config.RateLimitRPS > 0 && config.RateLimitBurst <= 0 {
+		addf("RATE_LIMIT_BURST must be positive when RATE_LIMIT_RPS is set")
+	}
+
+	if config.RiskVelocityMaxTransfers < 0 {
+		addf("RISK_VELOCITY_MAX_TRANSFERS must not be negative")
+	}
+
+	if config.RiskVelocityMaxTransfers > 0 && config.RiskVelocityWindow <= 0 {
+		addf("RISK_VELOCITY_WINDOW must be positive when RISK_VELOCITY_MAX_TRANSFERS is set")
+	}
+
+	if config.RiskKnownDeviceAge < 0 {
+		addf("RISK_KNOWN_DEVICE_AGE must not be negative")
+	}
+
+	if config.RiskBlockHits < 0 {
+		addf("RISK_BLOCK_HITS must not be negative")
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+	return nil
+}
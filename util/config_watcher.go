@@ -0,0 +1,124 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// reloadDebounce groups the burst of events editors and kubernetes produce
+// when a file is saved into a single reload
+const reloadDebounce = 500 * time.Millisecond
+
+// WatchConfig reloads the configuration whenever one of the config files in
+// path changes and calls onReload with the result. Only fields tagged
+// reload:"safe" are taken from the new configuration; changes to any other
+// field are logged and need a restart. An invalid configuration is logged and
+// the current one is kept. WatchConfig returns when ctx is cancelled.
+func WatchConfig(ctx context.Context, path string, current Config, onReload func(Config)) error {
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("cannot create config watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// watch the directory rather than the files so atomic renames and
+	// kubernetes ConfigMap symlink swaps are seen as well
+	if err = watcher.Add(path); err != nil {
+		return fmt.Errorf("cannot watch config directory %s: %w", path, err)
+	}
+
+	timer := time.NewTimer(reloadDebounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isConfigFile(event.Name) {
+				timer.Reset(reloadDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Error().Err(err).Msg("config watcher error")
+
+		case <-timer.C:
+			next, err := LoadConfig(path)
+			if err != nil {
+				log.Error().Err(err).Msg("cannot reload config, keeping the current one")
+				continue
+			}
+
+			merged, restartRequired := mergeReloadable(current, next)
+			if len(restartRequired) > 0 {
+				log.Warn().Strs("settings", restartRequired).Msg("config changes require a restart and were ignored")
+			}
+
+			if merged == current {
+				continue
+			}
+
+			current = merged
+			log.Info().Msg("config reloaded")
+			onReload(current)
+		}
+	}
+}
+
+func isConfigFile(name string) bool {
+
+	base := filepath.Base(name)
+	switch {
+	case base == dotEnvFile, base == baseConfigFile:
+		return true
+	case strings.HasPrefix(base, "config.") && strings.HasSuffix(base, ".yaml"):
+		return true
+	case base == "..data":
+		// kubernetes swaps this symlink when a mounted ConfigMap changes
+		return true
+	}
+	return false
+}
+
+// mergeReloadable copies the reload:"safe" fields of next into current. It
+// also returns the keys of the other fields whose value changed.
+func mergeReloadable(current Config, next Config) (Config, []string) {
+
+	var restartRequired []string
+
+	merged := reflect.ValueOf(&current).Elem()
+	updated := reflect.ValueOf(next)
+	fields := merged.Type()
+
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		if reflect.DeepEqual(merged.Field(i).Interface(), updated.Field(i).Interface()) {
+			continue
+		}
+
+		if field.Tag.Get("reload") != "safe" {
+			restartRequired = append(restartRequired, field.Tag.Get("mapstructure"))
+			continue
+		}
+
+		merged.Field(i).Set(updated.Field(i))
+	}
+
+	return current, restartRequired
+}
@@ -1,18 +1,10 @@
 package util
 
+// Codes of the currencies seeded by the migrations. Which currencies are
+// accepted is decided by the currencies table, see the currency package.
 const (
 	USD = "USD"
 	EUR = "EUR"
 	NGN = "NGN"
 	CAD = "CAD"
 )
-
-// IsSupprtedCurrency returns true if the currency is supported
-func IsSupprtedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, NGN, CAD:
-		return true
-	}
-
-	return false
-}
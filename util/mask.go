@@ -0,0 +1,17 @@
+package util
+
+import "strings"
+
+// MaskName hides all but the first letter of every word of a full name, so a
+// sender can confirm who they are paying without learning the whole name:
+// "Ada Lovelace" becomes "A** L*******"
+func MaskName(fullName string) string {
+
+	words := strings.Fields(fullName)
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+
+	return strings.Join(words, " ")
+}
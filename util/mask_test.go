@@ -0,0 +1,23 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskName(t *testing.T) {
+
+	testCases := map[string]string{
+		"Ada Lovelace":      "A** L*******",
+		"  Ada   Lovelace ": "A** L*******",
+		"Chukwuemeka":       "C**********",
+		"J R R Tolkien":     "J R R T******",
+		"Émile Zola":        "É**** Z***",
+		"":                  "",
+	}
+
+	for name, masked := range testCases {
+		require.Equal(t, masked, MaskName(name), name)
+	}
+}
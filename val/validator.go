@@ -1,9 +1,23 @@
 package val
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/mail"
 	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of the details a client can attach to a transfer
+const (
+	MaxTransferMemoLength          = 140
+	MaxTransferReferenceLength     = 140
+	MaxTransferMetadataKeys        = 20
+	MaxTransferMetadataKeyLength   = 40
+	MaxTransferMetadataValueLength = 500
+	// MaxTransferMetadataSize is the size of the metadata encoded as JSON
+	MaxTransferMetadataSize = 4096
 )
 
 var (
@@ -60,3 +74,63 @@ func ValidateFullname(value string) error {
 	}
 	return nil
 }
+
+// ValidateText checks that value is UTF-8 without control characters, which
+// covers line breaks and tabs
+func ValidateText(value string) error {
+	if !utf8.ValidString(value) {
+		return fmt.Errorf("must be valid UTF-8")
+	}
+
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("must not contain control characters")
+		}
+	}
+	return nil
+}
+
+func ValidateTransferMemo(value string) error {
+	if err := ValidateString(value, 0, MaxTransferMemoLength); err != nil {
+		return err
+	}
+	return ValidateText(value)
+}
+
+func ValidateTransferReference(value string) error {
+	if err := ValidateString(value, 0, MaxTransferReferenceLength); err != nil {
+		return err
+	}
+	return ValidateText(value)
+}
+
+func ValidateTransferMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxTransferMetadataKeys {
+		return fmt.Errorf("must have at most %d keys", MaxTransferMetadataKeys)
+	}
+
+	for key, value := range metadata {
+		if err := ValidateString(key, 1, MaxTransferMetadataKeyLength); err != nil {
+			return fmt.Errorf("key %q %w", key, err)
+		}
+		if err := ValidateText(key); err != nil {
+			return fmt.Errorf("key %q %w", key, err)
+		}
+
+		if err := ValidateString(value, 0, MaxTransferMetadataValueLength); err != nil {
+			return fmt.Errorf("value of %q %w", key, err)
+		}
+		if err := ValidateText(value); err != nil {
+			return fmt.Errorf("value of %q %w", key, err)
+		}
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if len(encoded) > MaxTransferMetadataSize {
+		return fmt.Errorf("must be at most %d bytes encoded as JSON", MaxTransferMetadataSize)
+	}
+	return nil
+}
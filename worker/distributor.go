@@ -2,6 +2,8 @@ package worker
 
 import (
 	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
 	"github.com/hibiken/asynq"
 )
 
@@ -11,10 +13,38 @@ type TaskDistributor interface {
 		payload *PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskTransferCompleted(
+		ctx context.Context,
+		payload *PayloadTransferCompleted,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskProcessBatchTransfer(
+		ctx context.Context,
+		payload *PayloadProcessBatchTransfer,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskAccrueInterest(
+		ctx context.Context,
+		payload *PayloadAccrueInterest,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskPaymentRequestSettled(
+		ctx context.Context,
+		payload *PayloadPaymentRequestSettled,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNewDeviceLogin(
+		ctx context.Context,
+		payload *PayloadNewDeviceLogin,
+		opts ...asynq.Option,
+	) error
+	Ping(ctx context.Context) error
+	Close() error
 }
 
 type RedisTaskTaskDistributor struct {
 	client *asynq.Client
+	redis  redis.UniversalClient
 }
 
 func NewRedisTaskTaskDistributor(redisOpt asynq.RedisClientOpt) TaskDistributor {
@@ -23,5 +53,22 @@ func NewRedisTaskTaskDistributor(redisOpt asynq.RedisClientOpt) TaskDistributor
 
 	return &RedisTaskTaskDistributor{
 		client: client,
+		redis:  redisOpt.MakeRedisClient().(redis.UniversalClient),
+	}
+}
+
+// Ping checks that the redis server tasks are enqueued to is reachable
+func (distributor *RedisTaskTaskDistributor) Ping(ctx context.Context) error {
+	if err := distributor.redis.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("cannot reach redis: %w", err)
+	}
+	return nil
+}
+
+// Close releases the redis connections held by the distributor
+func (distributor *RedisTaskTaskDistributor) Close() error {
+	if err := distributor.client.Close(); err != nil {
+		return err
 	}
+	return distributor.redis.Close()
 }
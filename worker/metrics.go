@@ -0,0 +1,23 @@
+package worker
+
+import (
+	"context"
+	"github.com/hibiken/asynq"
+	"github.com/joefazee/simplebank/metrics"
+)
+
+func taskMetrics(m *metrics.Metrics) asynq.MiddlewareFunc {
+	return func(next asynq.Handler) asynq.Handler {
+		return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+
+			err := next.ProcessTask(ctx, task)
+
+			m.TasksProcessed.WithLabelValues(task.Type()).Inc()
+			if err != nil {
+				m.TasksFailed.WithLabelValues(task.Type()).Inc()
+			}
+
+			return err
+		})
+	}
+}
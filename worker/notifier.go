@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+// NotificationSender delivers notifications on a channel outside the app
+type NotificationSender interface {
+	Send(ctx context.Context, user db.User, notification db.Notification) error
+}
+
+// LogSender only logs the notifications it is given. It stands in for the
+// email and SMS providers, which are not set up yet.
+type LogSender struct {
+	Channel string
+}
+
+func (sender LogSender) Send(ctx context.Context, user db.User, notification db.Notification) error {
+	log.Info().Str("channel", sender.Channel).Str("username", user.Username).Str("email", user.Email).
+		Str("event_type", notification.EventType).Str("title", notification.Title).Msg("sent notification")
+	return nil
+}
+
+// notification is what a user is told about an event
+type notification struct {
+	eventType string
+	title     string
+	body      string
+	data      interface{}
+	// dedupKey is unique per event and user, so a retried task notifies once
+	dedupKey string
+}
+
+// notify delivers a notification on every channel the preferences of the
+// user allow. Every notification is stored, whether or not it shows in the
+// inbox, so it is skipped when the task is retried after it was stored.
+func (processor *RedisTaskProcessor) notify(ctx context.Context, username string, n notification) error {
+
+	preferences, err := processor.store.ListNotificationPreferences(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	data, err := json.Marshal(n.data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification data: %w", err)
+	}
+
+	stored, err := processor.store.CreateNotification(ctx, db.CreateNotificationParams{
+		Username:  username,
+		EventType: n.eventType,
+		Title:     n.title,
+		Body:      n.body,
+		Data:      data,
+		InApp:     db.NotificationEnabled(preferences, n.eventType, db.ChannelInApp),
+		DedupKey:  n.dedupKey,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	var user db.User
+	for _, channel := range db.NotificationChannels {
+		sender, ok := processor.senders[channel]
+		if !ok || !db.NotificationEnabled(preferences, n.eventType, channel) {
+			continue
+		}
+
+		if user.Username == "" {
+			user, err = processor.store.GetUser(ctx, username)
+			if err != nil {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+		}
+
+		// the notification is stored already, so a failed channel is not retried
+		if err = sender.Send(ctx, user, stored); err != nil {
+			log.Error().Err(err).Str("channel", channel).Int64("notification_id", stored.ID).Msg("failed to send notification")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+const (
+	outboxPollInterval = time.Second
+	outboxBatchSize    = 100
+)
+
+// OutboxRelay moves committed outbox events to every registered publisher
+type OutboxRelay struct {
+	store      db.Store
+	publishers []EventPublisher
+}
+
+func NewOutboxRelay(store db.Store, publishers ...EventPublisher) *OutboxRelay {
+	return &OutboxRelay{
+		store:      store,
+		publishers: publishers,
+	}
+}
+
+// Start polls the outbox until ctx is cancelled
+func (relay *OutboxRelay) Start(ctx context.Context) error {
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			result, err := relay.RelayPending(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to relay outbox events")
+				continue
+			}
+
+			if len(result.Published) > 0 || len(result.Failed) > 0 {
+				log.Info().Int("published", len(result.Published)).
+					Int("failed", len(result.Failed)).Msg("relayed outbox events")
+			}
+		}
+	}
+}
+
+// RelayPending publishes one batch of pending events. An event is only marked
+// as published once every publisher has accepted it.
+func (relay *OutboxRelay) RelayPending(ctx context.Context) (db.PublishOutboxTxResult, error) {
+
+	return relay.store.PublishOutboxTx(ctx, db.PublishOutboxTxParams{
+		Limit: outboxBatchSize,
+		Publish: func(event db.Outbox) error {
+			return relay.publish(ctx, event)
+		},
+	})
+}
+
+// publish runs in the trace of the transaction that wrote the event
+func (relay *OutboxRelay) publish(ctx context.Context, event db.Outbox) error {
+
+	var carrier propagation.MapCarrier
+	if err := json.Unmarshal(event.TraceContext, &carrier); err == nil {
+		ctx = tracing.Extract(ctx, carrier)
+	}
+
+	ctx, span := tracer.Start(ctx, "outbox.publish "+event.EventType,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("event.type", event.EventType),
+			attribute.Int64("event.id", event.ID),
+		),
+	)
+	defer span.End()
+
+	for _, publisher := range relay.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			log.Error().Err(err).Str("event_type", event.EventType).
+				Int64("event_id", event.ID).Msg("failed to publish outbox event")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+	}
+	return nil
+}
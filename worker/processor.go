@@ -3,7 +3,9 @@ package worker
 import (
 	"context"
 	"github.com/hibiken/asynq"
+	"github.com/joefazee/simplebank/currency"
 	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/metrics"
 )
 
 const (
@@ -15,15 +17,25 @@ const (
 
 type TaskProcessor interface {
 	Start() error
+	Shutdown()
 	ProcessTaskVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskTransferCompleted(ctx context.Context, task *asynq.Task) error
+	ProcessTaskBatchTransfer(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskPaymentRequestSettled(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNewDeviceLogin(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
+	server     *asynq.Server
+	store      db.Store
+	metrics    *metrics.Metrics
+	currencies *currency.Registry
+	// senders deliver notifications by channel; the in-app inbox needs none
+	senders map[string]NotificationSender
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, m *metrics.Metrics, currencies *currency.Registry) TaskProcessor {
 
 	return &RedisTaskProcessor{
 		server: asynq.NewServer(redisOpt, asynq.Config{
@@ -32,12 +44,29 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store) TaskPr
 				QueueDefault:  QueuePriorityLower,
 			},
 		}),
-		store: store,
+		store:      store,
+		metrics:    m,
+		currencies: currencies,
+		senders: map[string]NotificationSender{
+			db.ChannelEmail: LogSender{Channel: db.ChannelEmail},
+			db.ChannelSMS:   LogSender{Channel: db.ChannelSMS},
+		},
 	}
 }
 
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
+	mux.Use(taskTracing, taskMetrics(processor.metrics))
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskVerifyEmail)
+	mux.HandleFunc(TaskTransferCompleted, processor.ProcessTaskTransferCompleted)
+	mux.HandleFunc(TaskProcessBatchTransfer, processor.ProcessTaskBatchTransfer)
+	mux.HandleFunc(TaskAccrueInterest, processor.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskPaymentRequestSettled, processor.ProcessTaskPaymentRequestSettled)
+	mux.HandleFunc(TaskNewDeviceLogin, processor.ProcessTaskNewDeviceLogin)
 	return processor.server.Start(mux)
 }
+
+// Shutdown stops pulling new tasks and waits for the running ones to finish
+func (processor *RedisTaskProcessor) Shutdown() {
+	processor.server.Shutdown()
+}
@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// dedupRetention is how long asynq keeps a finished task around so a
+// redelivered event with the same task ID is rejected as a duplicate.
+const dedupRetention = 24 * time.Hour
+
+// EventPublisher delivers outbox events to a sink. The outbox relay delivers
+// at least once, so implementations must be idempotent on event.DedupKey.
+type EventPublisher interface {
+	Publish(ctx context.Context, event db.Outbox) error
+}
+
+// TaskEventPublisher turns outbox events into asynq tasks
+type TaskEventPublisher struct {
+	distributor TaskDistributor
+}
+
+func NewTaskEventPublisher(distributor TaskDistributor) EventPublisher {
+	return &TaskEventPublisher{
+		distributor: distributor,
+	}
+}
+
+func (publisher *TaskEventPublisher) Publish(ctx context.Context, event db.Outbox) error {
+
+	opts := []asynq.Option{
+		asynq.TaskID(event.DedupKey),
+		asynq.Retention(dedupRetention),
+	}
+
+	var err error
+	switch event.EventType {
+	case db.EventUserCreated:
+		var payload PayloadSendVerifyEmail
+		if err = json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+
+		opts = append(opts,
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10*time.Second),
+			asynq.Queue(QueueCritical),
+		)
+		err = publisher.distributor.DistributeTaskSendVerifyEmail(ctx, &payload, opts...)
+
+	case db.EventTransferCompleted:
+		var payload PayloadTransferCompleted
+		if err = json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+
+		opts = append(opts, asynq.Queue(QueueDefault))
+		err = publisher.distributor.DistributeTaskTransferCompleted(ctx, &payload, opts...)
+
+	case db.EventBatchTransferCreated:
+		var payload PayloadProcessBatchTransfer
+		if err = json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+
+		opts = append(opts,
+			asynq.MaxRetry(10),
+			asynq.Queue(QueueCritical),
+		)
+		err = publisher.distributor.DistributeTaskProcessBatchTransfer(ctx, &payload, opts...)
+
+	case db.EventPaymentRequestSettled:
+		var payload PayloadPaymentRequestSettled
+		if err = json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+
+		opts = append(opts, asynq.Queue(QueueDefault))
+		err = publisher.distributor.DistributeTaskPaymentRequestSettled(ctx, &payload, opts...)
+
+	case db.EventNewDeviceLogin:
+		var payload PayloadNewDeviceLogin
+		if err = json.Unmarshal(event.Payload, &payload); err != nil {
+			return fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+
+		opts = append(opts, asynq.Queue(QueueCritical))
+		err = publisher.distributor.DistributeTaskNewDeviceLogin(ctx, &payload, opts...)
+
+	default:
+		log.Warn().Str("event_type", event.EventType).Int64("event_id", event.ID).Msg("no task for event type")
+		return nil
+	}
+
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+
+	return err
+}
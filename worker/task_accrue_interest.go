@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/interest"
+	"github.com/joefazee/simplebank/tracing"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+const TaskAccrueInterest = "task:accrue_interest"
+
+// dayLayout is how days are written in task payloads and IDs
+const dayLayout = "2006-01-02"
+
+// accrualDelay leaves room after midnight for transfers committed just before it
+const accrualDelay = 5 * time.Minute
+
+type PayloadAccrueInterest struct {
+	TraceCarrier
+	// Day is the UTC day to accrue, formatted as 2006-01-02
+	Day string `json:"day"`
+}
+
+func (distributor *RedisTaskTaskDistributor) DistributeTaskAccrueInterest(
+	ctx context.Context,
+	payload *PayloadAccrueInterest,
+	opts ...asynq.Option,
+) error {
+
+	payload.TraceContext = tracing.Inject(ctx)
+	taskPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskAccrueInterest, taskPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("unable to enqeueu task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+// ProcessTaskAccrueInterest accrues the interest of a day and, on the last day
+// of a month, posts the month. Both steps skip what was already done, so the
+// task can be retried or enqueued again for the same day.
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+
+	var payload PayloadAccrueInterest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	day, err := time.Parse(dayLayout, payload.Day)
+	if err != nil {
+		return fmt.Errorf("invalid day %q: %w", payload.Day, asynq.SkipRetry)
+	}
+
+	// the month is posted from every day of it, even those whose own task
+	// has not run yet
+	accrue := interest.Accrue
+	if interest.IsMonthEnd(day) {
+		accrue = interest.AccrueMonthToDate
+	}
+
+	result, err := accrue(ctx, processor.store, day)
+	if err != nil {
+		return fmt.Errorf("failed to accrue interest: %w", err)
+	}
+
+	logger := log.Info().Str("type", task.Type()).Str("day", payload.Day).
+		Int("accounts", result.Accounts).Int("accrued", result.Accrued)
+
+	if interest.IsMonthEnd(day) {
+		posted, err := interest.PostMonth(ctx, processor.store, day)
+		if err != nil {
+			return fmt.Errorf("failed to post interest: %w", err)
+		}
+		logger = logger.Int("posted", posted)
+	}
+
+	logger.Msg("task processed")
+	return nil
+}
+
+// ScheduleInterestAccrual enqueues the accrual of every day since the last
+// accrued one when it starts and shortly after every midnight until ctx is
+// done, so days missed while no worker was running are caught up. Task IDs are
+// derived from the day, so several workers scheduling the same day enqueue it once.
+func ScheduleInterestAccrual(ctx context.Context, store db.Store, distributor TaskDistributor) {
+
+	for {
+		now := time.Now().UTC()
+
+		// on error the days still hold yesterday, which is always due
+		days, err := interest.DaysToAccrue(ctx, store, now)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list the days to accrue interest for")
+		}
+		for _, day := range days {
+			enqueueInterestAccrual(ctx, distributor, day)
+		}
+
+		next := interest.Day(now).AddDate(0, 0, 1).Add(accrualDelay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+		}
+	}
+}
+
+func enqueueInterestAccrual(ctx context.Context, distributor TaskDistributor, day time.Time) {
+
+	payload := &PayloadAccrueInterest{Day: day.Format(dayLayout)}
+
+	err := distributor.DistributeTaskAccrueInterest(ctx, payload,
+		asynq.TaskID(fmt.Sprintf("%s:%s", TaskAccrueInterest, payload.Day)),
+		asynq.Retention(48*time.Hour),
+		asynq.MaxRetry(10),
+		asynq.Queue(QueueDefault),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		log.Error().Err(err).Str("day", payload.Day).Msg("failed to schedule interest accrual")
+	}
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskNewDeviceLogin = "task:new_device_login"
+
+type PayloadNewDeviceLogin struct {
+	TraceCarrier
+	Username  string `json:"username"`
+	SessionID string `json:"session_id"`
+	UserAgent string `json:"user_agent"`
+	ClientIP  string `json:"client_ip"`
+}
+
+func (distributor *RedisTaskTaskDistributor) DistributeTaskNewDeviceLogin(
+	ctx context.Context,
+	payload *PayloadNewDeviceLogin,
+	opts ...asynq.Option,
+) error {
+
+	payload.TraceContext = tracing.Inject(ctx)
+	taskPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskNewDeviceLogin, taskPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("unable to enqeueu task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+// ProcessTaskNewDeviceLogin warns a user about a login from a device they never used
+func (processor *RedisTaskProcessor) ProcessTaskNewDeviceLogin(ctx context.Context, task *asynq.Task) error {
+
+	var payload PayloadNewDeviceLogin
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	err := processor.notify(ctx, payload.Username, notification{
+		eventType: db.NotificationNewDeviceLogin,
+		title:     "New login",
+		body: fmt.Sprintf("Your account was signed in from %s at %s. If this was not you, change your password",
+			payload.UserAgent, payload.ClientIP),
+		data:     map[string]string{"session_id": payload.SessionID},
+		dedupKey: fmt.Sprintf("%s:%s", db.NotificationNewDeviceLogin, payload.SessionID),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("username", payload.Username).Msg("task processed")
+
+	return nil
+}
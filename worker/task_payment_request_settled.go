@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPaymentRequestSettled = "task:payment_request_settled"
+
+type PayloadPaymentRequestSettled struct {
+	TraceCarrier
+	RequestID  int64  `json:"request_id"`
+	Requester  string `json:"requester"`
+	Status     string `json:"status"`
+	TransferID int64  `json:"transfer_id,omitempty"`
+}
+
+func (distributor *RedisTaskTaskDistributor) DistributeTaskPaymentRequestSettled(
+	ctx context.Context,
+	payload *PayloadPaymentRequestSettled,
+	opts ...asynq.Option,
+) error {
+
+	payload.TraceContext = tracing.Inject(ctx)
+	taskPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskPaymentRequestSettled, taskPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("unable to enqeueu task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+// ProcessTaskPaymentRequestSettled tells the requester that their payment
+// request was paid or declined
+func (processor *RedisTaskProcessor) ProcessTaskPaymentRequestSettled(ctx context.Context, task *asynq.Task) error {
+
+	var payload PayloadPaymentRequestSettled
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	request, err := processor.store.GetPaymentRequest(ctx, payload.RequestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("unable to find the payment request in the database: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get payment request: %w", err)
+	}
+
+	title, verb := "Payment request paid", "paid"
+	if request.Status == db.PaymentRequestStatusDeclined {
+		title, verb = "Payment request declined", "declined"
+	}
+
+	err = processor.notify(ctx, request.Requester, notification{
+		eventType: db.NotificationPaymentRequestSettled,
+		title:     title,
+		body: fmt.Sprintf("%s %s your request for %s", request.Payer, verb,
+			processor.currencies.Format(request.Currency, request.Amount)),
+		data:     map[string]int64{"request_id": request.ID, "transfer_id": request.TransferID.Int64},
+		dedupKey: fmt.Sprintf("%s:%d", db.NotificationPaymentRequestSettled, request.ID),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int64("request_id", request.ID).Str("status", request.Status).Msg("task processed")
+
+	return nil
+}
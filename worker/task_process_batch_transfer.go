@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskProcessBatchTransfer = "task:process_batch_transfer"
+
+type PayloadProcessBatchTransfer struct {
+	TraceCarrier
+	BatchID int64 `json:"batch_id"`
+}
+
+func (distributor *RedisTaskTaskDistributor) DistributeTaskProcessBatchTransfer(
+	ctx context.Context,
+	payload *PayloadProcessBatchTransfer,
+	opts ...asynq.Option,
+) error {
+
+	payload.TraceContext = tracing.Inject(ctx)
+	taskPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskProcessBatchTransfer, taskPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("unable to enqeueu task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+// ProcessTaskBatchTransfer pays the pending rows of a batch. Rows that were
+// already paid are skipped, so a retried task picks up where it stopped.
+func (processor *RedisTaskProcessor) ProcessTaskBatchTransfer(ctx context.Context, task *asynq.Task) error {
+
+	var payload PayloadProcessBatchTransfer
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	batch, err := processor.store.GetBatchTransfer(ctx, payload.BatchID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("unable to find the batch transfer in the database: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get batch transfer: %w", err)
+	}
+
+	if batch.Status != db.BatchStatusPending && batch.Status != db.BatchStatusProcessing {
+		log.Info().Int64("batch_id", batch.ID).Str("status", batch.Status).Msg("batch transfer already processed")
+		return nil
+	}
+
+	batch, err = processor.store.UpdateBatchTransferStatus(ctx, db.UpdateBatchTransferStatusParams{
+		ID:     batch.ID,
+		Status: db.BatchStatusProcessing,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update batch transfer status: %w", err)
+	}
+
+	var rowErr *db.BatchRowError
+
+	if batch.Mode == db.BatchModeAllOrNothing {
+		_, err = processor.store.BatchTransferAllTx(ctx, db.BatchTransferAllTxParams{Batch: batch})
+		if err != nil && !errors.As(err, &rowErr) {
+			return fmt.Errorf("failed to process batch transfer: %w", err)
+		}
+	} else {
+		items, err := processor.store.ListPendingBatchTransferItems(ctx, batch.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list batch transfer items: %w", err)
+		}
+
+		for _, item := range items {
+			_, err = processor.store.BatchTransferItemTx(ctx, db.BatchTransferItemTxParams{
+				Batch:  batch,
+				ItemID: item.ID,
+			})
+			if err != nil && !errors.As(err, &rowErr) {
+				return fmt.Errorf("failed to process batch transfer row %d: %w", item.RowNumber, err)
+			}
+		}
+	}
+
+	counts, err := processor.store.CountBatchTransferItems(ctx, batch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count batch transfer items: %w", err)
+	}
+
+	if counts.Pending > 0 {
+		return fmt.Errorf("batch transfer %d still has %d pending rows", batch.ID, counts.Pending)
+	}
+
+	status := db.BatchStatusPartiallyCompleted
+	switch {
+	case counts.Failed == 0:
+		status = db.BatchStatusCompleted
+	case counts.Succeeded == 0:
+		status = db.BatchStatusFailed
+	}
+
+	batch, err = processor.store.CompleteBatchTransfer(ctx, db.CompleteBatchTransferParams{
+		ID:             batch.ID,
+		Status:         status,
+		SucceededCount: counts.Succeeded,
+		FailedCount:    counts.Failed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete batch transfer: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Int64("batch_id", batch.ID).Str("status", batch.Status).
+		Int32("succeeded", batch.SucceededCount).Int32("failed", batch.FailedCount).Msg("task processed")
+
+	return nil
+}
@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/hibiken/asynq"
+	"github.com/joefazee/simplebank/tracing"
 	"github.com/rs/zerolog/log"
 )
 
 const TaskSendVerifyEmail = "task:send_verify_email"
 
 type PayloadSendVerifyEmail struct {
+	TraceCarrier
 	Username string `json:"username"`
 }
 
@@ -21,6 +23,7 @@ func (distributor *RedisTaskTaskDistributor) DistributeTaskSendVerifyEmail(
 	opts ...asynq.Option,
 ) error {
 
+	payload.TraceContext = tracing.Inject(ctx)
 	taskPayload, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal task payload: %w", err)
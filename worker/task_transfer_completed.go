@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/hibiken/asynq"
+	db "github.com/joefazee/simplebank/db/sqlc"
+	"github.com/joefazee/simplebank/tracing"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskTransferCompleted = "task:transfer_completed"
+
+type PayloadTransferCompleted struct {
+	TraceCarrier
+	TransferID    int64 `json:"transfer_id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+}
+
+func (distributor *RedisTaskTaskDistributor) DistributeTaskTransferCompleted(
+	ctx context.Context,
+	payload *PayloadTransferCompleted,
+	opts ...asynq.Option,
+) error {
+
+	payload.TraceContext = tracing.Inject(ctx)
+	taskPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskTransferCompleted, taskPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("unable to enqeueu task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).Int("max_retry", info.MaxRetry).Msg("enqueued task")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskTransferCompleted(ctx context.Context, task *asynq.Task) error {
+
+	var payload PayloadTransferCompleted
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal task payload: %w", asynq.SkipRetry)
+	}
+
+	transfer, err := processor.store.GetTransfer(ctx, payload.TransferID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("unable to find the transfer in the database: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	if err = processor.notifyTransferReceived(ctx, transfer); err != nil {
+		return err
+	}
+
+	log.Info().Str("type", task.Type()).Bytes("payload", task.Payload()).
+		Int64("transfer_id", transfer.ID).Msg("task processed")
+
+	return nil
+}
+
+// notifyTransferReceived tells the members of the receiving account about a
+// transfer, unless they moved the money between their own accounts
+func (processor *RedisTaskProcessor) notifyTransferReceived(ctx context.Context, transfer db.Transfer) error {
+
+	account, err := processor.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	recipients, err := processor.store.ListAccountMembers(ctx, transfer.ToAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to list account members: %w", err)
+	}
+
+	senders, err := processor.store.ListAccountMembers(ctx, transfer.FromAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to list account members: %w", err)
+	}
+	isSender := make(map[string]bool, len(senders))
+	for _, member := range senders {
+		isSender[member.Username] = member.Status == db.MemberStatusActive
+	}
+
+	for _, member := range recipients {
+		if member.Status != db.MemberStatusActive || isSender[member.Username] {
+			continue
+		}
+
+		err = processor.notify(ctx, member.Username, notification{
+			eventType: db.NotificationTransferReceived,
+			title:     "Money received",
+			body: fmt.Sprintf("You received %s into account #%d",
+				processor.currencies.Format(account.Currency, transfer.Amount), account.ID),
+			data:     map[string]int64{"transfer_id": transfer.ID, "account_id": account.ID},
+			dedupKey: fmt.Sprintf("%s:%d:%s", db.NotificationTransferReceived, transfer.ID, member.Username),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hibiken/asynq"
+	"github.com/joefazee/simplebank/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/joefazee/simplebank/worker")
+
+// TraceCarrier is embedded in task payloads to carry the trace context of
+// the code that enqueued the task.
+type TraceCarrier struct {
+	TraceContext propagation.MapCarrier `json:"trace_context,omitempty"`
+}
+
+// taskTracing starts a span for every task, continuing the trace stored in the payload
+func taskTracing(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+
+		var carrier TraceCarrier
+		if err := json.Unmarshal(task.Payload(), &carrier); err == nil {
+			ctx = tracing.Extract(ctx, carrier.TraceContext)
+		}
+
+		ctx, span := tracer.Start(ctx, task.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attribute.String("task.type", task.Type())),
+		)
+		defer span.End()
+
+		err := next.ProcessTask(ctx, task)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	})
+}